@@ -17,6 +17,8 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	_ "github.com/openshift/cluster-network-operator/pkg/client"
+	"github.com/openshift/cluster-network-operator/pkg/cmd/mtuprober"
+	"github.com/openshift/cluster-network-operator/pkg/cmd/render"
 	"github.com/openshift/cluster-network-operator/pkg/version"
 
 	utilflag "k8s.io/component-base/cli/flag"
@@ -80,12 +82,17 @@ which is a kubeconfig from which to take just the URL to the apiserver`,
 	}
 
 	cmdcfg := controllercmd.NewControllerCommandConfig("network-operator", version.Get(), operator.RunOperator)
+	// The operator runs its own leader election so the lease is released on
+	// shutdown; library-go's would leave the next pod waiting out the lease.
+	cmdcfg.DisableLeaderElection = true
 
 	cmd2 := cmdcfg.NewCommand()
 	cmd2.Use = "start"
 	cmd2.Short = "Start the cluster network operator"
 
 	cmd.AddCommand(cmd2)
+	cmd.AddCommand(render.NewRenderCommand())
+	cmd.AddCommand(mtuprober.NewMTUProberCommand())
 
 	return cmd
 }
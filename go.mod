@@ -33,6 +33,7 @@ require (
 	k8s.io/api v0.21.1
 	k8s.io/apiextensions-apiserver v0.21.0
 	k8s.io/apimachinery v0.21.1
+	k8s.io/apiserver v0.21.0
 	k8s.io/client-go v0.21.0
 	k8s.io/code-generator v0.21.1
 	k8s.io/component-base v0.21.0
@@ -0,0 +1,132 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Server serves a validating admission webhook that runs the same
+// network.Validate and network.IsChangeSafe checks the operconfig controller
+// runs, so invalid or unsafe edits to Network.operator.openshift.io are
+// rejected at write time instead of silently degrading the operator minutes
+// later.
+type Server struct {
+	addr     string
+	certFile string
+	keyFile  string
+}
+
+// NewServer returns a webhook server listening on addr, serving TLS with the
+// given certificate and key.
+func NewServer(addr, certFile, keyFile string) *Server {
+	return &Server{addr: addr, certFile: certFile, keyFile: keyFile}
+}
+
+// Run serves the webhook until the context is cancelled.
+func (s *Server) Run(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/validate-network-config", ServeNetworkConfigValidation)
+
+	srv := &http.Server{
+		Addr:    s.addr,
+		Handler: mux,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Starting network config admission webhook on %s", s.addr)
+	err := srv.ListenAndServeTLS(s.certFile, s.keyFile)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// ServeNetworkConfigValidation handles AdmissionReview requests for
+// Network.operator.openshift.io objects.
+func ServeNetworkConfigValidation(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	review := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	response := validateNetworkConfig(review.Request)
+	response.UID = review.Request.UID
+	review.Response = response
+	review.Request = nil
+
+	buf, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode AdmissionReview: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(buf)
+}
+
+// validateNetworkConfig runs the controller's own safety checks against the
+// incoming object. On update, the previous object from the request is used as
+// the baseline for IsChangeSafe.
+func validateNetworkConfig(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	operConfig := &operv1.Network{}
+	if err := json.Unmarshal(req.Object.Raw, operConfig); err != nil {
+		return denied(fmt.Sprintf("failed to decode Network.operator.openshift.io: %v", err))
+	}
+
+	network.DeprecatedCanonicalize(&operConfig.Spec)
+	if err := network.Validate(&operConfig.Spec); err != nil {
+		return denied(fmt.Sprintf("invalid configuration: %v", err))
+	}
+
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		prevConfig := &operv1.Network{}
+		if err := json.Unmarshal(req.OldObject.Raw, prevConfig); err != nil {
+			return denied(fmt.Sprintf("failed to decode previous Network.operator.openshift.io: %v", err))
+		}
+		network.DeprecatedCanonicalize(&prevConfig.Spec)
+		network.FillDefaults(&prevConfig.Spec, &prevConfig.Spec)
+		network.FillDefaults(&operConfig.Spec, &prevConfig.Spec)
+		if err := network.IsChangeSafe(&prevConfig.Spec, &operConfig.Spec); err != nil {
+			return denied(fmt.Sprintf("unsafe configuration change: %v", err))
+		}
+	}
+
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+func denied(message string) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonInvalid,
+		},
+	}
+}
@@ -11,18 +11,22 @@ import (
 // a certificate signed by that CA. The certificate has both ClientAuth
 // and ServerAuth extended usages enabled.
 //
-//  More specifically, given an OperatorPKI with <name>, the CNO will manage:
+//	More specifically, given an OperatorPKI with <name>, the CNO will manage:
+//
 // - A Secret called <name>-ca with two data keys:
 //   - tls.key - the private key
 //   - tls.crt - the CA certificate
+//
 // - A ConfigMap called <name>-ca with a single data key:
 //   - cabundle.crt - the CA certificate(s)
+//
 // - A Secret called <name>-cert with two data keys:
 //   - tls.key - the private key
 //   - tls.crt - the certificate, signed by the CA
 //
-// The CA certificate will have a validity of 10 years, rotated after 9.
-// The target certificate will have a validity of 6 months, rotated after 3
+// By default, the CA certificate has a validity of 10 years, rotated after 9,
+// and the target certificate a validity of 6 months, rotated after 3. These
+// can be tuned via the spec.
 //
 // The CA certificate will have a CommonName of "<namespace>_<name>-ca@<timestamp>", where
 // <timestamp> is the last rotation time.
@@ -46,6 +50,39 @@ type OperatorPKISpec struct {
 	// targetCert configures the certificate signed by the CA. It will have
 	// both ClientAuth and ServerAuth enabled
 	TargetCert CertSpec `json:"targetCert"`
+
+	// signingDuration is the validity of the CA certificate. If unset,
+	// the CA is valid for 10 years.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	SigningDuration *metav1.Duration `json:"signingDuration,omitempty"`
+
+	// targetDuration is the validity of the target certificate. It must not
+	// exceed signingDuration. If unset, the target certificate is valid for
+	// 6 months.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	TargetDuration *metav1.Duration `json:"targetDuration,omitempty"`
+
+	// refreshPercent is the percentage of a certificate's validity after
+	// which it is rotated. If unset, the CA is rotated at 90% of its
+	// validity and the target certificate at 50%.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	RefreshPercent *int32 `json:"refreshPercent,omitempty"`
+
+	// keyAlgorithm selects the key algorithm for generated keys. The only
+	// supported value is "RSA-2048", which is also the default.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="";RSA-2048
+	// +optional
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
 }
 
 // CertSpec defines common certificate configuration.
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 // Code generated by deepcopy-gen. DO NOT EDIT.
@@ -5,6 +6,7 @@
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,7 +31,7 @@ func (in *OperatorPKI) DeepCopyInto(out *OperatorPKI) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 	return
 }
@@ -89,6 +91,21 @@ func (in *OperatorPKIList) DeepCopyObject() runtime.Object {
 func (in *OperatorPKISpec) DeepCopyInto(out *OperatorPKISpec) {
 	*out = *in
 	out.TargetCert = in.TargetCert
+	if in.SigningDuration != nil {
+		in, out := &in.SigningDuration, &out.SigningDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TargetDuration != nil {
+		in, out := &in.TargetDuration, &out.TargetDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RefreshPercent != nil {
+		in, out := &in.RefreshPercent, &out.RefreshPercent
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
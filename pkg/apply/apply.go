@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -18,6 +19,27 @@ import (
 // ApplyObject applies the desired object against the apiserver,
 // merging it with any existing objects if already present.
 func ApplyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstructured) error {
+	return ApplyObjectWithFieldManager(ctx, client, obj, DefaultFieldManager)
+}
+
+// ApplyObjectWithFieldManager is ApplyObject with a caller-chosen field
+// manager, so that each controller's writes are attributed (and, with
+// server-side apply, owned) separately. Applies are throttled to the
+// configured QPS budget and reported in the apply throughput metrics.
+func ApplyObjectWithFieldManager(ctx context.Context, client k8sclient.Client, obj *uns.Unstructured, fieldManager string) error {
+	applyRateLimiter.Accept()
+	start := time.Now()
+	err := applyObject(ctx, client, obj, fieldManager)
+	applyDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		applyTotal.WithLabelValues("failure").Inc()
+	} else {
+		applyTotal.WithLabelValues("success").Inc()
+	}
+	return err
+}
+
+func applyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstructured, fieldManager string) error {
 	name := obj.GetName()
 	namespace := obj.GetNamespace()
 	if name == "" {
@@ -32,6 +54,13 @@ func ApplyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstruct
 		return errors.Wrapf(err, "object %s unsupported", objDesc)
 	}
 
+	if serverSideApplyEnabled() {
+		if err := applyObjectServerSide(ctx, client, obj, fieldManager, objDesc); err != nil {
+			return errors.Wrapf(err, "ApplyObject of %s was unsuccessful", objDesc)
+		}
+		return nil
+	}
+
 	// Get existing
 	existing := &uns.Unstructured{}
 	existing.SetGroupVersionKind(gvk)
@@ -46,6 +75,7 @@ func ApplyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstruct
 				return err
 			}
 			log.Printf("successfully created %s", objDesc)
+			applyWrites.WithLabelValues("changed").Inc()
 			return nil
 		}
 		if err != nil {
@@ -58,13 +88,19 @@ func ApplyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstruct
 			log.Printf("could not merge %s with existing", objDesc)
 			return err
 		}
+		// Honor any fields an administrator asked us not to reconcile.
+		preserveUserFields(existing, obj, objDesc)
 		if !equality.Semantic.DeepEqual(existing, obj) {
+			logObjectDiff(objDesc, existing, obj)
 			if err := client.Update(ctx, obj); err != nil {
 				log.Printf("update of %s was unsuccessful", objDesc)
 				return err
 			} else {
 				log.Printf("update was successful")
 			}
+			applyWrites.WithLabelValues("changed").Inc()
+		} else {
+			applyWrites.WithLabelValues("noop").Inc()
 		}
 		return nil
 	})
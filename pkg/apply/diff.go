@@ -0,0 +1,78 @@
+package apply
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// diffVerbosity is the klog level at which per-object diffs are logged.
+// Raise the operator log level (e.g. via the operator spec loglevel) to see
+// exactly which fields each apply is changing.
+const diffVerbosity = 4
+
+// logObjectDiff logs a structured field-level diff between the live object
+// and the object about to be applied, to debug unnecessary write
+// amplification. It is a no-op unless diff logging is enabled.
+func logObjectDiff(objDesc string, live, desired *uns.Unstructured) {
+	if !klog.V(diffVerbosity).Enabled() {
+		return
+	}
+	diffs := diffValues("", live.Object, desired.Object)
+	if len(diffs) == 0 {
+		return
+	}
+	klog.V(diffVerbosity).InfoS("Applying object changes", "object", objDesc, "diff", diffs)
+}
+
+// diffValues recursively compares two unstructured values and returns one
+// "path: old -> new" entry per changed leaf. Slices are compared wholesale.
+func diffValues(path string, live, desired interface{}) []string {
+	if equality.Semantic.DeepEqual(live, desired) {
+		return nil
+	}
+
+	liveMap, liveOK := live.(map[string]interface{})
+	desiredMap, desiredOK := desired.(map[string]interface{})
+	if !liveOK || !desiredOK {
+		return []string{fmt.Sprintf("%s: %s -> %s", path, formatValue(live), formatValue(desired))}
+	}
+
+	keys := map[string]bool{}
+	for key := range liveMap {
+		keys[key] = true
+	}
+	for key := range desiredMap {
+		keys[key] = true
+	}
+
+	diffs := []string{}
+	for key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		liveVal, liveHas := liveMap[key]
+		desiredVal, desiredHas := desiredMap[key]
+		switch {
+		case !liveHas:
+			diffs = append(diffs, fmt.Sprintf("%s: <absent> -> %s", childPath, formatValue(desiredVal)))
+		case !desiredHas:
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> <absent>", childPath, formatValue(liveVal)))
+		default:
+			diffs = append(diffs, diffValues(childPath, liveVal, desiredVal)...)
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+func formatValue(val interface{}) string {
+	if val == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%v", val)
+}
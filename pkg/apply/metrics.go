@@ -0,0 +1,63 @@
+package apply
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// applyQPSEnv overrides the client-side QPS budget for apply API writes.
+// On bootstrap the operator applies hundreds of objects; the budget keeps
+// that burst from starving other clients of the apiserver.
+const applyQPSEnv = "CNO_APPLY_QPS"
+
+// defaultApplyQPS is the default write budget, with a burst of twice that.
+const defaultApplyQPS = 20
+
+// applyRateLimiter throttles apply API writes to the configured QPS budget.
+var applyRateLimiter = newApplyRateLimiter()
+
+func newApplyRateLimiter() flowcontrol.RateLimiter {
+	qps := float64(defaultApplyQPS)
+	if val := os.Getenv(applyQPSEnv); val != "" {
+		parsed, err := strconv.ParseFloat(val, 32)
+		if err != nil || parsed <= 0 {
+			log.Printf("ignoring invalid %s value %q", applyQPSEnv, val)
+		} else {
+			qps = parsed
+		}
+	}
+	return flowcontrol.NewTokenBucketRateLimiter(float32(qps), int(qps)*2)
+}
+
+// applyTotal counts apply operations by result, so aggregate apply throughput
+// can be graphed.
+var applyTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "cno_applies_total",
+	Help: "Total object apply operations, by result.",
+}, []string{"result"})
+
+// applyDuration observes the latency of individual apply operations,
+// including time spent waiting for the QPS budget.
+var applyDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Name:    "cno_apply_duration_seconds",
+	Help:    "Latency of individual object apply operations.",
+	Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+})
+
+// applyWrites distinguishes applies that actually mutated the object from
+// no-ops, to debug unnecessary write amplification.
+var applyWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "cno_apply_writes_total",
+	Help: "Apply operations that changed the object versus no-ops.",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(applyTotal)
+	prometheus.MustRegister(applyDuration)
+	prometheus.MustRegister(applyWrites)
+}
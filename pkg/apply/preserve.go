@@ -0,0 +1,89 @@
+package apply
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// preservedFields records, per object, which field paths were skipped during
+// the last apply because of the preserve-fields annotation, so the operator
+// can surface them in status.
+var preservedFields = struct {
+	sync.Mutex
+	m map[string][]string
+}{m: map[string][]string{}}
+
+// PreservedFields returns a snapshot of the field paths that were preserved
+// (not reconciled) on the last apply of each object, keyed by object
+// description.
+func PreservedFields() map[string][]string {
+	preservedFields.Lock()
+	defer preservedFields.Unlock()
+	out := map[string][]string{}
+	for desc, paths := range preservedFields.m {
+		out[desc] = append([]string{}, paths...)
+	}
+	return out
+}
+
+// preserveUserFields enforces the preserve-fields annotation on the live
+// object: for every declared path (dot-separated, comma-delimited, e.g.
+// "spec.replicas,spec.template.spec.containers"), the live value replaces the
+// rendered one in the desired object, so CNO never reconciles fields an
+// administrator has tuned. The preserved paths are logged and recorded for
+// status reporting.
+func preserveUserFields(existing, desired *uns.Unstructured, objDesc string) {
+	spec := existing.GetAnnotations()[names.PreserveFieldsAnnotation]
+
+	preserved := []string{}
+	for _, path := range strings.Split(spec, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		fields := strings.Split(path, ".")
+		if fields[0] == "metadata" || fields[0] == "status" {
+			// Metadata is already merged, and status is never applied.
+			continue
+		}
+		val, found, err := uns.NestedFieldCopy(existing.Object, fields...)
+		if err != nil {
+			log.Printf("ignoring unpreservable path %q on %s: %v", path, objDesc, err)
+			continue
+		}
+		if found {
+			if err := uns.SetNestedField(desired.Object, val, fields...); err != nil {
+				log.Printf("ignoring unpreservable path %q on %s: %v", path, objDesc, err)
+				continue
+			}
+		} else {
+			uns.RemoveNestedField(desired.Object, fields...)
+		}
+		preserved = append(preserved, path)
+	}
+
+	preservedFields.Lock()
+	defer preservedFields.Unlock()
+	if len(preserved) == 0 {
+		delete(preservedFields.m, objDesc)
+		return
+	}
+	log.Printf("not reconciling user-managed fields of %s: %s", objDesc, strings.Join(preserved, ", "))
+	preservedFields.m[objDesc] = preserved
+}
+
+// PreservedFieldsSummary flattens the recorded preserved paths into
+// human-readable entries for status reporting.
+func PreservedFieldsSummary() []string {
+	out := []string{}
+	for desc, paths := range PreservedFields() {
+		out = append(out, fmt.Sprintf("%s: %s", desc, strings.Join(paths, ", ")))
+	}
+	return out
+}
@@ -0,0 +1,191 @@
+package apply
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// nestedField looks up a dot-separated path in an unstructured object.
+func nestedField(obj map[string]interface{}, path string) (interface{}, bool, error) {
+	return uns.NestedFieldCopy(obj, strings.Split(path, ".")...)
+}
+
+func TestPreserveUserFields(t *testing.T) {
+	existingYAML := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: d1
+  namespace: ns
+  labels:
+    user: tuned
+spec:
+  replicas: 5
+  template:
+    spec:
+      nodeSelector:
+        disk: ssd
+status:
+  readyReplicas: 5`
+
+	desiredYAML := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: d1
+  namespace: ns
+spec:
+  replicas: 2
+  template:
+    spec:
+      nodeSelector:
+        kubernetes.io/os: linux
+  strategy:
+    type: Recreate`
+
+	testcases := []struct {
+		name       string
+		annotation string
+
+		// checks on the desired object after preservation, as path -> value;
+		// a nil value asserts the path is absent.
+		want          map[string]interface{}
+		wantPreserved []string
+	}{
+		{
+			name:       "scalar path",
+			annotation: "spec.replicas",
+			want: map[string]interface{}{
+				"spec.replicas": int64(5),
+				// unrelated fields still come from the rendered object
+				"spec.strategy.type": "Recreate",
+			},
+			wantPreserved: []string{"spec.replicas"},
+		},
+		{
+			name:       "nested path",
+			annotation: "spec.template.spec.nodeSelector",
+			want: map[string]interface{}{
+				// the whole live map wins, the rendered keys are gone
+				"spec.template.spec.nodeSelector": map[string]interface{}{"disk": "ssd"},
+				"spec.replicas":                   int64(2),
+			},
+			wantPreserved: []string{"spec.template.spec.nodeSelector"},
+		},
+		{
+			name:       "multiple paths with whitespace",
+			annotation: " spec.replicas , spec.template.spec.nodeSelector ,",
+			want: map[string]interface{}{
+				"spec.replicas":                        int64(5),
+				"spec.template.spec.nodeSelector.disk": "ssd",
+			},
+			wantPreserved: []string{"spec.replicas", "spec.template.spec.nodeSelector"},
+		},
+		{
+			name:       "path missing from the live object is removed",
+			annotation: "spec.strategy",
+			want: map[string]interface{}{
+				"spec.strategy": nil,
+				"spec.replicas": int64(2),
+			},
+			wantPreserved: []string{"spec.strategy"},
+		},
+		{
+			name:       "metadata and status paths are ignored",
+			annotation: "metadata.labels,status.readyReplicas",
+			want: map[string]interface{}{
+				"spec.replicas":   int64(2),
+				"metadata.labels": nil,
+			},
+			wantPreserved: nil,
+		},
+		{
+			name: "path through a scalar is ignored",
+			// spec.replicas is an integer, so descending through it fails;
+			// the valid entry is still honored.
+			annotation: "spec.replicas.max,spec.replicas",
+			want: map[string]interface{}{
+				"spec.replicas": int64(5),
+			},
+			wantPreserved: []string{"spec.replicas"},
+		},
+		{
+			name:          "empty annotation preserves nothing",
+			annotation:    "",
+			want:          map[string]interface{}{"spec.replicas": int64(2)},
+			wantPreserved: nil,
+		},
+	}
+
+	for i, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			existing := UnstructuredFromYaml(t, existingYAML)
+			desired := UnstructuredFromYaml(t, desiredYAML)
+			if tc.annotation != "" {
+				existing.SetAnnotations(map[string]string{names.PreserveFieldsAnnotation: tc.annotation})
+			}
+
+			objDesc := fmt.Sprintf("(apps/v1, Kind=Deployment) ns/d%d", i)
+			preserveUserFields(existing, desired, objDesc)
+
+			for path, want := range tc.want {
+				val, found, err := nestedField(desired.Object, path)
+				g.Expect(err).NotTo(HaveOccurred(), "path %s", path)
+				if want == nil {
+					g.Expect(found).To(BeFalse(), "path %s should be absent", path)
+				} else {
+					g.Expect(found).To(BeTrue(), "path %s should be present", path)
+					g.Expect(val).To(Equal(want), "path %s", path)
+				}
+			}
+
+			if tc.wantPreserved == nil {
+				g.Expect(PreservedFields()).NotTo(HaveKey(objDesc))
+			} else {
+				g.Expect(PreservedFields()).To(HaveKeyWithValue(objDesc, tc.wantPreserved))
+			}
+		})
+	}
+}
+
+func TestPreserveUserFieldsRecordCleared(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	existing := UnstructuredFromYaml(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+  namespace: ns
+  annotations:
+    networkoperator.openshift.io/preserve-fields: data.key
+data:
+  key: user`)
+	desired := UnstructuredFromYaml(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+  namespace: ns
+data:
+  key: rendered`)
+
+	objDesc := "(/v1, Kind=ConfigMap) ns/cm1"
+	preserveUserFields(existing, desired, objDesc)
+	g.Expect(PreservedFields()).To(HaveKeyWithValue(objDesc, []string{"data.key"}))
+	g.Expect(desired.Object["data"]).To(Equal(map[string]interface{}{"key": "user"}))
+
+	// Removing the annotation clears the record on the next apply.
+	existing.SetAnnotations(nil)
+	preserveUserFields(existing, desired, objDesc)
+	g.Expect(PreservedFields()).NotTo(HaveKey(objDesc))
+}
@@ -1,6 +1,8 @@
 package bootstrap
 
 import (
+	"time"
+
 	"github.com/gophercloud/utils/openstack/clientconfig"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -27,21 +29,171 @@ type KuryrBootstrapResult struct {
 	UserCACert               string
 }
 
+// OVNNodeOverlay describes one node pool that needs its own ovnkube-node
+// DaemonSet variant (e.g. DPDK nodes with hugepage-aware settings). Overlays
+// are declared as labeled ConfigMaps and matched to nodes by a single label.
+type OVNNodeOverlay struct {
+	Name          string
+	LabelKey      string
+	LabelValue    string
+	CPURequest    string
+	MemoryRequest string
+}
+
+// OVNInternalSubnets carries the optional overrides for the subnets OVN uses
+// internally (join, masquerade and transit switch), per IP family. Empty
+// fields mean the ovn-kubernetes defaults.
+type OVNInternalSubnets struct {
+	JoinV4          string
+	JoinV6          string
+	MasqueradeV4    string
+	MasqueradeV6    string
+	TransitSwitchV4 string
+	TransitSwitchV6 string
+}
+
+// OVNInterconnectConfig describes the requested OVN zone layout: disabled
+// (one global zone) or enabled with a target number of nodes per zone.
+type OVNInterconnectConfig struct {
+	Enabled      bool
+	NodesPerZone int
+}
+
+// HardwareOffloadProfile declares one offload-capable node pool: the
+// MachineConfig role of the pool, the node label identifying its nodes, an
+// optional NIC selector, and its ovs hw-offload settings.
+type HardwareOffloadProfile struct {
+	Name         string
+	Role         string
+	LabelKey     string
+	LabelValue   string
+	NICSelector  string
+	OvsHwOffload bool
+	MaxIdle      string
+}
+
+// OVNIPsecConfig carries the optional IPsec crypto parameter overrides (IKE
+// and ESP cipher proposals, PFS group, SA lifetime in seconds). Empty fields
+// mean the libreswan defaults.
+type OVNIPsecConfig struct {
+	IKEProposal string
+	ESPProposal string
+	PFSGroup    string
+	SALifetime  int
+
+	// Mode is "full" (east-west, the default) or "external" (north-south
+	// only). In external mode an optional node label restricts IPsec to a
+	// subset of nodes.
+	Mode                  string
+	ExternalSelectorKey   string
+	ExternalSelectorValue string
+}
+
+// IsolationAllowFrom is one namespace label selector (key, optional value)
+// whose namespaces may reach the OVN control plane when isolation
+// NetworkPolicies are rendered.
+type IsolationAllowFrom struct {
+	Key   string
+	Value string
+}
+
+// IsolationPolicyConfig controls rendering of NetworkPolicies that restrict
+// traffic to the CNO-managed control-plane components, for clusters with
+// multi-tenant isolation requirements.
+type IsolationPolicyConfig struct {
+	Enabled   bool
+	AllowFrom []IsolationAllowFrom
+}
+
+// PrimaryInterfacePolicyConfig describes how nodes select the primary
+// interface the default network attaches to. Policy is one of
+// "default-route" (the default), "interface-name" or "subnet".
+type PrimaryInterfacePolicyConfig struct {
+	Policy string
+	// InterfaceName is a glob matched against interface names when Policy
+	// is "interface-name".
+	InterfaceName string
+	// Subnet is the CIDR the interface's address must fall in when Policy
+	// is "subnet".
+	Subnet string
+}
+
 type OVNBootstrapResult struct {
 	MasterIPs               []string
 	ClusterInitiator        string
 	ExistingMasterDaemonset *appsv1.DaemonSet
 	ExistingNodeDaemonset   *appsv1.DaemonSet
 	GatewayMode             string
+	GatewayRouterSubnetV4   string
+	GatewayRouterSubnetV6   string
+	GatewayRoutingTableID   string
+	NodeOverlays            []OVNNodeOverlay
+	InternalSubnets         OVNInternalSubnets
+	Interconnect            OVNInterconnectConfig
+	OffloadProfiles         []HardwareOffloadProfile
+	IPsecConfig             OVNIPsecConfig
+	IsolationPolicies       IsolationPolicyConfig
+	PrimaryInterface        PrimaryInterfacePolicyConfig
 	Platform                configv1.PlatformType
+
+	// NodePlatforms is the distinct set of node provider schemes (from the
+	// node providerIDs), used to detect node pools on platforms other than
+	// the infrastructure platform.
+	NodePlatforms []string
+}
+
+// NetworkDiagnosticsTarget is one user-supplied external endpoint (e.g. a
+// registry mirror or IdP) that network-check-source probes in addition to the
+// built-in check set.
+type NetworkDiagnosticsTarget struct {
+	Name     string
+	Endpoint string
+}
+
+// NetworkDiagnosticsConfig tunes the connectivity check subsystem: which
+// check groups are generated, extra external targets to probe, and how often
+// the checkers probe each target. The zero value means the default check set
+// at the default interval.
+type NetworkDiagnosticsConfig struct {
+	EnabledChecks   []string
+	ExternalTargets []NetworkDiagnosticsTarget
+	ProbeInterval   time.Duration
+
+	// DNSNames are names the DNS resolution checks resolve instead of the
+	// default cluster DNS service name.
+	DNSNames []string
+
+	// LatencyProbes enables the east-west latency/jitter probes against the
+	// network-check-target pod on every node.
+	LatencyProbes bool
+}
+
+// WhereaboutsConfig tunes the whereabouts IPAM plugin: its log level and the
+// cron schedule of its stale IP reconciliation. The zero value means the
+// whereabouts defaults with the reconciler enabled.
+type WhereaboutsConfig struct {
+	LogLevel           string
+	ReconcilerDisabled bool
+	ReconcilerSchedule string
 }
 
 type BootstrapResult struct {
-	Kuryr KuryrBootstrapResult
-	OVN   OVNBootstrapResult
-	SDN   SDNBootstrapResult
+	Kuryr       KuryrBootstrapResult
+	OVN         OVNBootstrapResult
+	SDN         SDNBootstrapResult
+	Diagnostics NetworkDiagnosticsConfig
+	Whereabouts WhereaboutsConfig
+
+	// SysctlAllowlist is the rendered contents of the cni-sysctl-allowlist
+	// ConfigMap: the default allowlist plus validated per-namespace requests.
+	SysctlAllowlist string
 }
 
 type SDNBootstrapResult struct {
 	Platform configv1.PlatformType
+
+	// NodePlatforms is the distinct set of node provider schemes (from the
+	// node providerIDs), used to detect node pools on platforms other than
+	// the infrastructure platform.
+	NodePlatforms []string
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/openshift/cluster-network-operator/pkg/util/k8s"
@@ -64,6 +65,10 @@ type Client struct {
 	// controllers.
 	hc *OperatorHelperClient
 
+	// clients for secondary clusters, keyed by cluster name
+	clusterMu sync.Mutex
+	clusters  map[string]*ClusterClient
+
 	// if the informers are started
 	started bool
 	donech  <-chan struct{}
@@ -74,6 +79,8 @@ func New(cfg, protocfg *rest.Config) (*Client, error) {
 		cfg:      cfg,
 		protocfg: protocfg,
 	}
+	applyClientDefaults(cfg, "default")
+	applyClientDefaults(protocfg, "default")
 	var err error
 
 	if c.kClient, err = kubernetes.NewForConfig(protocfg); err != nil {
@@ -154,6 +161,9 @@ func (c *Client) Start(ctx context.Context) error {
 		go inf.Run(ctx.Done())
 	}
 
+	// Watch secondary cluster kubeconfigs for rotation
+	go c.watchClusterKubeconfigs(ctx)
+
 	klog.Info("Waiting for informers to sync...")
 
 	// Wait for informer factories to sync
@@ -200,16 +210,16 @@ func (c *Client) OperatorHelperClient() operatorv1helpers.OperatorClient {
 // Example for a label-selected ConfigMap watch:
 //
 // c.AddCustomInformer(
-//     v1coreinformers.NewFilteredServiceInformer(
-//          c.Kubernetes(),
-//			kapi.NamespaceAll,
-//			5 * time.Minute, // resync Period
-//			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
-//			func(options *metav1.ListOptions) {
-//				// use k8s.io/apimachinery/pkg/labels for more sophisticated selectors
-//				options.LabelSelector = "operator.example.dev/mylabel=myval"
-//			}))
 //
+//	    v1coreinformers.NewFilteredServiceInformer(
+//	         c.Kubernetes(),
+//				kapi.NamespaceAll,
+//				5 * time.Minute, // resync Period
+//				cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+//				func(options *metav1.ListOptions) {
+//					// use k8s.io/apimachinery/pkg/labels for more sophisticated selectors
+//					options.LabelSelector = "operator.example.dev/mylabel=myval"
+//				}))
 func (c *Client) AddCustomInformer(inf cache.SharedInformer) {
 	c.informers = append(c.informers, inf)
 	if c.started {
@@ -0,0 +1,274 @@
+package client
+
+// Secondary cluster clients. Some deployment topologies (e.g. hosted control
+// planes) need the operator to talk to a second cluster whose kubeconfig is
+// delivered as a Secret and rotated out from under us. Clients are built
+// lazily on first use, health-checked periodically, and rebuilt in place on
+// kubeconfig rotation or connection failure, so consumers never hold a
+// connection built from revoked credentials and the operator pod does not
+// need to restart. Connection state is exported as a metric and available as
+// a health probe for the Degraded condition.
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kubeconfigWatchPeriod is how often registered kubeconfig Secrets are
+// re-read to detect rotation, and built clients are health-checked.
+const kubeconfigWatchPeriod = 30 * time.Second
+
+// clusterClientUp reports, per secondary cluster, whether its client is
+// connected and passing health checks.
+var clusterClientUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_cluster_client_up",
+	Help: "1 if the client for the secondary cluster is connected and healthy, 0 otherwise.",
+}, []string{"cluster"})
+
+func init() {
+	prometheus.MustRegister(clusterClientUp)
+}
+
+// ClusterClient is the set of clients for one secondary cluster. Clients are
+// built on first use; accessors always return ones built from the latest
+// kubeconfig.
+type ClusterClient struct {
+	name string
+
+	// where the kubeconfig comes from
+	secretNamespace string
+	secretName      string
+	secretKey       string
+
+	// getKubeconfig reads the current kubeconfig bytes (from the Secret).
+	getKubeconfig func(ctx context.Context) ([]byte, error)
+
+	mu             sync.Mutex
+	kubeconfigHash [sha256.Size]byte
+	kClient        kubernetes.Interface
+	dynamic        crclient.Client
+	lastErr        error
+}
+
+// Kubernetes returns the typed clientset for the secondary cluster, building
+// it on first use.
+func (cc *ClusterClient) Kubernetes(ctx context.Context) (kubernetes.Interface, error) {
+	if err := cc.ensure(ctx); err != nil {
+		return nil, err
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.kClient, nil
+}
+
+// Dynamic returns the untyped client for the secondary cluster, building it
+// on first use.
+func (cc *ClusterClient) Dynamic(ctx context.Context) (crclient.Client, error) {
+	if err := cc.ensure(ctx); err != nil {
+		return nil, err
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.dynamic, nil
+}
+
+// Probe reports the cluster client's connection state; it is suitable for
+// registration as a StatusManager health probe so a down cluster surfaces as
+// a Degraded reason.
+func (cc *ClusterClient) Probe(ctx context.Context) error {
+	cc.mu.Lock()
+	built := cc.kClient != nil
+	lastErr := cc.lastErr
+	cc.mu.Unlock()
+	if !built {
+		// Never used yet; nothing to report.
+		return nil
+	}
+	if lastErr != nil {
+		return fmt.Errorf("client for cluster %q is unhealthy: %v", cc.name, lastErr)
+	}
+	return nil
+}
+
+// ensure builds the clients from the current kubeconfig if they have not
+// been built yet, or if the kubeconfig rotated since.
+func (cc *ClusterClient) ensure(ctx context.Context) error {
+	kubeconfig, err := cc.getKubeconfig(ctx)
+	if err != nil {
+		cc.setErr(err)
+		return err
+	}
+
+	hash := sha256.Sum256(kubeconfig)
+	cc.mu.Lock()
+	unchanged := hash == cc.kubeconfigHash && cc.kClient != nil
+	cc.mu.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	if err := cc.rebuild(kubeconfig); err != nil {
+		cc.setErr(err)
+		return err
+	}
+	klog.Infof("Built clients for cluster %q from kubeconfig %s/%s", cc.name, cc.secretNamespace, cc.secretName)
+	return nil
+}
+
+// healthCheck pings the cluster's API server. On failure the clients are
+// torn down so the next use reconnects from the current kubeconfig.
+func (cc *ClusterClient) healthCheck(ctx context.Context) {
+	cc.mu.Lock()
+	kClient := cc.kClient
+	cc.mu.Unlock()
+	if kClient == nil {
+		// Not built yet; lazily-constructed clients are not dialed just to
+		// check on them.
+		return
+	}
+
+	_, err := kClient.Discovery().ServerVersion()
+	cc.setErr(err)
+	if err == nil {
+		clusterClientUp.WithLabelValues(cc.name).Set(1)
+		// Still pick up a rotated kubeconfig before the old credentials
+		// expire.
+		if err := cc.ensure(ctx); err != nil {
+			klog.Errorf("Failed to rebuild client for cluster %q from rotated kubeconfig: %v", cc.name, err)
+		}
+		return
+	}
+	clusterClientUp.WithLabelValues(cc.name).Set(0)
+	klog.Errorf("Health check for cluster %q failed, reconnecting: %v", cc.name, err)
+
+	// Force a rebuild on next use; the kubeconfig may have rotated.
+	cc.mu.Lock()
+	cc.kubeconfigHash = [sha256.Size]byte{}
+	cc.mu.Unlock()
+	if err := cc.ensure(ctx); err != nil {
+		klog.Errorf("Failed to reconnect client for cluster %q: %v", cc.name, err)
+	}
+}
+
+func (cc *ClusterClient) setErr(err error) {
+	cc.mu.Lock()
+	cc.lastErr = err
+	cc.mu.Unlock()
+}
+
+// rebuild replaces the clients with ones built from the given kubeconfig.
+func (cc *ClusterClient) rebuild(kubeconfig []byte) error {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to parse kubeconfig: %v", err)
+	}
+	return cc.rebuildFromConfig(cfg, sha256.Sum256(kubeconfig))
+}
+
+func (cc *ClusterClient) rebuildFromConfig(cfg *rest.Config, hash [sha256.Size]byte) error {
+	applyClientDefaults(cfg, cc.name)
+	kClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	dynamic, err := crclient.New(cfg, crclient.Options{})
+	if err != nil {
+		return err
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.kubeconfigHash = hash
+	cc.kClient = kClient
+	cc.dynamic = dynamic
+	cc.lastErr = nil
+	return nil
+}
+
+// RegisterClusterClient makes the kubeconfig stored under key in the given
+// Secret available as a named secondary cluster client. Construction is
+// lazy: nothing is dialed until the client is first used. The Secret is
+// subsequently watched for rotation, and the connection is health-checked
+// periodically. Register the returned client's Probe with the
+// StatusManager's health probes to surface a down cluster as Degraded.
+func (c *Client) RegisterClusterClient(name, secretNamespace, secretName, secretKey string) *ClusterClient {
+	cc := &ClusterClient{
+		name:            name,
+		secretNamespace: secretNamespace,
+		secretName:      secretName,
+		secretKey:       secretKey,
+	}
+	cc.getKubeconfig = func(ctx context.Context) ([]byte, error) {
+		secret, err := c.kClient.CoreV1().Secrets(secretNamespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return kubeconfigFromSecret(secret, secretKey)
+	}
+
+	c.clusterMu.Lock()
+	defer c.clusterMu.Unlock()
+	if c.clusters == nil {
+		c.clusters = map[string]*ClusterClient{}
+	}
+	c.clusters[name] = cc
+	return cc
+}
+
+// ClusterClient returns the client bundle for a registered secondary
+// cluster.
+func (c *Client) ClusterClient(name string) (*ClusterClient, error) {
+	c.clusterMu.Lock()
+	defer c.clusterMu.Unlock()
+	cc, ok := c.clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("no client registered for cluster %q", name)
+	}
+	return cc, nil
+}
+
+func kubeconfigFromSecret(secret *corev1.Secret, key string) ([]byte, error) {
+	kubeconfig, ok := secret.Data[key]
+	if !ok || len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %q key", secret.Namespace, secret.Name, key)
+	}
+	return kubeconfig, nil
+}
+
+// watchClusterKubeconfigs periodically health-checks the built cluster
+// clients and rebuilds any whose credentials were rotated.
+func (c *Client) watchClusterKubeconfigs(ctx context.Context) {
+	ticker := time.NewTicker(kubeconfigWatchPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.clusterMu.Lock()
+			clusters := make([]*ClusterClient, 0, len(c.clusters))
+			for _, cc := range c.clusters {
+				clusters = append(clusters, cc)
+			}
+			c.clusterMu.Unlock()
+
+			for _, cc := range clusters {
+				cc.healthCheck(ctx)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package client
+
+// Client-side API pressure controls and visibility. Every rest.Config the
+// operator builds gets configurable QPS/burst limits and a transport wrapper
+// that measures request count, latency and errors, labeled by cluster, verb
+// and resource, so the load CNO puts on an API server can be measured and
+// tuned.
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"k8s.io/klog/v2"
+)
+
+// Environment overrides for the client-side rate limits, applied to every
+// clientset the operator builds.
+const (
+	clientQPSEnv   = "CNO_CLIENT_QPS"
+	clientBurstEnv = "CNO_CLIENT_BURST"
+)
+
+const (
+	defaultClientQPS   = 20
+	defaultClientBurst = 40
+)
+
+var (
+	clientRequestCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cno_client_requests_total",
+		Help: "Number of API requests made by CNO, by cluster, verb, resource and status code.",
+	}, []string{"cluster", "verb", "resource", "code"})
+
+	clientRequestLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cno_client_request_duration_seconds",
+		Help:    "Latency of API requests made by CNO, by cluster, verb and resource.",
+		Buckets: []float64{0.005, 0.025, 0.1, 0.25, 0.5, 1, 2, 4, 8},
+	}, []string{"cluster", "verb", "resource"})
+)
+
+func init() {
+	prometheus.MustRegister(clientRequestCount)
+	prometheus.MustRegister(clientRequestLatency)
+}
+
+// applyClientDefaults sets the configured rate limits on the rest.Config and
+// instruments its transport with request metrics. The cluster label
+// distinguishes the management cluster ("default") from secondary clusters.
+func applyClientDefaults(cfg *rest.Config, cluster string) {
+	cfg.QPS = float32(clientLimit(clientQPSEnv, defaultClientQPS))
+	cfg.Burst = clientLimit(clientBurstEnv, defaultClientBurst)
+	cfg.Wrap(requestMetricsWrapper(cluster))
+}
+
+func clientLimit(env string, fallback int) int {
+	if raw := os.Getenv(env); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			return parsed
+		}
+		klog.Warningf("Ignoring invalid %s value %q", env, raw)
+	}
+	return fallback
+}
+
+// requestMetricsWrapper wraps a transport so every request is counted and
+// timed.
+func requestMetricsWrapper(cluster string) transport.WrapperFunc {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &metricsRoundTripper{cluster: cluster, delegate: rt}
+	}
+}
+
+type metricsRoundTripper struct {
+	cluster  string
+	delegate http.RoundTripper
+}
+
+func (m *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := pathResource(req.URL.Path)
+	start := time.Now()
+	resp, err := m.delegate.RoundTrip(req)
+	clientRequestLatency.WithLabelValues(m.cluster, req.Method, resource).Observe(time.Since(start).Seconds())
+
+	code := "<error>"
+	if err == nil && resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	clientRequestCount.WithLabelValues(m.cluster, req.Method, resource, code).Inc()
+	return resp, err
+}
+
+// pathResource extracts the resource name from an apiserver request path
+// ("/api/v1/namespaces/foo/pods/bar" -> "pods"). Unrecognized paths map to
+// "other" so the label cardinality stays bounded.
+func pathResource(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	// Strip the group/version prefix: "api/v1" or "apis/<group>/<version>".
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		segments = segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		segments = segments[3:]
+	default:
+		return "other"
+	}
+	// Strip a "namespaces/<name>" scope, keeping bare namespace requests.
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+	if len(segments) == 0 {
+		return "other"
+	}
+	return segments[0]
+}
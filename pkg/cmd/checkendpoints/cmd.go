@@ -98,6 +98,10 @@ func NewCheckEndpointsCommand() *cobra.Command {
 		kubeInformers.Start(ctx.Done())
 		go check.Run(ctx, 1)
 		go stopController.Run(ctx, 1)
+		go controller.NewDNSChecker(namespace, controller.DNSCheckNames()).Run(ctx)
+		if controller.LatencyProbesEnabled() {
+			go controller.NewLatencyProber(namespace, namespace, kubeInformers.Core().V1().Endpoints().Lister()).Run(ctx)
+		}
 		<-ctx.Done()
 		return nil
 	})
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"os"
 	"regexp"
 	"time"
 
@@ -18,10 +19,27 @@ import (
 )
 
 const (
-	checkPeriod  = 1 * time.Minute
-	checkTimeout = 10 * time.Second
+	defaultCheckPeriod = 1 * time.Minute
+	checkTimeout       = 10 * time.Second
 )
 
+// checkPeriod is how often each endpoint is probed. It can be overridden by
+// the operator via the NETWORK_CHECK_PROBE_INTERVAL environment variable.
+var checkPeriod = defaultCheckPeriod
+
+func init() {
+	raw := os.Getenv("NETWORK_CHECK_PROBE_INTERVAL")
+	if raw == "" {
+		return
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval < checkTimeout {
+		klog.Warningf("Ignoring invalid NETWORK_CHECK_PROBE_INTERVAL %q", raw)
+		return
+	}
+	checkPeriod = interval
+}
+
 // ConnectionChecker checks a single connection and updates status when appropriate
 type ConnectionChecker interface {
 	Run(ctx context.Context)
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// defaultDNSCheckNames are resolved when the operator does not configure a
+// list; the cluster DNS service name exercises the full in-cluster
+// resolution path.
+var defaultDNSCheckNames = []string{"kubernetes.default.svc.cluster.local"}
+
+// DNSCheckNames returns the names the DNS checker should resolve: the
+// operator-configured list from NETWORK_CHECK_DNS_NAMES, or the default set.
+func DNSCheckNames() []string {
+	raw := os.Getenv("NETWORK_CHECK_DNS_NAMES")
+	if raw == "" {
+		return defaultDNSCheckNames
+	}
+	var names []string
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return defaultDNSCheckNames
+	}
+	return names
+}
+
+// DNSChecker periodically resolves a set of cluster-internal and external
+// names and reports latency and failure metrics, to separate DNS incidents
+// from connectivity incidents.
+type DNSChecker struct {
+	componentName string
+	names         []string
+	resolver      *net.Resolver
+}
+
+// NewDNSChecker returns a DNSChecker that resolves the given names.
+func NewDNSChecker(componentName string, names []string) *DNSChecker {
+	RegisterMetrics()
+	return &DNSChecker{
+		componentName: componentName,
+		names:         names,
+		resolver:      net.DefaultResolver,
+	}
+}
+
+// Run resolves each name every check period until the context is cancelled.
+func (d *DNSChecker) Run(ctx context.Context) {
+	klog.V(1).Infof("Started DNS checks for %s.", strings.Join(d.names, ", "))
+	ticker := time.NewTicker(checkPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			klog.V(1).Info("Stopped DNS checks.")
+			return
+		case <-ticker.C:
+			for _, name := range d.names {
+				d.checkName(ctx, name)
+			}
+		}
+	}
+}
+
+func (d *DNSChecker) checkName(ctx context.Context, name string) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := d.resolver.LookupHost(ctx, name)
+	latency := time.Since(start)
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+		klog.V(2).Infof("%7s | %-15s | %10s | Failed to resolve %s: %v", "Failure", "DNSError", latency, name, err)
+	} else {
+		klog.V(4).Infof("%7s | %-15s | %10s | Resolved %s", "Success", "DNSResolve", latency, name)
+	}
+	dnsCheckCounter.WithLabelValues(d.componentName, name, result).Inc()
+	if err == nil {
+		dnsCheckLatencyGauge.WithLabelValues(d.componentName, name).Set(float64(latency.Nanoseconds()))
+	}
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// latencyProbeSamples is how many connections are timed per target per round;
+// jitter is meaningless with fewer samples.
+const latencyProbeSamples = 5
+
+// LatencyProbesEnabled reports whether the operator turned on east-west
+// latency probing for this pod.
+func LatencyProbesEnabled() bool {
+	return os.Getenv("NETWORK_CHECK_LATENCY_PROBES") == "true"
+}
+
+// LatencyProber measures round-trip latency and jitter from this pod to the
+// network-check-target pod on every node, over the pod network. The resulting
+// histograms give a per-node-pair baseline for detecting overlay degradation.
+type LatencyProber struct {
+	componentName   string
+	endpointsLister corev1listers.EndpointsLister
+	namespace       string
+}
+
+// NewLatencyProber returns a LatencyProber that probes the
+// network-check-target endpoints in the given namespace.
+func NewLatencyProber(componentName, namespace string, endpointsLister corev1listers.EndpointsLister) *LatencyProber {
+	RegisterMetrics()
+	return &LatencyProber{
+		componentName:   componentName,
+		endpointsLister: endpointsLister,
+		namespace:       namespace,
+	}
+}
+
+// Run probes every target endpoint each check period until the context is
+// cancelled.
+func (p *LatencyProber) Run(ctx context.Context) {
+	klog.V(1).Info("Started east-west latency probes.")
+	ticker := time.NewTicker(checkPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			klog.V(1).Info("Stopped east-west latency probes.")
+			return
+		case <-ticker.C:
+			for node, endpoint := range p.targetEndpoints() {
+				p.probe(ctx, node, endpoint)
+			}
+		}
+	}
+}
+
+// targetEndpoints maps node name to the network-check-target endpoint hosted
+// on that node.
+func (p *LatencyProber) targetEndpoints() map[string]string {
+	endpoints, err := p.endpointsLister.Endpoints(p.namespace).Get("network-check-target")
+	if err != nil {
+		klog.V(2).Infof("Unable to list network-check-target endpoints: %v", err)
+		return nil
+	}
+	targets := map[string]string{}
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			if address.NodeName == nil {
+				continue
+			}
+			for _, port := range subset.Ports {
+				targets[*address.NodeName] = net.JoinHostPort(address.IP, strconv.Itoa(int(port.Port)))
+			}
+		}
+	}
+	return targets
+}
+
+// probe times several TCP connections to the endpoint and records the
+// latency samples and their jitter.
+func (p *LatencyProber) probe(ctx context.Context, node, endpoint string) {
+	dialer := &net.Dialer{Timeout: checkTimeout}
+	samples := make([]time.Duration, 0, latencyProbeSamples)
+	for i := 0; i < latencyProbeSamples; i++ {
+		start := time.Now()
+		conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+		if err != nil {
+			klog.V(2).Infof("Latency probe to %s (%s) failed: %v", node, endpoint, err)
+			return
+		}
+		samples = append(samples, time.Since(start))
+		_ = conn.Close()
+	}
+
+	for _, sample := range samples {
+		nodeLatencyHistogram.WithLabelValues(p.componentName, node).Observe(sample.Seconds())
+	}
+	nodeJitterGauge.WithLabelValues(p.componentName, node).Set(jitter(samples).Seconds())
+}
+
+// jitter is the difference between the fastest and slowest sample of a
+// round, a simple proxy for dataplane variance.
+func jitter(samples []time.Duration) time.Duration {
+	if len(samples) < 2 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)-1] - sorted[0]
+}
@@ -14,6 +14,10 @@ var (
 	endpointCheckCounter   *metrics.CounterVec
 	tcpConnectLatencyGauge *metrics.GaugeVec
 	dnsResolveLatencyGauge *metrics.GaugeVec
+	dnsCheckCounter        *metrics.CounterVec
+	dnsCheckLatencyGauge   *metrics.GaugeVec
+	nodeLatencyHistogram   *metrics.HistogramVec
+	nodeJitterGauge        *metrics.GaugeVec
 )
 
 // RegisterMetrics in the global registry
@@ -33,9 +37,32 @@ func RegisterMetrics() {
 			Name: "pod_network_connectivity_check_dns_resolve_latency_gauge",
 			Help: "Report latency of DNS resolve of target endpoint over time.",
 		}, []string{"component", "checkName", "targetEndpoint"})
+		dnsCheckCounter = metrics.NewCounterVec(&metrics.CounterOpts{
+			Name: "pod_network_dns_check_count",
+			Help: "Report status of pod network DNS resolution checks over time.",
+		}, []string{"component", "name", "result"})
+
+		dnsCheckLatencyGauge = metrics.NewGaugeVec(&metrics.GaugeOpts{
+			Name: "pod_network_dns_check_latency_gauge",
+			Help: "Report latency of DNS resolution checks over time.",
+		}, []string{"component", "name"})
+		nodeLatencyHistogram = metrics.NewHistogramVec(&metrics.HistogramOpts{
+			Name:    "pod_network_node_latency_seconds",
+			Help:    "Round-trip latency of east-west probes to the network-check-target pod on each node.",
+			Buckets: []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+		}, []string{"component", "node"})
+
+		nodeJitterGauge = metrics.NewGaugeVec(&metrics.GaugeOpts{
+			Name: "pod_network_node_jitter_seconds",
+			Help: "Latency spread of the most recent east-west probe round to each node.",
+		}, []string{"component", "node"})
 		legacyregistry.MustRegister(endpointCheckCounter)
 		legacyregistry.MustRegister(tcpConnectLatencyGauge)
 		legacyregistry.MustRegister(dnsResolveLatencyGauge)
+		legacyregistry.MustRegister(dnsCheckCounter)
+		legacyregistry.MustRegister(dnsCheckLatencyGauge)
+		legacyregistry.MustRegister(nodeLatencyHistogram)
+		legacyregistry.MustRegister(nodeJitterGauge)
 	})
 }
 
@@ -0,0 +1,106 @@
+package mtuprober
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// resyncPeriod is how often the node MTUs are re-probed. They only change on
+// host or network reconfiguration, so this just bounds how stale the
+// annotations can get.
+const resyncPeriod = 5 * time.Minute
+
+// NewMTUProberCommand creates a cobra command that probes the local MTUs and
+// publishes them as annotations on this node: the uplink MTU, which the
+// operator's mtu controller aggregates into per-pool minimums, and the MTU
+// applied on the cluster-network interface, which the controller compares
+// against the configured MTU to track migration progress. It is run as a
+// host-network DaemonSet from the operator image.
+func NewMTUProberCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "mtu-prober",
+		Short: "Publish the local MTUs as Node annotations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run()
+		},
+	}
+}
+
+func run() error {
+	nodeName := os.Getenv("NODE_NAME")
+	if nodeName == "" {
+		return fmt.Errorf("NODE_NAME must be set")
+	}
+
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return fmt.Errorf("failed to build in-cluster config: %v", err)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	published := map[string]interface{}{}
+	for {
+		annotations := probe()
+		if !reflect.DeepEqual(annotations, published) {
+			patch, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"annotations": annotations}})
+			if err != nil {
+				return err
+			}
+			_, err = clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+			if err != nil {
+				klog.Errorf("Failed to annotate node %s: %v", nodeName, err)
+			} else {
+				klog.Infof("Published MTU annotations on node %s: %s", nodeName, patch)
+				published = annotations
+			}
+		}
+		time.Sleep(resyncPeriod)
+	}
+}
+
+// probe returns the MTU annotations to merge-patch onto the node. A nil
+// value removes the annotation, so a probe error from an earlier cycle is
+// cleared once probing succeeds again.
+func probe() map[string]interface{} {
+	annotations := map[string]interface{}{
+		names.NodeMTUErrorAnnotation: nil,
+	}
+
+	uplink, err := network.LocalHostMTU()
+	if err != nil {
+		klog.Errorf("Failed to probe uplink MTU: %v", err)
+		annotations[names.NodeMTUErrorAnnotation] = fmt.Sprintf("probing uplink MTU: %v", err)
+	} else {
+		annotations[names.NodeUplinkMTUAnnotation] = strconv.Itoa(uplink)
+	}
+
+	applied, err := network.LocalClusterNetworkMTU()
+	if err != nil {
+		klog.Errorf("Failed to probe cluster-network MTU: %v", err)
+		annotations[names.NodeMTUErrorAnnotation] = fmt.Sprintf("probing cluster-network MTU: %v", err)
+	} else if applied > 0 {
+		annotations[names.NodeAppliedMTUAnnotation] = strconv.Itoa(applied)
+	}
+
+	return annotations
+}
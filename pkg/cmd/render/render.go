@@ -0,0 +1,155 @@
+package render
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// renderOpts holds the inputs for an offline render: everything that would
+// otherwise be read from a live cluster is supplied as files on disk.
+type renderOpts struct {
+	configFile        string
+	clusterConfigFile string
+	infraStatusFile   string
+	manifestDir       string
+	outDir            string
+	ovnMasterIPs      []string
+}
+
+// NewRenderCommand creates a cobra command that renders the network operator
+// manifests from configuration files on disk, without a live cluster. This
+// lets installers and CI generate (and diff) network manifests offline.
+func NewRenderCommand() *cobra.Command {
+	opts := &renderOpts{}
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render network manifests from configuration files on disk",
+		Long: `Render reads a Network.operator.openshift.io CR, and optionally a
+Network.config.openshift.io CR and an Infrastructure status, from files on
+disk and emits the manifests the operator would apply, without contacting a
+cluster.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run()
+		},
+	}
+	cmd.Flags().StringVar(&opts.configFile, "config", "", "path to a Network.operator.openshift.io CR in YAML form (required)")
+	cmd.Flags().StringVar(&opts.clusterConfigFile, "cluster-config", "", "path to a Network.config.openshift.io CR to merge in")
+	cmd.Flags().StringVar(&opts.infraStatusFile, "infra-status", "", "path to an Infrastructure.config.openshift.io CR used to determine the platform")
+	cmd.Flags().StringVar(&opts.manifestDir, "manifest-dir", "./bindata", "path to the manifest templates")
+	cmd.Flags().StringVar(&opts.outDir, "out", "-", "directory to write rendered manifests to, or - for stdout")
+	cmd.Flags().StringSliceVar(&opts.ovnMasterIPs, "ovn-master-ips", nil, "IP addresses to use as OVN database endpoints")
+	_ = cmd.MarkFlagRequired("config")
+	return cmd
+}
+
+func (opts *renderOpts) run() error {
+	operConfig := &operv1.Network{}
+	if err := unmarshalFile(opts.configFile, operConfig); err != nil {
+		return errors.Wrap(err, "failed to read operator config")
+	}
+
+	if opts.clusterConfigFile != "" {
+		clusterConfig := &configv1.Network{}
+		if err := unmarshalFile(opts.clusterConfigFile, clusterConfig); err != nil {
+			return errors.Wrap(err, "failed to read cluster config")
+		}
+		if err := network.ValidateClusterConfig(clusterConfig.Spec); err != nil {
+			return errors.Wrap(err, "cluster config failed validation")
+		}
+		network.MergeClusterConfig(&operConfig.Spec, clusterConfig.Spec)
+	}
+
+	network.DeprecatedCanonicalize(&operConfig.Spec)
+	if err := network.Validate(&operConfig.Spec); err != nil {
+		return errors.Wrap(err, "operator config failed validation")
+	}
+	network.FillDefaults(&operConfig.Spec, nil)
+
+	bootstrapResult, err := opts.bootstrapResult()
+	if err != nil {
+		return err
+	}
+
+	objs, err := network.Render(&operConfig.Spec, bootstrapResult, opts.manifestDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to render")
+	}
+
+	return opts.write(objs)
+}
+
+// bootstrapResult builds an offline BootstrapResult from the supplied files
+// and flags, standing in for the cluster probing a live reconcile would do.
+func (opts *renderOpts) bootstrapResult() (*bootstrap.BootstrapResult, error) {
+	res := &bootstrap.BootstrapResult{
+		OVN: bootstrap.OVNBootstrapResult{
+			MasterIPs: opts.ovnMasterIPs,
+		},
+	}
+
+	if opts.infraStatusFile != "" {
+		infra := &configv1.Infrastructure{}
+		if err := unmarshalFile(opts.infraStatusFile, infra); err != nil {
+			return nil, errors.Wrap(err, "failed to read infrastructure status")
+		}
+		var platform configv1.PlatformType
+		if infra.Status.PlatformStatus != nil {
+			platform = infra.Status.PlatformStatus.Type
+		}
+		res.OVN.Platform = platform
+		res.SDN.Platform = platform
+	}
+
+	return res, nil
+}
+
+func (opts *renderOpts) write(objs []*uns.Unstructured) error {
+	if opts.outDir == "-" {
+		for _, obj := range objs {
+			buf, err := yaml.Marshal(obj.Object)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("---\n%s", buf)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(opts.outDir, 0o755); err != nil {
+		return err
+	}
+	for i, obj := range objs {
+		buf, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%03d-%s-%s.yaml", i, strings.ToLower(obj.GetKind()), obj.GetName())
+		if err := ioutil.WriteFile(filepath.Join(opts.outDir, name), buf, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalFile reads a YAML file into the given object.
+func unmarshalFile(path string, out interface{}) error {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(buf, out)
+}
@@ -1,12 +1,21 @@
 package controller
 
 import (
+	"github.com/openshift/cluster-network-operator/pkg/controller/apbroute"
 	"github.com/openshift/cluster-network-operator/pkg/controller/clusterconfig"
 	configmapcainjector "github.com/openshift/cluster-network-operator/pkg/controller/configmap_ca_injector"
+	"github.com/openshift/cluster-network-operator/pkg/controller/connectivitycheck"
+	"github.com/openshift/cluster-network-operator/pkg/controller/dashboards"
 	"github.com/openshift/cluster-network-operator/pkg/controller/egress_router"
+	"github.com/openshift/cluster-network-operator/pkg/controller/egressip"
 	"github.com/openshift/cluster-network-operator/pkg/controller/ingressconfig"
+	"github.com/openshift/cluster-network-operator/pkg/controller/ipsec"
+	"github.com/openshift/cluster-network-operator/pkg/controller/migration"
+	"github.com/openshift/cluster-network-operator/pkg/controller/mtu"
+	"github.com/openshift/cluster-network-operator/pkg/controller/nodereadiness"
 	"github.com/openshift/cluster-network-operator/pkg/controller/operconfig"
 	"github.com/openshift/cluster-network-operator/pkg/controller/pki"
+	"github.com/openshift/cluster-network-operator/pkg/controller/podlatency"
 	"github.com/openshift/cluster-network-operator/pkg/controller/proxyconfig"
 	signer "github.com/openshift/cluster-network-operator/pkg/controller/signer"
 )
@@ -16,11 +25,20 @@ func init() {
 	AddToManagerFuncs = append(AddToManagerFuncs,
 		pki.Add,
 		egress_router.Add,
+		egressip.Add,
+		apbroute.Add,
+		mtu.Add,
+		ipsec.Add,
+		migration.Add,
+		connectivitycheck.Add,
 		proxyconfig.Add,
 		operconfig.Add,
 		clusterconfig.Add,
 		configmapcainjector.Add,
 		signer.Add,
 		ingressconfig.Add,
+		dashboards.Add,
+		nodereadiness.Add,
+		podlatency.Add,
 	)
 }
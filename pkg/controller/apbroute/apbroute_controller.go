@@ -0,0 +1,107 @@
+package apbroute
+
+// The apbroute controller aggregates the status of AdminPolicyBasedExternalRoute
+// objects (ovn-kubernetes multiple external gateways) into operator metrics and
+// a summary condition, so misconfigured external gateway policies surface at
+// the operator level instead of only on the individual CRs.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ResyncPeriod is how often external gateway policy status is recomputed.
+var ResyncPeriod = 3 * time.Minute
+
+var apbExternalRouteListGVK = schema.GroupVersionKind{
+	Group:   "k8s.ovn.org",
+	Version: "v1",
+	Kind:    "AdminPolicyBasedExternalRouteList",
+}
+
+// apbExternalRoutePolicies reports the number of AdminPolicyBasedExternalRoute
+// policies per status reported by ovn-kubernetes.
+var apbExternalRoutePolicies = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_apbexternalroute_policies",
+	Help: "Number of AdminPolicyBasedExternalRoute policies by status.",
+}, []string{"status"})
+
+func init() {
+	prometheus.MustRegister(apbExternalRoutePolicies)
+}
+
+// Add registers the external gateway policy status checker with the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	checker := &apbRouteStatusChecker{client: mgr.GetClient(), status: status}
+	return mgr.Add(manager.RunnableFunc(checker.run))
+}
+
+type apbRouteStatusChecker struct {
+	client client.Client
+	status *statusmanager.StatusManager
+}
+
+func (c *apbRouteStatusChecker) run(ctx context.Context) error {
+	ticker := time.NewTicker(ResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// check recomputes the aggregate policy status. On clusters without the
+// AdminPolicyBasedExternalRoute CRD it is a no-op.
+func (c *apbRouteStatusChecker) check(ctx context.Context) {
+	routes := &uns.UnstructuredList{}
+	routes.SetGroupVersionKind(apbExternalRouteListGVK)
+	if err := c.client.List(ctx, routes); err != nil {
+		if !meta.IsNoMatchError(err) {
+			klog.Errorf("Failed to list AdminPolicyBasedExternalRoutes: %v", err)
+		}
+		return
+	}
+
+	counts := map[string]int{"applied": 0, "failing": 0, "pending": 0}
+	failures := []string{}
+	for _, route := range routes.Items {
+		state, _, _ := uns.NestedString(route.Object, "status", "status")
+		switch state {
+		case "Success":
+			counts["applied"]++
+		case "Fail":
+			counts["failing"]++
+			messages, _, _ := uns.NestedStringSlice(route.Object, "status", "messages")
+			failures = append(failures, fmt.Sprintf("policy %q failed: %s", route.GetName(), strings.Join(messages, "; ")))
+		default:
+			counts["pending"]++
+		}
+	}
+	for state, count := range counts {
+		apbExternalRoutePolicies.WithLabelValues(state).Set(float64(count))
+	}
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		c.status.SetDegraded(statusmanager.ExternalGatewayConfig, "ExternalGatewayPolicyFailed", strings.Join(failures, "\n"))
+	} else {
+		c.status.SetNotDegraded(statusmanager.ExternalGatewayConfig)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
 	"github.com/openshift/cluster-network-operator/pkg/names"
@@ -151,8 +152,10 @@ func (r *ReconcileConfigMapInjector) Reconcile(ctx context.Context, request reco
 	}
 
 	errs := []error{}
+	failing := []string{}
 
 	for _, configMap := range configMapsToChange {
+		start := time.Now()
 		err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 			retrievedConfigMap := &corev1.ConfigMap{}
 			err = r.client.Get(ctx, types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}, retrievedConfigMap)
@@ -182,13 +185,20 @@ func (r *ReconcileConfigMapInjector) Reconcile(ctx context.Context, request reco
 		})
 		if err != nil {
 			errs = append(errs, err)
+			failing = append(failing, fmt.Sprintf("%s/%s: %v", configMap.Namespace, configMap.Name, err))
 			if len(errs) > 5 {
+				caInjectionFailing.Set(float64(len(failing)))
+				r.status.SetCAInjectionProblems(failing)
 				r.status.SetDegraded(statusmanager.InjectorConfig, "ConfigMapUpdateFailure",
 					"Too many errors seen when updating trusted CA configmaps")
 				return reconcile.Result{}, fmt.Errorf("Too many errors attempting to update configmaps with CA cert. data")
 			}
+			continue
 		}
+		caInjectionLatency.Observe(time.Since(start).Seconds())
 	}
+	caInjectionFailing.Set(float64(len(failing)))
+	r.status.SetCAInjectionProblems(failing)
 	if len(errs) > 0 {
 		r.status.SetDegraded(statusmanager.InjectorConfig, "ConfigmapUpdateFailure",
 			"some configmaps didn't fully update with CA cert. data")
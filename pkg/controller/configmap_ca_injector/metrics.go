@@ -0,0 +1,23 @@
+package configmapcainjector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	caInjectionFailing = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "cno_ca_injection_failing_configmaps",
+		Help: "Number of labeled ConfigMaps the trust bundle could not be injected into on the last reconciliation.",
+	})
+
+	caInjectionLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "cno_ca_injection_latency_seconds",
+		Help:    "Time taken to inject the trust bundle into one labeled ConfigMap.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10},
+	})
+)
+
+func init() {
+	prometheus.MustRegister(caInjectionFailing)
+	prometheus.MustRegister(caInjectionLatency)
+}
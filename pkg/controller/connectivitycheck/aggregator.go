@@ -0,0 +1,177 @@
+package connectivitycheck
+
+// The connectivity aggregator consumes the PodNetworkConnectivityCheck
+// statuses the network-check-source pods maintain and condenses them into a
+// cluster-wide view: Prometheus gauges for reachable/unreachable checks and a
+// warning-level ConnectivityDegraded condition once too many checks fail or
+// any check has been failing for a sustained period.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// AggregationPeriod is how often the check statuses are re-aggregated.
+var AggregationPeriod = 1 * time.Minute
+
+const (
+	// degradedPercentThreshold is the fraction of unreachable checks beyond
+	// which the ConnectivityDegraded condition is raised.
+	degradedPercentThreshold = 10.0
+
+	// sustainedOutageThreshold is how long a single check must be in an open
+	// outage before it alone raises the ConnectivityDegraded condition.
+	sustainedOutageThreshold = 10 * time.Minute
+)
+
+var connectivityCheckGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_connectivity_checks",
+	Help: "Number of PodNetworkConnectivityChecks by reachability state.",
+}, []string{"state"})
+
+var connectivityUnreachablePercent = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_connectivity_unreachable_percent",
+	Help: "Percentage of PodNetworkConnectivityChecks that are currently unreachable.",
+})
+
+func init() {
+	prometheus.MustRegister(connectivityCheckGauge)
+	prometheus.MustRegister(connectivityUnreachablePercent)
+}
+
+var connectivityCheckListGVK = schema.GroupVersionKind{
+	Group: "controlplane.operator.openshift.io", Version: "v1alpha1", Kind: "PodNetworkConnectivityCheckList",
+}
+
+// Add registers the connectivity aggregator with the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	aggregator := &connectivityAggregator{client: mgr.GetClient(), status: status}
+	return mgr.Add(manager.RunnableFunc(aggregator.run))
+}
+
+type connectivityAggregator struct {
+	client client.Client
+	status *statusmanager.StatusManager
+}
+
+func (a *connectivityAggregator) run(ctx context.Context) error {
+	ticker := time.NewTicker(AggregationPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			a.aggregate(ctx)
+		}
+	}
+}
+
+func (a *connectivityAggregator) aggregate(ctx context.Context) {
+	checks := &uns.UnstructuredList{}
+	checks.SetGroupVersionKind(connectivityCheckListGVK)
+	if err := a.client.List(ctx, checks); err != nil {
+		if !meta.IsNoMatchError(err) {
+			klog.Errorf("Failed to list PodNetworkConnectivityChecks: %v", err)
+		}
+		return
+	}
+
+	var reachable, unreachable, unknown int
+	var failing, sustained []string
+	now := time.Now()
+	for i := range checks.Items {
+		check := &checks.Items[i]
+		switch checkReachability(check) {
+		case "True":
+			reachable++
+		case "False":
+			unreachable++
+			failing = append(failing, check.GetName())
+			if since, open := openOutageStart(check); open && now.Sub(since) >= sustainedOutageThreshold {
+				sustained = append(sustained, fmt.Sprintf("%s (since %s)", check.GetName(), since.Format(time.RFC3339)))
+			}
+		default:
+			unknown++
+		}
+	}
+
+	connectivityCheckGauge.WithLabelValues("reachable").Set(float64(reachable))
+	connectivityCheckGauge.WithLabelValues("unreachable").Set(float64(unreachable))
+	connectivityCheckGauge.WithLabelValues("unknown").Set(float64(unknown))
+
+	percent := 0.0
+	if total := reachable + unreachable; total > 0 {
+		percent = float64(unreachable) * 100 / float64(total)
+	}
+	connectivityUnreachablePercent.Set(percent)
+
+	sort.Strings(failing)
+	sort.Strings(sustained)
+	switch {
+	case len(sustained) > 0:
+		a.status.SetConnectivityDegraded("SustainedConnectivityFailure",
+			fmt.Sprintf("The following connectivity checks have been failing for more than %v: %s",
+				sustainedOutageThreshold, strings.Join(sustained, ", ")))
+	case percent > degradedPercentThreshold:
+		a.status.SetConnectivityDegraded("ConnectivityFailureThresholdExceeded",
+			fmt.Sprintf("%.0f%% of connectivity checks are failing (threshold %.0f%%): %s",
+				percent, degradedPercentThreshold, strings.Join(failing, ", ")))
+	default:
+		a.status.SetConnectivityDegraded("", "")
+	}
+}
+
+// checkReachability returns the status of the check's Reachable condition, or
+// "" if it has none yet.
+func checkReachability(check *uns.Unstructured) string {
+	conditions, _, _ := uns.NestedSlice(check.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ctype, _, _ := uns.NestedString(condition, "type")
+		if ctype != "Reachable" {
+			continue
+		}
+		status, _, _ := uns.NestedString(condition, "status")
+		return status
+	}
+	return ""
+}
+
+// openOutageStart returns the start of the check's current outage, if one is
+// open. Outages are newest-first, so only the first entry can be open.
+func openOutageStart(check *uns.Unstructured) (time.Time, bool) {
+	outages, _, _ := uns.NestedSlice(check.Object, "status", "outages")
+	if len(outages) == 0 {
+		return time.Time{}, false
+	}
+	outage, ok := outages[0].(map[string]interface{})
+	if !ok {
+		return time.Time{}, false
+	}
+	if end, _, _ := uns.NestedString(outage, "end"); end != "" {
+		return time.Time{}, false
+	}
+	raw, _, _ := uns.NestedString(outage, "start")
+	start, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return start, true
+}
@@ -17,6 +17,7 @@ import (
 	configv1listers "github.com/openshift/client-go/config/listers/config/v1"
 	operatorcontrolplaneclient "github.com/openshift/client-go/operatorcontrolplane/clientset/versioned"
 	"github.com/openshift/cluster-network-operator/pkg/controller/eventrecorder"
+	"github.com/openshift/cluster-network-operator/pkg/names"
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/connectivitycheckcontroller"
 	"github.com/openshift/library-go/pkg/operator/events"
@@ -24,6 +25,8 @@ import (
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiextensionsinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -75,6 +78,7 @@ func NewNetworkConnectivityCheckController(
 		),
 	}
 	generator := &connectivityCheckTemplateProvider{
+		kubeClient:                        kubeClient,
 		operatorClient:                    operatorClient,
 		operatorcontrolplaneClient:        operatorcontrolplaneClient,
 		diagnosticsPodLister:              kubeInformersForNamespaces.InformersFor("openshift-network-diagnostics").Core().V1().Pods().Lister(),
@@ -94,6 +98,7 @@ type networkConnectivityCheckController struct {
 }
 
 type connectivityCheckTemplateProvider struct {
+	kubeClient                        kubernetes.Interface
 	operatorClient                    v1helpers.OperatorClient
 	operatorcontrolplaneClient        *operatorcontrolplaneclient.Clientset
 	diagnosticsPodLister              corev1listers.PodLister
@@ -107,23 +112,42 @@ type connectivityCheckTemplateProvider struct {
 }
 
 func (c *connectivityCheckTemplateProvider) generate(ctx context.Context, syncContext factory.SyncContext) ([]*v1alpha1.PodNetworkConnectivityCheck, error) {
+	enabled := c.enabledChecks(ctx, syncContext.Recorder())
 	var templates []*v1alpha1.PodNetworkConnectivityCheck
 	// kas service IP
-	templates = append(templates, c.getTemplatesForKubernetesServiceMonitorService(syncContext.Recorder())...)
+	if enabled("kubernetes-apiserver-service") {
+		templates = append(templates, c.getTemplatesForKubernetesServiceMonitorService(syncContext.Recorder())...)
+	}
 	// kas default service IP
-	templates = append(templates, c.getTemplatesForKubernetesDefaultServiceCheck(syncContext.Recorder())...)
+	if enabled("kubernetes-default-service") {
+		templates = append(templates, c.getTemplatesForKubernetesDefaultServiceCheck(syncContext.Recorder())...)
+	}
 	// each kas endpoint IP
-	templates = append(templates, c.getTemplatesForKubernetesServiceEndpointsChecks(syncContext.Recorder())...)
+	if enabled("kubernetes-apiserver-endpoint") {
+		templates = append(templates, c.getTemplatesForKubernetesServiceEndpointsChecks(syncContext.Recorder())...)
+	}
 	// oas service IP
-	templates = append(templates, c.getTemplatesForOpenShiftAPIServerServiceCheck(syncContext.Recorder())...)
+	if enabled("openshift-apiserver-service") {
+		templates = append(templates, c.getTemplatesForOpenShiftAPIServerServiceCheck(syncContext.Recorder())...)
+	}
 	// each oas endpoint IP
-	templates = append(templates, c.getTemplatesForOpenShiftAPIServerServiceEndpointsChecks(syncContext.Recorder())...)
+	if enabled("openshift-apiserver-endpoint") {
+		templates = append(templates, c.getTemplatesForOpenShiftAPIServerServiceEndpointsChecks(syncContext.Recorder())...)
+	}
 	// each api load balancer hostname
-	templates = append(templates, c.getTemplatesForAPILoadBalancerChecks(syncContext.Recorder())...)
+	if enabled("load-balancer") {
+		templates = append(templates, c.getTemplatesForAPILoadBalancerChecks(syncContext.Recorder())...)
+	}
 	// generic pod service IP
-	templates = append(templates, c.getTemplatesForGenericPodServiceCheck(syncContext.Recorder())...)
+	if enabled("network-check-target-service") {
+		templates = append(templates, c.getTemplatesForGenericPodServiceCheck(syncContext.Recorder())...)
+	}
 	// each generic pod endpoint IP
-	templates = append(templates, c.getTemplatesForGenericPodServiceEndpointsChecks(syncContext.Recorder())...)
+	if enabled("network-check-target") {
+		templates = append(templates, c.getTemplatesForGenericPodServiceEndpointsChecks(syncContext.Recorder())...)
+	}
+	// each user-supplied external endpoint
+	templates = append(templates, c.getTemplatesForExternalTargets(ctx, syncContext.Recorder())...)
 
 	pods, err := c.diagnosticsPodLister.List(labels.Set{"app": "network-check-source"}.AsSelector())
 	if err != nil {
@@ -343,6 +367,63 @@ func (c *connectivityCheckTemplateProvider) getTemplatesForAPILoadBalancerChecks
 	return templates
 }
 
+// getTemplatesForExternalTargets generates a check for every user-supplied
+// external endpoint in the network diagnostics ConfigMap. Results aggregate
+// into PodNetworkConnectivityCheck objects and the checkers' Prometheus
+// metrics like the built-in checks.
+func (c *connectivityCheckTemplateProvider) getTemplatesForExternalTargets(ctx context.Context, recorder events.Recorder) []*v1alpha1.PodNetworkConnectivityCheck {
+	cm, err := c.kubeClient.CoreV1().ConfigMaps(names.APPLIED_NAMESPACE).Get(ctx, names.NETWORK_DIAGNOSTICS_CONFIGMAP, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			recorder.Warningf("DiagnosticsConfigError", "failed to read ConfigMap %s: %v", names.NETWORK_DIAGNOSTICS_CONFIGMAP, err)
+		}
+		return nil
+	}
+	raw := cm.Data["external-targets"]
+	if raw == "" {
+		return nil
+	}
+
+	var templates []*v1alpha1.PodNetworkConnectivityCheck
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			recorder.Warningf("DiagnosticsConfigError", "ignoring invalid external-targets entry %q: must be name=host:port", entry)
+			continue
+		}
+		if _, _, err := net.SplitHostPort(parts[1]); err != nil {
+			recorder.Warningf("DiagnosticsConfigError", "ignoring external-targets endpoint %q: %v", parts[1], err)
+			continue
+		}
+		templates = append(templates, NewPodNetworkConnectivityCheckTemplate(parts[1], "openshift-network-diagnostics", withTarget("external", parts[0])))
+	}
+	return templates
+}
+
+// enabledChecks returns a predicate reporting whether a check group should be
+// generated, per the "checks" list in the network diagnostics ConfigMap. An
+// absent ConfigMap or empty list enables every group.
+func (c *connectivityCheckTemplateProvider) enabledChecks(ctx context.Context, recorder events.Recorder) func(string) bool {
+	all := func(string) bool { return true }
+	cm, err := c.kubeClient.CoreV1().ConfigMaps(names.APPLIED_NAMESPACE).Get(ctx, names.NETWORK_DIAGNOSTICS_CONFIGMAP, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			recorder.Warningf("DiagnosticsConfigError", "failed to read ConfigMap %s: %v", names.NETWORK_DIAGNOSTICS_CONFIGMAP, err)
+		}
+		return all
+	}
+	raw := cm.Data["checks"]
+	if raw == "" {
+		return all
+	}
+	selected := map[string]bool{}
+	for _, check := range strings.Split(raw, ",") {
+		selected[strings.TrimSpace(check)] = true
+	}
+	return func(name string) bool { return selected[name] }
+}
+
 type endpointInfo struct {
 	hostName string
 	port     string
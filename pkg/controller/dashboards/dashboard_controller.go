@@ -0,0 +1,102 @@
+package dashboards
+
+// The console discovers dashboards from ConfigMaps in the
+// openshift-config-managed namespace carrying the
+// console.openshift.io/dashboard label. This controller keeps the
+// network dashboards shipped in bindata/dashboards applied, and restores
+// them if they are modified or deleted.
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/openshift/cluster-network-operator/pkg/apply"
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// dashboardNamespace is where the console looks for dashboard ConfigMaps.
+const dashboardNamespace = "openshift-config-managed"
+
+// dashboardNames are the ConfigMaps rendered from bindata/dashboards.
+var dashboardNames = map[string]bool{
+	"grafana-dashboard-network-ovn-dataplane": true,
+}
+
+var manifestDir = "bindata"
+
+// Add attaches the dashboard controller to the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	r := &ReconcileDashboards{client: mgr.GetClient(), status: status}
+
+	c, err := controller.New("dashboard-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch our dashboard ConfigMaps so drift and deletion are repaired.
+	pred := predicate.NewPredicateFuncs(func(object crclient.Object) bool {
+		return object.GetNamespace() == dashboardNamespace && dashboardNames[object.GetName()]
+	})
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestForObject{}, pred)
+	if err != nil {
+		return err
+	}
+
+	// The dashboards may not exist yet, in which case no ConfigMap event
+	// will ever fire; seed one reconcile at startup.
+	initial := make(chan event.GenericEvent, 1)
+	initial <- event.GenericEvent{Object: &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: dashboardNamespace, Name: "dashboards"},
+	}}
+	close(initial)
+	return c.Watch(&source.Channel{Source: initial}, &handler.EnqueueRequestForObject{})
+}
+
+var _ reconcile.Reconciler = &ReconcileDashboards{}
+
+// ReconcileDashboards applies the console dashboard ConfigMaps.
+type ReconcileDashboards struct {
+	client crclient.Client
+	status *statusmanager.StatusManager
+}
+
+// Reconcile renders bindata/dashboards and applies every manifest. The
+// individual request is irrelevant; all dashboards are reapplied together.
+func (r *ReconcileDashboards) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	klog.Infof("Reconciling console dashboards")
+
+	data := render.MakeRenderData()
+	manifests, err := render.RenderDir(filepath.Join(manifestDir, "dashboards"), &data)
+	if err != nil {
+		klog.Errorf("Failed to render dashboards: %v", err)
+		r.status.SetDegraded(statusmanager.DashboardConfig, "RenderDashboardsFailed",
+			fmt.Sprintf("Failed to render dashboards: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	for _, obj := range manifests {
+		if err := apply.ApplyObjectWithFieldManager(ctx, r.client, obj, "cluster-network-operator/dashboards"); err != nil {
+			klog.Errorf("Failed to apply dashboard %s: %v", obj.GetName(), err)
+			r.status.SetDegraded(statusmanager.DashboardConfig, "ApplyDashboardsFailed",
+				fmt.Sprintf("Failed to apply dashboard %s: %v", obj.GetName(), err))
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.status.SetNotDegraded(statusmanager.DashboardConfig)
+	return reconcile.Result{}, nil
+}
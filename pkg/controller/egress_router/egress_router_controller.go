@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"fmt"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"net"
 	"os"
 
 	"github.com/openshift/cluster-network-operator/pkg/apply"
@@ -25,6 +24,7 @@ import (
 
 	netopv1 "github.com/openshift/api/networkoperator/v1"
 	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	appsv1 "k8s.io/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -54,6 +54,16 @@ func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
 		return err
 	}
 
+	// Watch the generated Deployments so pod failures show up in the router
+	// status without waiting for the periodic resync.
+	err = c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{
+		OwnerType:    &netopv1.EgressRouter{},
+		IsController: true,
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -149,6 +159,7 @@ func (r EgressRouterReconciler) Reconcile(ctx context.Context, request reconcile
 	klog.Infof("successful reconciliation")
 	delete(r.egressrouterErrs, request.NamespacedName)
 	r.setStatus()
+	r.updateRouterStatus(ctx, request.NamespacedName)
 	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
 }
 
@@ -192,25 +203,42 @@ func getAllowedDestinationsConfigJSON(RedirectRules []netopv1.L4RedirectRule) (s
 }
 
 func (r *EgressRouterReconciler) ensureEgressRouter(manifestDir string, namespace string, router *netopv1.EgressRouter, EgressRouterOwnerReferences []v1.OwnerReference) error {
-	var err error
-	if len(router.Spec.Addresses) == 0 {
-		return fmt.Errorf("Error: router without addresses")
+	if err := validateEgressRouter(router); err != nil {
+		return errors.Wrap(err, "invalid EgressRouter")
 	}
 	out := []*uns.Unstructured{}
 	data := render.MakeRenderData()
 	data.Data["ReleaseVersion"] = os.Getenv("RELEASE_VERSION")
 	data.Data["EgressRouterNamespace"] = namespace
-	if isItValidCidr(string(router.Spec.Addresses[0].IP)) {
-		data.Data["Addresses"] = router.Spec.Addresses[0].IP
+
+	// All addresses (IPv4, IPv6 or both) go on the router's interface; the
+	// gateway is the first one specified.
+	addresses := make([]string, 0, len(router.Spec.Addresses))
+	gateway := ""
+	for _, address := range router.Spec.Addresses {
+		addresses = append(addresses, address.IP)
+		if gateway == "" {
+			gateway = address.Gateway
+		}
+	}
+	addressesJSON, err := json.Marshal(addresses)
+	if err != nil {
+		return errors.Wrap(err, "failed to render addresses config")
 	}
-	if isItValidIPAddress(router.Spec.Addresses[0].Gateway) {
-		data.Data["Gateway"] = router.Spec.Addresses[0].Gateway
+	data.Data["Addresses"] = string(addressesJSON)
+	data.Data["Gateway"] = gateway
+
+	rules := []netopv1.L4RedirectRule{}
+	fallbackIP := ""
+	if router.Spec.Redirect != nil {
+		rules = router.Spec.Redirect.RedirectRules
+		fallbackIP = router.Spec.Redirect.FallbackIP
 	}
-	data.Data["AllowedDestinations"], err = getAllowedDestinationsConfigJSON(router.Spec.Redirect.RedirectRules)
+	data.Data["AllowedDestinations"], err = getAllowedDestinationsConfigJSON(rules)
 	if err != nil {
 		return errors.Wrap(err, "failed to render AllowedDestinations config")
 	}
-	data.Data["FallbackIP"] = router.Spec.Redirect.FallbackIP
+	data.Data["FallbackIP"] = fallbackIP
 	data.Data["mode"] = router.Spec.Mode
 	data.Data["network_interfaces"] = router.Spec.NetworkInterface
 	data.Data["EgressRouterPodImage"] = os.Getenv("EGRESS_ROUTER_CNI_IMAGE")
@@ -224,7 +252,7 @@ func (r *EgressRouterReconciler) ensureEgressRouter(manifestDir string, namespac
 		klog.Infof("Assigning owner references")
 		obj.SetOwnerReferences(EgressRouterOwnerReferences)
 		klog.Infof("Applying manifest")
-		if err := apply.ApplyObject(context.TODO(), r.client, obj); err != nil {
+		if err := apply.ApplyObjectWithFieldManager(context.TODO(), r.client, obj, "cluster-network-operator/egress-router"); err != nil {
 			klog.Infof("could not apply egress router object: %v", err)
 			return err
 		}
@@ -232,16 +260,3 @@ func (r *EgressRouterReconciler) ensureEgressRouter(manifestDir string, namespac
 
 	return nil
 }
-
-func isItValidCidr(cidr string) bool {
-	_, _, err := net.ParseCIDR(cidr)
-	if err != nil {
-		klog.Error(err)
-		return false
-	}
-	return true
-}
-
-func isItValidIPAddress(ip string) bool {
-	return net.ParseIP(ip) != nil
-}
@@ -0,0 +1,155 @@
+package egress_router
+
+// Status reporting for EgressRouter CRs: the generated pod and NAD are
+// inspected and condensed into the router's status conditions (assigned
+// node, secondary interface state, failure reasons) and an egress_router_up
+// metric.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	netopv1 "github.com/openshift/api/networkoperator/v1"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// networkStatusAnnotation is where multus records the attached networks of a
+// pod, used to verify that the egress-router interface actually came up.
+const networkStatusAnnotation = "k8s.v1.cni.cncf.io/networks-status"
+
+var egressRouterUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "egress_router_up",
+	Help: "1 if the egress router pod is running with its secondary interface attached, 0 otherwise.",
+}, []string{"namespace", "name"})
+
+func init() {
+	prometheus.MustRegister(egressRouterUp)
+}
+
+var nadGVK = schema.GroupVersionKind{
+	Group: "k8s.cni.cncf.io", Version: "v1", Kind: "NetworkAttachmentDefinition",
+}
+
+// updateRouterStatus inspects the router's generated objects and publishes
+// the result as status conditions and the egress_router_up metric.
+func (r *EgressRouterReconciler) updateRouterStatus(ctx context.Context, name types.NamespacedName) {
+	available, reason, message := r.routerHealth(ctx, name.Namespace)
+
+	up := 0.0
+	status := netopv1.ConditionFalse
+	if available {
+		up = 1
+		status = netopv1.ConditionTrue
+	}
+	egressRouterUp.WithLabelValues(name.Namespace, name.Name).Set(up)
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		router := &netopv1.EgressRouter{}
+		if err := r.client.Get(ctx, name, router); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		conditions := append([]netopv1.EgressRouterStatusCondition(nil), router.Status.Conditions...)
+		conditions = setRouterCondition(conditions, netopv1.EgressRouterAvailable, status, reason, message)
+		degraded := netopv1.ConditionFalse
+		if !available {
+			degraded = netopv1.ConditionTrue
+		}
+		conditions = setRouterCondition(conditions, netopv1.EgressRouterDegraded, degraded, reason, message)
+		if equalRouterConditions(router.Status.Conditions, conditions) {
+			return nil
+		}
+		router.Status.Conditions = conditions
+		return r.client.Status().Update(ctx, router)
+	})
+	if err != nil {
+		klog.Errorf("Failed to update status of EgressRouter %s: %v", name, err)
+	}
+}
+
+// routerHealth determines whether the router in the given namespace is
+// serving: the NAD exists and the generated pod is running on a node with the
+// secondary interface attached.
+func (r *EgressRouterReconciler) routerHealth(ctx context.Context, namespace string) (bool, string, string) {
+	nad := &uns.Unstructured{}
+	nad.SetGroupVersionKind(nadGVK)
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "egress-router-cni-nad"}, nad); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, "AttachmentDefinitionMissing", "The egress-router-cni-nad NetworkAttachmentDefinition does not exist"
+		}
+		return false, "AttachmentDefinitionUnknown", fmt.Sprintf("Unable to retrieve the NetworkAttachmentDefinition: %v", err)
+	}
+
+	pods := &corev1.PodList{}
+	err := r.client.List(ctx, pods, crclient.InNamespace(namespace), crclient.MatchingLabels{"app": "egress-router-cni"})
+	if err != nil {
+		return false, "PodListFailed", fmt.Sprintf("Unable to list egress router pods: %v", err)
+	}
+	if len(pods.Items) == 0 {
+		return false, "PodMissing", "No egress router pod exists"
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if !strings.Contains(pod.Annotations[networkStatusAnnotation], "egress-router-cni-nad") {
+			return false, "InterfaceNotAttached",
+				fmt.Sprintf("Pod %s is running on node %s but the egress-router interface is not attached", pod.Name, pod.Spec.NodeName)
+		}
+		return true, "RouterRunning",
+			fmt.Sprintf("Pod %s is running on node %s with the egress-router interface attached", pod.Name, pod.Spec.NodeName)
+	}
+	pod := &pods.Items[0]
+	return false, "PodNotRunning",
+		fmt.Sprintf("Pod %s is in phase %s on node %q", pod.Name, pod.Status.Phase, pod.Spec.NodeName)
+}
+
+// setRouterCondition upserts a condition, preserving LastTransitionTime when
+// the status is unchanged.
+func setRouterCondition(conditions []netopv1.EgressRouterStatusCondition, ctype netopv1.EgressRouterStatusConditionType, status netopv1.ConditionStatus, reason, message string) []netopv1.EgressRouterStatusCondition {
+	condition := netopv1.EgressRouterStatusCondition{
+		Type:               ctype,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+	for i, existing := range conditions {
+		if existing.Type != ctype {
+			continue
+		}
+		if existing.Status == status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		conditions[i] = condition
+		return conditions
+	}
+	return append(conditions, condition)
+}
+
+func equalRouterConditions(a, b []netopv1.EgressRouterStatusCondition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].Status != b[i].Status || a[i].Reason != b[i].Reason || a[i].Message != b[i].Message {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package egress_router
+
+// Validation of EgressRouter CRs before any NAD is rendered, so a bad spec
+// degrades with a pointed message instead of producing a broken attachment.
+
+import (
+	"fmt"
+	"net"
+
+	netopv1 "github.com/openshift/api/networkoperator/v1"
+)
+
+// validateEgressRouter checks the addresses and redirect rules of the router.
+// Addresses may be IPv4, IPv6 or one of each (dual-stack); redirect rules may
+// name multiple destinations of either family.
+func validateEgressRouter(router *netopv1.EgressRouter) error {
+	if len(router.Spec.Addresses) == 0 {
+		return fmt.Errorf("EgressRouter must specify at least one address")
+	}
+
+	seenFamily := map[bool]bool{}
+	for _, address := range router.Spec.Addresses {
+		ip, _, err := net.ParseCIDR(address.IP)
+		if err != nil {
+			return fmt.Errorf("invalid address %q: %v", address.IP, err)
+		}
+		v4 := ip.To4() != nil
+		if seenFamily[v4] {
+			return fmt.Errorf("at most one address per IP family is allowed; %q repeats a family", address.IP)
+		}
+		seenFamily[v4] = true
+
+		if address.Gateway != "" {
+			gw := net.ParseIP(address.Gateway)
+			if gw == nil {
+				return fmt.Errorf("invalid gateway %q", address.Gateway)
+			}
+			if (gw.To4() != nil) != v4 {
+				return fmt.Errorf("gateway %q is not in the same IP family as address %q", address.Gateway, address.IP)
+			}
+		}
+	}
+
+	if router.Spec.Redirect == nil {
+		return nil
+	}
+	for _, rule := range router.Spec.Redirect.RedirectRules {
+		if net.ParseIP(rule.DestinationIP) == nil {
+			return fmt.Errorf("invalid redirect destination %q", rule.DestinationIP)
+		}
+		if rule.Port < 0 || rule.Port > 65535 {
+			return fmt.Errorf("invalid redirect port %d", rule.Port)
+		}
+		if rule.TargetPort < 0 || rule.TargetPort > 65535 {
+			return fmt.Errorf("invalid redirect target port %d", rule.TargetPort)
+		}
+		if rule.Port != 0 {
+			switch rule.Protocol {
+			case netopv1.ProtocolTypeTCP, netopv1.ProtocolTypeUDP, netopv1.ProtocolTypeSCTP:
+			default:
+				return fmt.Errorf("invalid redirect protocol %q", rule.Protocol)
+			}
+		}
+	}
+
+	if fallback := router.Spec.Redirect.FallbackIP; fallback != "" && net.ParseIP(fallback) == nil {
+		return fmt.Errorf("invalid fallback IP %q", fallback)
+	}
+	return nil
+}
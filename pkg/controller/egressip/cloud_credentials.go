@@ -0,0 +1,116 @@
+package egressip
+
+// Cloud credential health for egress IP assignment. Clusters using
+// short-lived STS / workload-identity credentials rotate the cloud
+// credentials Secret frequently; an expired token or missing permission
+// otherwise only shows up as silent CloudPrivateIPConfig assignment
+// failures. Rotations are detected and logged, credential expiry is exported
+// as a metric, and expiry or permission errors are surfaced as an explicit
+// condition.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// The Secret holding the cloud credentials used for egress IP assignment.
+const (
+	cloudCredentialsNamespace = "openshift-cloud-network-config-controller"
+	cloudCredentialsSecret    = "cloud-credentials"
+)
+
+// credentialExpiryWarning is how close to expiry short-lived credentials may
+// get before they are reported; a working refresher replaces them well
+// before this.
+const credentialExpiryWarning = 10 * time.Minute
+
+// cloudCredentialsExpiry reports the expiration timestamp of the cloud
+// credentials, when they carry one.
+var cloudCredentialsExpiry = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_cloud_credentials_expiry_timestamp_seconds",
+	Help: "Expiration timestamp of the short-lived cloud credentials used for egress IP assignment.",
+})
+
+// cloudCredentialsLastRotation reports when the cloud credentials Secret was
+// last observed to change.
+var cloudCredentialsLastRotation = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_cloud_credentials_last_rotation_timestamp_seconds",
+	Help: "Timestamp of the last observed rotation of the cloud credentials Secret.",
+})
+
+func init() {
+	prometheus.MustRegister(cloudCredentialsExpiry)
+	prometheus.MustRegister(cloudCredentialsLastRotation)
+}
+
+// checkCloudCredentials inspects the cloud credentials Secret for rotation
+// and expiry and returns descriptions of any credential problems. On
+// clusters without the Secret it is a no-op.
+func (c *egressIPHealthChecker) checkCloudCredentials(ctx context.Context) []string {
+	secret := &corev1.Secret{}
+	err := c.client.Get(ctx, types.NamespacedName{Namespace: cloudCredentialsNamespace, Name: cloudCredentialsSecret}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		klog.Errorf("Failed to inspect cloud credentials: %v", err)
+		return nil
+	}
+
+	if c.lastCredentialsVersion != "" && secret.ResourceVersion != c.lastCredentialsVersion {
+		klog.Infof("Cloud credentials Secret %s/%s was rotated", cloudCredentialsNamespace, cloudCredentialsSecret)
+		cloudCredentialsLastRotation.Set(float64(time.Now().Unix()))
+	}
+	c.lastCredentialsVersion = secret.ResourceVersion
+
+	expiry, ok := credentialExpiry(secret)
+	if !ok {
+		// Long-lived credentials without an expiration.
+		return nil
+	}
+	cloudCredentialsExpiry.Set(float64(expiry.Unix()))
+
+	switch {
+	case time.Now().After(expiry):
+		return []string{fmt.Sprintf("cloud credentials expired %s and have not been refreshed", expiry.UTC().Format(time.RFC3339))}
+	case time.Until(expiry) < credentialExpiryWarning:
+		return []string{fmt.Sprintf("cloud credentials expire %s and have not been refreshed", expiry.UTC().Format(time.RFC3339))}
+	}
+	return nil
+}
+
+// credentialExpiry extracts the expiration of short-lived credentials, when
+// the Secret carries one (the RFC3339 "expiration" key written by STS /
+// workload-identity token refreshers).
+func credentialExpiry(secret *corev1.Secret) (time.Time, bool) {
+	raw := strings.TrimSpace(string(secret.Data["expiration"]))
+	if raw == "" {
+		return time.Time{}, false
+	}
+	expiry, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		klog.Warningf("Could not parse cloud credentials expiration %q: %v", raw, err)
+		return time.Time{}, false
+	}
+	return expiry, true
+}
+
+// isCredentialError reports whether a cloud assignment failure message
+// points at the cloud credentials rather than the request itself.
+func isCredentialError(message string) bool {
+	m := strings.ToLower(message)
+	for _, marker := range []string{"accessdenied", "unauthorized", "forbidden", "credential", "expired token", "invalid token", "401", "403"} {
+		if strings.Contains(m, marker) {
+			return true
+		}
+	}
+	return false
+}
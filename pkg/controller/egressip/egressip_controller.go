@@ -0,0 +1,177 @@
+package egressip
+
+// The egressip controller does not manage EgressIP objects; it watches the
+// ones users create (and the cloud assignment results) and aggregates their
+// health into operator conditions and metrics, so broken egress IP
+// configurations degrade the operator visibly.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ResyncPeriod is how often egress IP health is recomputed.
+var ResyncPeriod = 3 * time.Minute
+
+var egressIPListGVK = schema.GroupVersionKind{
+	Group:   "k8s.ovn.org",
+	Version: "v1",
+	Kind:    "EgressIPList",
+}
+
+var cloudPrivateIPConfigListGVK = schema.GroupVersionKind{
+	Group:   "cloud.network.openshift.io",
+	Version: "v1",
+	Kind:    "CloudPrivateIPConfigList",
+}
+
+// unassignedEgressIPs reports how many requested egress IPs have no node
+// assignment.
+var unassignedEgressIPs = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_egressip_unassigned",
+	Help: "Number of requested egress IPs without a node assignment.",
+})
+
+// conflictingEgressIPs reports egress IP addresses claimed by more than one
+// EgressIP object.
+var conflictingEgressIPs = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_egressip_conflicting",
+	Help: "Number of egress IP addresses claimed by more than one EgressIP object.",
+})
+
+// failedCloudPrivateIPConfigs reports cloud IP assignments that the cloud
+// network config controller could not satisfy.
+var failedCloudPrivateIPConfigs = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_cloudprivateipconfig_failed",
+	Help: "Number of CloudPrivateIPConfigs that failed cloud assignment.",
+})
+
+func init() {
+	prometheus.MustRegister(unassignedEgressIPs)
+	prometheus.MustRegister(conflictingEgressIPs)
+	prometheus.MustRegister(failedCloudPrivateIPConfigs)
+}
+
+// Add registers the egress IP health checker with the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	checker := &egressIPHealthChecker{client: mgr.GetClient(), status: status}
+	return mgr.Add(manager.RunnableFunc(checker.run))
+}
+
+type egressIPHealthChecker struct {
+	client client.Client
+	status *statusmanager.StatusManager
+
+	// lastCredentialsVersion is the resourceVersion of the cloud
+	// credentials Secret at the previous pass, for rotation detection.
+	lastCredentialsVersion string
+}
+
+func (c *egressIPHealthChecker) run(ctx context.Context) error {
+	ticker := time.NewTicker(ResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// check computes aggregate egress IP health. On clusters without the EgressIP
+// CRD (e.g. openshift-sdn) it is a no-op.
+func (c *egressIPHealthChecker) check(ctx context.Context) {
+	problems := []string{}
+
+	egressIPs := &uns.UnstructuredList{}
+	egressIPs.SetGroupVersionKind(egressIPListGVK)
+	if err := c.client.List(ctx, egressIPs); err != nil {
+		if !meta.IsNoMatchError(err) {
+			klog.Errorf("Failed to list EgressIPs: %v", err)
+		}
+		return
+	}
+
+	unassigned := 0
+	claims := map[string][]string{}
+	for _, eip := range egressIPs.Items {
+		requested, _, _ := uns.NestedStringSlice(eip.Object, "spec", "egressIPs")
+		assigned, _, _ := uns.NestedSlice(eip.Object, "status", "items")
+		if len(assigned) < len(requested) {
+			unassigned += len(requested) - len(assigned)
+			problems = append(problems, fmt.Sprintf("EgressIP %q has %d of %d egress IPs assigned", eip.GetName(), len(assigned), len(requested)))
+		}
+		for _, ip := range requested {
+			claims[ip] = append(claims[ip], eip.GetName())
+		}
+	}
+	conflicts := 0
+	for ip, claimants := range claims {
+		if len(claimants) > 1 {
+			conflicts++
+			sort.Strings(claimants)
+			problems = append(problems, fmt.Sprintf("egress IP %s is claimed by multiple EgressIP objects: %s", ip, strings.Join(claimants, ", ")))
+		}
+	}
+	unassignedEgressIPs.Set(float64(unassigned))
+	conflictingEgressIPs.Set(float64(conflicts))
+
+	credProblems := c.checkCloudCredentials(ctx)
+	failedCloudPrivateIPConfigs.Set(float64(c.countFailedCloudAssignments(ctx, &problems, &credProblems)))
+	c.status.SetCloudCredentialProblems(credProblems)
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		c.status.SetDegraded(statusmanager.EgressIPConfig, "EgressIPUnhealthy", strings.Join(problems, "\n"))
+	} else {
+		c.status.SetNotDegraded(statusmanager.EgressIPConfig)
+	}
+}
+
+// countFailedCloudAssignments inspects CloudPrivateIPConfigs (when the CRD
+// exists) for assignments the cloud could not satisfy. Failures caused by
+// the cloud credentials are additionally collected into credProblems.
+func (c *egressIPHealthChecker) countFailedCloudAssignments(ctx context.Context, problems *[]string, credProblems *[]string) int {
+	configs := &uns.UnstructuredList{}
+	configs.SetGroupVersionKind(cloudPrivateIPConfigListGVK)
+	if err := c.client.List(ctx, configs); err != nil {
+		if !meta.IsNoMatchError(err) {
+			klog.Errorf("Failed to list CloudPrivateIPConfigs: %v", err)
+		}
+		return 0
+	}
+
+	failed := 0
+	for _, config := range configs.Items {
+		conditions, _, _ := uns.NestedSlice(config.Object, "status", "conditions")
+		for _, cond := range conditions {
+			condMap, ok := cond.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if condMap["type"] == "Assigned" && condMap["status"] == "False" {
+				failed++
+				*problems = append(*problems, fmt.Sprintf("CloudPrivateIPConfig %q failed cloud assignment: %v", config.GetName(), condMap["message"]))
+				if message, ok := condMap["message"].(string); ok && isCredentialError(message) {
+					*credProblems = append(*credProblems, fmt.Sprintf("CloudPrivateIPConfig %q failed with a credential error: %s", config.GetName(), message))
+				}
+			}
+		}
+	}
+	return failed
+}
@@ -0,0 +1,113 @@
+package ingressconfig
+
+// Conflict detection between the ingress controller configuration and the
+// network configuration: a HostNetwork ingress collides with a node port
+// range that covers its fixed host ports, and an externalIP policy can
+// reject the CIDRs that load balancer IPs are auto-assigned from. These are
+// reported as an explicit condition rather than left to surface as obscure
+// dataplane failures.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	iputil "github.com/openshift/cluster-network-operator/pkg/util/ip"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// hostNetworkIngressPorts are the host ports a HostNetwork ingress
+// controller binds on its nodes.
+var hostNetworkIngressPorts = []int{80, 443, 1936}
+
+// defaultNodePortRange is the kube-apiserver default service node port range.
+const defaultNodePortRange = "30000-32767"
+
+// checkNetworkConflicts returns descriptions of the conflicts between the
+// given ingress controller and the cluster network configuration.
+func (r *ReconcileIngressConfigs) checkNetworkConflicts(ctx context.Context, ic *operv1.IngressController) ([]string, error) {
+	clusterConfig := &configv1.Network{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: names.CLUSTER_CONFIG}, clusterConfig); err != nil {
+		return nil, err
+	}
+
+	strategy := ic.Status.EndpointPublishingStrategy
+	if strategy == nil {
+		strategy = ic.Spec.EndpointPublishingStrategy
+	}
+	if strategy == nil {
+		return nil, nil
+	}
+
+	problems := []string{}
+	switch strategy.Type {
+	case operv1.HostNetworkStrategyType:
+		nodePortRange := clusterConfig.Spec.ServiceNodePortRange
+		if nodePortRange == "" {
+			nodePortRange = defaultNodePortRange
+		}
+		lo, hi, err := parseNodePortRange(nodePortRange)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse serviceNodePortRange %q: %v", nodePortRange, err)
+		}
+		for _, port := range hostNetworkIngressPorts {
+			if port >= lo && port <= hi {
+				problems = append(problems, fmt.Sprintf("host network ingress port %d is within the service node port range %s", port, nodePortRange))
+			}
+		}
+	case operv1.LoadBalancerServiceStrategyType:
+		problems = append(problems, checkExternalIPConflicts(clusterConfig.Spec.ExternalIP)...)
+	}
+	return problems, nil
+}
+
+// checkExternalIPConflicts reports auto-assign CIDRs that the externalIP
+// policy rejects, which would leave load balancer services without an IP.
+func checkExternalIPConflicts(externalIP *configv1.ExternalIPConfig) []string {
+	if externalIP == nil || externalIP.Policy == nil {
+		return nil
+	}
+	problems := []string{}
+	for _, auto := range externalIP.AutoAssignCIDRs {
+		_, autoNet, err := net.ParseCIDR(auto)
+		if err != nil {
+			continue
+		}
+		for _, rejected := range externalIP.Policy.RejectedCIDRs {
+			_, rejectedNet, err := net.ParseCIDR(rejected)
+			if err != nil {
+				continue
+			}
+			if iputil.NetsOverlap(*autoNet, *rejectedNet) {
+				problems = append(problems, fmt.Sprintf("externalIP auto-assign CIDR %s overlaps rejected CIDR %s", auto, rejected))
+			}
+		}
+	}
+	return problems
+}
+
+// parseNodePortRange parses a "lo-hi" port range.
+func parseNodePortRange(raw string) (int, int, error) {
+	parts := strings.SplitN(raw, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected a lo-hi range")
+	}
+	lo, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	if lo < 1 || hi > 65535 || lo > hi {
+		return 0, 0, fmt.Errorf("invalid port range %d-%d", lo, hi)
+	}
+	return lo, hi, nil
+}
@@ -32,12 +32,12 @@ var ManifestPath = "./bindata"
 // Add creates a new ingressConfig controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
-	return add(mgr, newIngressConfigReconciler(mgr.GetClient()))
+	return add(mgr, newIngressConfigReconciler(mgr.GetClient(), status))
 }
 
 // newIngressConfigReconciler returns a new reconcile.Reconciler
-func newIngressConfigReconciler(client client.Client) *ReconcileIngressConfigs {
-	return &ReconcileIngressConfigs{client: client}
+func newIngressConfigReconciler(client client.Client, status *statusmanager.StatusManager) *ReconcileIngressConfigs {
+	return &ReconcileIngressConfigs{client: client, status: status}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -60,6 +60,7 @@ var _ reconcile.Reconciler = &ReconcileIngressConfigs{}
 // and sets the network policy related labels on the openshift-host-network namespace
 type ReconcileIngressConfigs struct {
 	client client.Client
+	status *statusmanager.StatusManager
 }
 
 // Reconcile sets the openshift-host-network namespaces' labels as per the
@@ -99,6 +100,17 @@ func (r *ReconcileIngressConfigs) Reconcile(ctx context.Context, request reconci
 		log.Printf("Error setting the host network label on namespace %s: %v", names.HostNetworkNamespace, err)
 		return reconcile.Result{}, err
 	}
+
+	// Surface conflicts between the ingress and network configuration as an
+	// explicit condition instead of leaving them to show up as dataplane
+	// failures.
+	conflicts, err := r.checkNetworkConflicts(ctx, ingressControllerConfig)
+	if err != nil {
+		log.Printf("Error checking for ingress/network configuration conflicts: %v", err)
+		return reconcile.Result{}, err
+	}
+	r.status.SetIngressConflicts(conflicts)
+
 	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
 }
 
@@ -0,0 +1,112 @@
+package ipsec
+
+// The ipsec controller scrapes the tunnel-state aggregation ConfigMap the
+// ovn-ipsec pods maintain and republishes it as per-node Prometheus gauges,
+// alongside the configured IPsec mode.
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	ipsecutil "github.com/openshift/cluster-network-operator/pkg/util/ipsec"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ResyncPeriod is how often IPsec tunnel state is rescraped.
+var ResyncPeriod = 2 * time.Minute
+
+// Add registers the IPsec metrics collector with the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	collector := &metricsCollector{client: mgr.GetClient(), status: status}
+	return mgr.Add(manager.RunnableFunc(collector.run))
+}
+
+type metricsCollector struct {
+	client client.Client
+	status *statusmanager.StatusManager
+
+	// rolloutSince is when the current IPsec rollout was first observed
+	// unconverged; zero when no rollout is in progress.
+	rolloutSince time.Time
+}
+
+func (c *metricsCollector) run(ctx context.Context) error {
+	ticker := time.NewTicker(ResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.collect(ctx)
+		}
+	}
+}
+
+func (c *metricsCollector) collect(ctx context.Context) {
+	mode := c.currentMode(ctx)
+	ipsecutil.SetMode(mode)
+
+	statuses := c.nodeStatuses(ctx)
+	ipsecutil.SetTunnelStatus(statuses)
+	c.trackRollout(ctx, mode, statuses)
+}
+
+// nodeStatuses parses the per-node tunnel state out of the IPsec status
+// ConfigMap.
+func (c *metricsCollector) nodeStatuses(ctx context.Context) map[string]ipsecutil.TunnelStatus {
+	statuses := map[string]ipsecutil.TunnelStatus{}
+
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.IPSEC_STATUS_CONFIGMAP}
+	if err := c.client.Get(ctx, nsn, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to get IPsec status ConfigMap: %v", err)
+		}
+		return statuses
+	}
+
+	for key, raw := range cm.Data {
+		if !strings.HasPrefix(key, "node.") {
+			continue
+		}
+		node := strings.TrimPrefix(key, "node.")
+		status, err := ipsecutil.ParseNodeStatus(raw)
+		if err != nil {
+			klog.Warningf("Ignoring malformed IPsec status for node %s: %v", node, err)
+			continue
+		}
+		statuses[node] = status
+	}
+	return statuses
+}
+
+// currentMode derives the effective IPsec mode from the operator config and
+// the IPsec tuning ConfigMap.
+func (c *metricsCollector) currentMode(ctx context.Context) string {
+	operConfig := &operv1.Network{}
+	if err := c.client.Get(ctx, types.NamespacedName{Name: names.OPERATOR_CONFIG}, operConfig); err != nil {
+		return ""
+	}
+	ovn := operConfig.Spec.DefaultNetwork.OVNKubernetesConfig
+	if ovn == nil || ovn.IPsecConfig == nil {
+		return ""
+	}
+	mode := "full"
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.IPSEC_CONFIG_CONFIGMAP}
+	if err := c.client.Get(ctx, nsn, cm); err == nil && cm.Data["mode"] != "" {
+		mode = cm.Data["mode"]
+	}
+	return mode
+}
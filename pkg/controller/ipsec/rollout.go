@@ -0,0 +1,93 @@
+package ipsec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	ipsecutil "github.com/openshift/cluster-network-operator/pkg/util/ipsec"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// RolloutStallTimeout is how long an IPsec enable/disable rollout may stay
+// unconverged before the operator degrades.
+var RolloutStallTimeout = 30 * time.Minute
+
+// trackRollout maintains the IPsecRolloutProgressing condition while IPsec is
+// being enabled or disabled: it watches the ovn-ipsec DaemonSet convergence
+// and the per-node tunnel state, reports complete only when every node
+// encrypts, and degrades if the rollout stalls beyond RolloutStallTimeout.
+func (c *metricsCollector) trackRollout(ctx context.Context, mode string, statuses map[string]ipsecutil.TunnelStatus) {
+	enabled := mode != ""
+
+	ds := &appsv1.DaemonSet{}
+	dsExists := true
+	err := c.client.Get(ctx, types.NamespacedName{Namespace: "openshift-ovn-kubernetes", Name: "ovn-ipsec"}, ds)
+	if apierrors.IsNotFound(err) {
+		dsExists = false
+	} else if err != nil {
+		klog.Errorf("Failed to get ovn-ipsec daemonset: %v", err)
+		return
+	}
+
+	progressing := false
+	message := ""
+	switch {
+	case !enabled && dsExists:
+		progressing = true
+		message = "IPsec is disabled but the ovn-ipsec DaemonSet has not been removed yet"
+	case enabled && !dsExists:
+		progressing = true
+		message = "IPsec is enabled but the ovn-ipsec DaemonSet has not been created yet"
+	case enabled:
+		desired := ds.Status.DesiredNumberScheduled
+		if ds.Status.UpdatedNumberScheduled < desired || ds.Status.NumberAvailable < desired || ds.Status.NumberUnavailable > 0 {
+			progressing = true
+			message = fmt.Sprintf("ovn-ipsec DaemonSet is rolling out (%d out of %d nodes updated, %d available)",
+				ds.Status.UpdatedNumberScheduled, desired, ds.Status.NumberAvailable)
+		} else if down := nodesNotEncrypting(statuses); len(down) > 0 {
+			// Only nodes actively reporting unhealthy tunnels hold the
+			// rollout open. Nodes absent from the telemetry (an older image
+			// without the status sidecar, or a freshly created ConfigMap)
+			// are not evidence of an unconverged rollout.
+			progressing = true
+			message = fmt.Sprintf("waiting for all nodes to encrypt: %d of %d reporting nodes have unhealthy tunnels",
+				len(down), len(statuses))
+		}
+	}
+
+	if !progressing {
+		c.rolloutSince = time.Time{}
+		c.status.SetIPsecRollout(false, "", "")
+		c.status.SetNotDegraded(statusmanager.IPsecConfig)
+		return
+	}
+
+	if c.rolloutSince.IsZero() {
+		c.rolloutSince = time.Now()
+	}
+	c.status.SetIPsecRollout(true, "IPsecRollingOut", message)
+	if time.Since(c.rolloutSince) > RolloutStallTimeout {
+		c.status.SetDegraded(statusmanager.IPsecConfig, "IPsecRolloutStalled",
+			fmt.Sprintf("IPsec rollout has not converged in %s: %s", RolloutStallTimeout, message))
+	} else {
+		c.status.SetNotDegraded(statusmanager.IPsecConfig)
+	}
+}
+
+// nodesNotEncrypting returns the nodes reporting unestablished tunnels or
+// failed SA negotiations.
+func nodesNotEncrypting(statuses map[string]ipsecutil.TunnelStatus) []string {
+	down := []string{}
+	for node, status := range statuses {
+		if status.Down > 0 || status.FailedSANeg > 0 {
+			down = append(down, node)
+		}
+	}
+	return down
+}
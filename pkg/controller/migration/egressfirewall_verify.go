@@ -0,0 +1,202 @@
+package migration
+
+// Verification of the EgressNetworkPolicy -> EgressFirewall conversion during
+// a migration to OVN-Kubernetes. The converted EgressFirewalls are re-read
+// and semantically diffed against the original EgressNetworkPolicies; rules
+// that cannot be expressed on OVN-Kubernetes (wildcard DNS names, rule-count
+// overflow) and policies that were not converted at all are reported in a
+// report ConfigMap and the Degraded condition.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// maxEgressFirewallRules is the most rules OVN-Kubernetes accepts in a single
+// EgressFirewall.
+const maxEgressFirewallRules = 8000
+
+var egressNetworkPolicyGVK = schema.GroupVersionKind{
+	Group: "network.openshift.io", Version: "v1", Kind: "EgressNetworkPolicyList",
+}
+
+var egressFirewallGVK = schema.GroupVersionKind{
+	Group: "k8s.ovn.org", Version: "v1", Kind: "EgressFirewallList",
+}
+
+// egressRule is the comparable core of one egress rule on either API.
+type egressRule struct {
+	ruleType string
+	cidr     string
+	dnsName  string
+}
+
+// verifyEgressFirewallMigration diffs converted EgressFirewalls against the
+// original EgressNetworkPolicies and returns the problems found, along with
+// how many policies were checked.
+func (t *progressTracker) verifyEgressFirewallMigration(ctx context.Context) ([]string, int) {
+	enps := &uns.UnstructuredList{}
+	enps.SetGroupVersionKind(egressNetworkPolicyGVK)
+	if err := t.client.List(ctx, enps); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, 0
+		}
+		klog.Errorf("Failed to list EgressNetworkPolicies: %v", err)
+		return nil, 0
+	}
+	if len(enps.Items) == 0 {
+		return nil, 0
+	}
+
+	firewalls := map[string][]egressRule{}
+	efs := &uns.UnstructuredList{}
+	efs.SetGroupVersionKind(egressFirewallGVK)
+	if err := t.client.List(ctx, efs); err != nil {
+		if !meta.IsNoMatchError(err) {
+			klog.Errorf("Failed to list EgressFirewalls: %v", err)
+			return nil, 0
+		}
+		// CRD absent: every policy below reports as unconverted.
+	} else {
+		for _, ef := range efs.Items {
+			firewalls[ef.GetNamespace()] = parseEgressRules(&ef)
+		}
+	}
+
+	problems := []string{}
+	for _, enp := range enps.Items {
+		namespace := enp.GetNamespace()
+		rules := parseEgressRules(&enp)
+
+		if len(rules) > maxEgressFirewallRules {
+			problems = append(problems, fmt.Sprintf("namespace %s: policy has %d rules, more than the %d EgressFirewall supports",
+				namespace, len(rules), maxEgressFirewallRules))
+		}
+		for _, rule := range rules {
+			if rule.dnsName != "" && rule.dnsName[0] == '*' {
+				problems = append(problems, fmt.Sprintf("namespace %s: wildcard DNS name %q is not supported by EgressFirewall",
+					namespace, rule.dnsName))
+			}
+		}
+
+		converted, ok := firewalls[namespace]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("namespace %s: EgressNetworkPolicy has no converted EgressFirewall", namespace))
+			continue
+		}
+		if missing := missingRules(rules, converted); len(missing) > 0 {
+			for _, rule := range missing {
+				problems = append(problems, fmt.Sprintf("namespace %s: rule %s not present in the converted EgressFirewall",
+					namespace, describeRule(rule)))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems, len(enps.Items)
+}
+
+// parseEgressRules extracts the comparable egress rules from either an
+// EgressNetworkPolicy or an EgressFirewall.
+func parseEgressRules(obj *uns.Unstructured) []egressRule {
+	items, _, _ := uns.NestedSlice(obj.Object, "spec", "egress")
+	rules := make([]egressRule, 0, len(items))
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rule := egressRule{}
+		rule.ruleType, _, _ = uns.NestedString(entry, "type")
+		if cidr, ok, _ := uns.NestedString(entry, "to", "cidrSelector"); ok {
+			rule.cidr = cidr
+		}
+		if dns, ok, _ := uns.NestedString(entry, "to", "dnsName"); ok {
+			rule.dnsName = dns
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// missingRules returns the rules in want that do not appear in have.
+func missingRules(want, have []egressRule) []egressRule {
+	counts := map[egressRule]int{}
+	for _, rule := range have {
+		counts[rule]++
+	}
+	missing := []egressRule{}
+	for _, rule := range want {
+		if counts[rule] > 0 {
+			counts[rule]--
+			continue
+		}
+		missing = append(missing, rule)
+	}
+	return missing
+}
+
+func describeRule(rule egressRule) string {
+	to := rule.cidr
+	if rule.dnsName != "" {
+		to = rule.dnsName
+	}
+	return fmt.Sprintf("%s %s", rule.ruleType, to)
+}
+
+// publishEgressFirewallReport writes the verification outcome to the report
+// ConfigMap.
+func (t *progressTracker) publishEgressFirewallReport(ctx context.Context, checked int, problems []string) {
+	data := map[string]string{
+		"policies-checked": strconv.Itoa(checked),
+		"checked-at":       time.Now().UTC().Format(time.RFC3339),
+	}
+	if len(problems) == 0 {
+		data["result"] = "verified"
+	} else {
+		data["result"] = "incomplete"
+		for i, problem := range problems {
+			data[fmt.Sprintf("problem.%d", i+1)] = problem
+		}
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.EGRESS_FIREWALL_MIGRATION_CONFIGMAP}
+		err := t.client.Get(ctx, nsn, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: data,
+			}
+			return t.client.Create(ctx, cm)
+		} else if err != nil {
+			return err
+		}
+		if equalStringMaps(cm.Data, data) {
+			return nil
+		}
+		cm.Data = data
+		return t.client.Update(ctx, cm)
+	})
+	if err != nil {
+		klog.Errorf("Failed to publish EgressFirewall migration report: %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package migration
+
+// The migration controller tracks a default network live migration
+// (Spec.Migration) as it progresses across nodes. The node-side tooling
+// annotates each Node with its migration state; this controller aggregates
+// the states into a status ConfigMap and Prometheus metrics so large clusters
+// can follow the migration without scraping events.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ResyncPeriod is how often migration progress is recomputed.
+var ResyncPeriod = 1 * time.Minute
+
+// Per-node live migration states, in rollout order.
+const (
+	migrationStatePending         = "pending"
+	migrationStateRoutesInstalled = "routes-installed"
+	migrationStateCNISwitched     = "cni-switched"
+	migrationStateDone            = "done"
+)
+
+var migrationStates = []string{migrationStatePending, migrationStateRoutesInstalled, migrationStateCNISwitched, migrationStateDone}
+
+// liveMigrationNodes reports how many nodes are in each live migration state.
+var liveMigrationNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_live_migration_nodes",
+	Help: "Number of nodes per default network live migration state.",
+}, []string{"state"})
+
+func init() {
+	prometheus.MustRegister(liveMigrationNodes)
+}
+
+// Add registers the migration progress tracker with the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	tracker := &progressTracker{client: mgr.GetClient(), status: status}
+	return mgr.Add(manager.RunnableFunc(tracker.run))
+}
+
+type progressTracker struct {
+	client client.Client
+	status *statusmanager.StatusManager
+}
+
+func (t *progressTracker) run(ctx context.Context) error {
+	ticker := time.NewTicker(ResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			t.sync(ctx)
+		}
+	}
+}
+
+func (t *progressTracker) sync(ctx context.Context) {
+	operConfig := &operv1.Network{}
+	if err := t.client.Get(ctx, types.NamespacedName{Name: names.OPERATOR_CONFIG}, operConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to get operator configuration: %v", err)
+		}
+		return
+	}
+
+	// A rollback request takes precedence over progress tracking.
+	if rollbackRequested(operConfig) {
+		t.rollbackMigration(ctx, operConfig)
+		return
+	}
+
+	migration := operConfig.Spec.Migration
+	if migration == nil || migration.NetworkType == "" {
+		// No migration in progress; any previous status is stale.
+		for _, state := range migrationStates {
+			liveMigrationNodes.WithLabelValues(state).Set(0)
+		}
+		t.deleteStatus(ctx)
+		t.status.SetNotDegraded(statusmanager.MigrationConfig)
+		return
+	}
+
+	tuning, err := t.getMigrationTuning(ctx)
+	if err != nil {
+		klog.Errorf("Invalid migration tuning: %v", err)
+		t.status.SetDegraded(statusmanager.MigrationConfig, "InvalidMigrationTuning",
+			fmt.Sprintf("Error while reading the %s ConfigMap: %v", names.MIGRATION_TUNING_CONFIGMAP, err))
+		return
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := t.client.List(ctx, nodes); err != nil {
+		klog.Errorf("Failed to list nodes: %v", err)
+		return
+	}
+
+	counts := map[string]int{}
+	data := map[string]string{"target": string(migration.NetworkType)}
+	for _, node := range nodes.Items {
+		state := migrationStatePending
+		switch annotated := node.Annotations[names.NodeMigrationStateAnnotation]; annotated {
+		case migrationStateRoutesInstalled, migrationStateCNISwitched, migrationStateDone:
+			state = annotated
+		case "":
+		default:
+			klog.Warningf("Ignoring unknown migration state %q on node %s", annotated, node.Name)
+		}
+		counts[state]++
+		data["node."+node.Name] = state
+	}
+	for _, state := range migrationStates {
+		liveMigrationNodes.WithLabelValues(state).Set(float64(counts[state]))
+	}
+	data["nodes-total"] = strconv.Itoa(len(nodes.Items))
+	data["nodes-done"] = strconv.Itoa(counts[migrationStateDone])
+	applyTuning(data, tuning, counts, time.Now())
+
+	if err := t.publishStatus(ctx, data); err != nil {
+		klog.Errorf("Failed to publish migration status: %v", err)
+	}
+
+	// When migrating to OVN-Kubernetes, verify that the EgressNetworkPolicy
+	// conversion is faithful.
+	if migration.NetworkType == operv1.NetworkTypeOVNKubernetes {
+		// Publish the multicast enablement dry-run so administrators can audit
+		// the annotation changes before they are made.
+		t.auditMulticastMigration(ctx)
+
+		problems, checked := t.verifyEgressFirewallMigration(ctx)
+		if checked > 0 {
+			t.publishEgressFirewallReport(ctx, checked, problems)
+		}
+		if len(problems) > 0 {
+			t.status.SetDegraded(statusmanager.MigrationConfig, "EgressFirewallMigrationIncomplete",
+				fmt.Sprintf("EgressNetworkPolicy conversion is incomplete; see the %s/%s ConfigMap for details",
+					names.APPLIED_NAMESPACE, names.EGRESS_FIREWALL_MIGRATION_CONFIGMAP))
+			return
+		}
+	}
+	t.status.SetNotDegraded(statusmanager.MigrationConfig)
+}
+
+// publishStatus writes the per-node progress to the migration status
+// ConfigMap.
+func (t *progressTracker) publishStatus(ctx context.Context, data map[string]string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.MIGRATION_STATUS_CONFIGMAP}
+		err := t.client.Get(ctx, nsn, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: data,
+			}
+			return t.client.Create(ctx, cm)
+		} else if err != nil {
+			return err
+		}
+		if equalStringMaps(cm.Data, data) {
+			return nil
+		}
+		cm.Data = data
+		return t.client.Update(ctx, cm)
+	})
+}
+
+func (t *progressTracker) deleteStatus(ctx context.Context) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.MIGRATION_STATUS_CONFIGMAP,
+		},
+	}
+	if err := t.client.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to delete stale migration status: %v", err)
+	}
+}
+
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
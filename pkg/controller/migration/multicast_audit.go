@@ -0,0 +1,107 @@
+package migration
+
+// Dry-run audit of multicast enablement for a migration to OVN-Kubernetes.
+// On openshift-sdn, multicast is enabled per NetNamespace with an annotation;
+// on OVN-Kubernetes the equivalent annotation lives on the Namespace itself.
+// Before the actual conversion runs, the audit lists which namespaces would
+// gain the OVN multicast annotation and which NetNamespaces cannot be mapped,
+// so administrators can review the changes up front.
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// sdnMulticastAnnotation enables multicast for a NetNamespace on
+// openshift-sdn.
+const sdnMulticastAnnotation = "netnamespace.network.openshift.io/multicast-enabled"
+
+var netNamespaceGVK = schema.GroupVersionKind{
+	Group: "network.openshift.io", Version: "v1", Kind: "NetNamespaceList",
+}
+
+// auditMulticastMigration publishes the multicast enablement dry-run report:
+// the namespaces that would gain the OVN multicast annotation and the
+// NetNamespaces that cannot be mapped to a Namespace.
+func (t *progressTracker) auditMulticastMigration(ctx context.Context) {
+	netnss := &uns.UnstructuredList{}
+	netnss.SetGroupVersionKind(netNamespaceGVK)
+	if err := t.client.List(ctx, netnss); err != nil {
+		if !meta.IsNoMatchError(err) {
+			klog.Errorf("Failed to list NetNamespaces: %v", err)
+		}
+		return
+	}
+
+	wouldEnable := []string{}
+	unmappable := map[string]string{}
+	for _, netns := range netnss.Items {
+		if netns.GetAnnotations()[sdnMulticastAnnotation] != "true" {
+			continue
+		}
+		name := netns.GetName()
+		namespace := &corev1.Namespace{}
+		if err := t.client.Get(ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+			if apierrors.IsNotFound(err) {
+				unmappable[name] = "no Namespace with this name exists"
+			} else {
+				unmappable[name] = err.Error()
+			}
+			continue
+		}
+		wouldEnable = append(wouldEnable, name)
+	}
+	sort.Strings(wouldEnable)
+
+	data := map[string]string{
+		"checked-at":        time.Now().UTC().Format(time.RFC3339),
+		"multicast-enabled": strconv.Itoa(len(wouldEnable) + len(unmappable)),
+	}
+	if len(wouldEnable) > 0 {
+		data["would-enable"] = strings.Join(wouldEnable, ",")
+	}
+	for name, reason := range unmappable {
+		data["unmappable."+name] = reason
+	}
+
+	if err := t.publishMulticastReport(ctx, data); err != nil {
+		klog.Errorf("Failed to publish multicast migration report: %v", err)
+	}
+}
+
+func (t *progressTracker) publishMulticastReport(ctx context.Context, data map[string]string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.MULTICAST_MIGRATION_CONFIGMAP}
+		err := t.client.Get(ctx, nsn, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: data,
+			}
+			return t.client.Create(ctx, cm)
+		} else if err != nil {
+			return err
+		}
+		cm.Data = data
+		return t.client.Update(ctx, cm)
+	})
+}
@@ -0,0 +1,183 @@
+package migration
+
+// Rollback orchestration for a default network migration. A rollback is
+// requested either with the rollback annotation or by reverting
+// Spec.Migration.NetworkType to the currently deployed network type. The
+// controller then unwinds the migration: it clears Spec.Migration so the
+// operconfig controller re-renders the original operands and MachineConfigs,
+// reverts the per-node migration annotations, and finishes with a
+// verification pass before declaring the rollback complete.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// rollbackRequested reports whether the administrator asked for an in-flight
+// migration to be unwound, either explicitly by annotation or by reverting
+// the migration target back to the deployed network type.
+func rollbackRequested(operConfig *operv1.Network) bool {
+	if operConfig.GetAnnotations()[names.MigrationRollbackAnnotation] == "true" {
+		return true
+	}
+	migration := operConfig.Spec.Migration
+	return migration != nil && migration.NetworkType == operConfig.Spec.DefaultNetwork.Type
+}
+
+// operandDaemonSet returns the node-level DaemonSet that must be healthy for
+// the given default network type.
+func operandDaemonSet(networkType operv1.NetworkType) (types.NamespacedName, bool) {
+	switch networkType {
+	case operv1.NetworkTypeOpenShiftSDN:
+		return types.NamespacedName{Namespace: "openshift-sdn", Name: "sdn"}, true
+	case operv1.NetworkTypeOVNKubernetes:
+		return types.NamespacedName{Namespace: "openshift-ovn-kubernetes", Name: "ovnkube-node"}, true
+	}
+	return types.NamespacedName{}, false
+}
+
+// rollbackMigration drives one step of the rollback and returns whether it is
+// still in progress. Each resync advances whatever remains: clearing
+// Spec.Migration, reverting node annotations, and finally verifying the
+// original operand is healthy before removing the rollback annotation.
+func (t *progressTracker) rollbackMigration(ctx context.Context, operConfig *operv1.Network) bool {
+	// Stop migration rendering first; the operconfig controller restores the
+	// original operands and MachineConfigs once Spec.Migration is cleared.
+	if operConfig.Spec.Migration != nil {
+		if err := t.clearMigrationSpec(ctx); err != nil {
+			klog.Errorf("Rollback: failed to clear migration spec: %v", err)
+			return true
+		}
+	}
+
+	reverted, remaining, err := t.revertNodeAnnotations(ctx)
+	if err != nil {
+		klog.Errorf("Rollback: failed to revert node annotations: %v", err)
+		return true
+	}
+
+	data := map[string]string{
+		"phase":           "rolling-back",
+		"nodes-reverted":  strconv.Itoa(reverted),
+		"nodes-remaining": strconv.Itoa(remaining),
+	}
+	if remaining > 0 {
+		if err := t.publishStatus(ctx, data); err != nil {
+			klog.Errorf("Failed to publish rollback status: %v", err)
+		}
+		return true
+	}
+
+	// Final verification: the original operand must be fully available again.
+	if err := t.verifyRollback(ctx, operConfig.Spec.DefaultNetwork.Type); err != nil {
+		klog.Infof("Rollback: waiting for verification: %v", err)
+		data["verification"] = err.Error()
+		if err := t.publishStatus(ctx, data); err != nil {
+			klog.Errorf("Failed to publish rollback status: %v", err)
+		}
+		return true
+	}
+
+	data["phase"] = "rolled-back"
+	delete(data, "verification")
+	if err := t.publishStatus(ctx, data); err != nil {
+		klog.Errorf("Failed to publish rollback status: %v", err)
+	}
+	if err := t.clearRollbackAnnotation(ctx); err != nil {
+		klog.Errorf("Rollback: failed to clear rollback annotation: %v", err)
+		return true
+	}
+	klog.Infof("Default network migration rollback complete")
+	return false
+}
+
+// clearMigrationSpec removes Spec.Migration from the operator configuration.
+func (t *progressTracker) clearMigrationSpec(ctx context.Context) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		operConfig := &operv1.Network{}
+		if err := t.client.Get(ctx, types.NamespacedName{Name: names.OPERATOR_CONFIG}, operConfig); err != nil {
+			return err
+		}
+		if operConfig.Spec.Migration == nil {
+			return nil
+		}
+		operConfig.Spec.Migration = nil
+		return t.client.Update(ctx, operConfig)
+	})
+}
+
+// revertNodeAnnotations removes the migration state annotation from all
+// nodes, returning how many were reverted this pass and how many still carry
+// migration state.
+func (t *progressTracker) revertNodeAnnotations(ctx context.Context) (int, int, error) {
+	nodes := &corev1.NodeList{}
+	if err := t.client.List(ctx, nodes); err != nil {
+		return 0, 0, err
+	}
+
+	reverted := 0
+	remaining := 0
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if _, ok := node.Annotations[names.NodeMigrationStateAnnotation]; !ok {
+			continue
+		}
+		delete(node.Annotations, names.NodeMigrationStateAnnotation)
+		if err := t.client.Update(ctx, node); err != nil {
+			klog.Warningf("Rollback: failed to revert annotations on node %s (will retry): %v", node.Name, err)
+			remaining++
+			continue
+		}
+		reverted++
+	}
+	return reverted, remaining, nil
+}
+
+// verifyRollback checks that the restored default network's node DaemonSet is
+// fully available again.
+func (t *progressTracker) verifyRollback(ctx context.Context, networkType operv1.NetworkType) error {
+	nsn, ok := operandDaemonSet(networkType)
+	if !ok {
+		// Nothing we know how to verify; don't hold the rollback open.
+		return nil
+	}
+	ds := &appsv1.DaemonSet{}
+	if err := t.client.Get(ctx, nsn, ds); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("DaemonSet %s has not been restored yet", nsn.String())
+		}
+		return err
+	}
+	if ds.Status.NumberUnavailable > 0 || ds.Status.NumberAvailable < ds.Status.DesiredNumberScheduled {
+		return fmt.Errorf("DaemonSet %s is not fully available (%d of %d nodes)",
+			nsn.String(), ds.Status.NumberAvailable, ds.Status.DesiredNumberScheduled)
+	}
+	return nil
+}
+
+// clearRollbackAnnotation removes the rollback request annotation once the
+// rollback has been verified.
+func (t *progressTracker) clearRollbackAnnotation(ctx context.Context) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		operConfig := &operv1.Network{}
+		if err := t.client.Get(ctx, types.NamespacedName{Name: names.OPERATOR_CONFIG}, operConfig); err != nil {
+			return err
+		}
+		if _, ok := operConfig.Annotations[names.MigrationRollbackAnnotation]; !ok {
+			return nil
+		}
+		delete(operConfig.Annotations, names.MigrationRollbackAnnotation)
+		return t.client.Update(ctx, operConfig)
+	})
+}
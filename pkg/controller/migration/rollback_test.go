@@ -0,0 +1,139 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+//nolint:errcheck
+func init() {
+	operv1.AddToScheme(scheme.Scheme)
+	appsv1.AddToScheme(scheme.Scheme)
+}
+
+func TestRollbackRequested(t *testing.T) {
+	operConfig := &operv1.Network{
+		ObjectMeta: metav1.ObjectMeta{Name: names.OPERATOR_CONFIG},
+		Spec: operv1.NetworkSpec{
+			DefaultNetwork: operv1.DefaultNetworkDefinition{Type: operv1.NetworkTypeOpenShiftSDN},
+		},
+	}
+	if rollbackRequested(operConfig) {
+		t.Fatalf("expected no rollback without migration or annotation")
+	}
+
+	operConfig.Spec.Migration = &operv1.NetworkMigration{NetworkType: operv1.NetworkTypeOVNKubernetes}
+	if rollbackRequested(operConfig) {
+		t.Fatalf("expected no rollback for an in-flight migration")
+	}
+
+	// Reverting the migration target to the deployed type requests a rollback.
+	operConfig.Spec.Migration.NetworkType = operv1.NetworkTypeOpenShiftSDN
+	if !rollbackRequested(operConfig) {
+		t.Fatalf("expected rollback when the migration target is reverted")
+	}
+
+	// As does the explicit annotation.
+	operConfig.Spec.Migration.NetworkType = operv1.NetworkTypeOVNKubernetes
+	operConfig.Annotations = map[string]string{names.MigrationRollbackAnnotation: "true"}
+	if !rollbackRequested(operConfig) {
+		t.Fatalf("expected rollback when the rollback annotation is set")
+	}
+}
+
+func TestRollbackMigration(t *testing.T) {
+	operConfig := &operv1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.OPERATOR_CONFIG,
+			Annotations: map[string]string{names.MigrationRollbackAnnotation: "true"},
+		},
+		Spec: operv1.NetworkSpec{
+			DefaultNetwork: operv1.DefaultNetworkDefinition{Type: operv1.NetworkTypeOpenShiftSDN},
+			Migration:      &operv1.NetworkMigration{NetworkType: operv1.NetworkTypeOVNKubernetes},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node-1",
+			Annotations: map[string]string{names.NodeMigrationStateAnnotation: "cni-switched"},
+		},
+	}
+	sdnDS := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-sdn", Name: "sdn"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 1,
+			NumberAvailable:        1,
+		},
+	}
+	client := fake.NewClientBuilder().WithRuntimeObjects(operConfig, node, sdnDS).Build()
+	tracker := &progressTracker{client: client}
+
+	if inProgress := tracker.rollbackMigration(context.TODO(), operConfig); inProgress {
+		t.Fatalf("expected rollback to complete in one pass with a healthy operand")
+	}
+
+	updated := &operv1.Network{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: names.OPERATOR_CONFIG}, updated); err != nil {
+		t.Fatalf("failed to get operator config: %v", err)
+	}
+	if updated.Spec.Migration != nil {
+		t.Errorf("expected Spec.Migration to be cleared")
+	}
+	if _, ok := updated.Annotations[names.MigrationRollbackAnnotation]; ok {
+		t.Errorf("expected rollback annotation to be removed")
+	}
+
+	updatedNode := &corev1.Node{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: "node-1"}, updatedNode); err != nil {
+		t.Fatalf("failed to get node: %v", err)
+	}
+	if _, ok := updatedNode.Annotations[names.NodeMigrationStateAnnotation]; ok {
+		t.Errorf("expected node migration state annotation to be removed")
+	}
+
+	status := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.MIGRATION_STATUS_CONFIGMAP}
+	if err := client.Get(context.TODO(), nsn, status); err != nil {
+		t.Fatalf("failed to get migration status: %v", err)
+	}
+	if status.Data["phase"] != "rolled-back" {
+		t.Errorf("expected phase rolled-back, got %q", status.Data["phase"])
+	}
+}
+
+func TestRollbackMigrationWaitsForOperand(t *testing.T) {
+	operConfig := &operv1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.OPERATOR_CONFIG,
+			Annotations: map[string]string{names.MigrationRollbackAnnotation: "true"},
+		},
+		Spec: operv1.NetworkSpec{
+			DefaultNetwork: operv1.DefaultNetworkDefinition{Type: operv1.NetworkTypeOpenShiftSDN},
+		},
+	}
+	client := fake.NewClientBuilder().WithRuntimeObjects(operConfig).Build()
+	tracker := &progressTracker{client: client}
+
+	// The sdn DaemonSet has not been restored, so verification must hold the
+	// rollback open and keep the annotation.
+	if inProgress := tracker.rollbackMigration(context.TODO(), operConfig); !inProgress {
+		t.Fatalf("expected rollback to stay in progress until the operand is restored")
+	}
+	updated := &operv1.Network{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: names.OPERATOR_CONFIG}, updated); err != nil {
+		t.Fatalf("failed to get operator config: %v", err)
+	}
+	if updated.Annotations[names.MigrationRollbackAnnotation] != "true" {
+		t.Errorf("expected rollback annotation to be retained while in progress")
+	}
+}
@@ -0,0 +1,151 @@
+package migration
+
+// Disruption budget controls for a default network live migration. The
+// tuning ConfigMap lets administrators bound how many nodes are converted in
+// parallel, define wall-clock windows during which conversion pauses, and
+// order which namespaces are converted first. The effective pacing is
+// published through the migration status ConfigMap, which the node-side
+// tooling honors.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// defaultMaxUnavailable is how many nodes are converted in parallel when the
+// tuning ConfigMap does not say otherwise.
+const defaultMaxUnavailable = 1
+
+// pauseWindow is a daily wall-clock range, in minutes since midnight UTC,
+// during which no new nodes are converted. Windows may wrap midnight.
+type pauseWindow struct {
+	start int
+	end   int
+}
+
+func (w pauseWindow) contains(t time.Time) bool {
+	minute := t.UTC().Hour()*60 + t.UTC().Minute()
+	if w.start <= w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+type migrationTuning struct {
+	MaxUnavailable int
+	PauseWindows   []pauseWindow
+	NamespaceOrder []string
+}
+
+// paused reports whether any pause window covers the given time.
+func (t *migrationTuning) paused(now time.Time) bool {
+	for _, w := range t.PauseWindows {
+		if w.contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// getMigrationTuning reads and validates the migration tuning ConfigMap.
+// An absent ConfigMap yields the defaults; invalid values are errors so that
+// a typo pauses the migration pacing update rather than silently unthrottling
+// it.
+func (t *progressTracker) getMigrationTuning(ctx context.Context) (*migrationTuning, error) {
+	tuning := &migrationTuning{MaxUnavailable: defaultMaxUnavailable}
+
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.MIGRATION_TUNING_CONFIGMAP}
+	if err := t.client.Get(ctx, nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return tuning, nil
+		}
+		return nil, err
+	}
+
+	if raw := cm.Data["max-unavailable"]; raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return nil, fmt.Errorf("invalid max-unavailable %q: must be a positive integer", raw)
+		}
+		tuning.MaxUnavailable = parsed
+	}
+
+	if raw := cm.Data["pause-windows"]; raw != "" {
+		for _, spec := range strings.Split(raw, ",") {
+			window, err := parsePauseWindow(strings.TrimSpace(spec))
+			if err != nil {
+				return nil, err
+			}
+			tuning.PauseWindows = append(tuning.PauseWindows, window)
+		}
+	}
+
+	if raw := cm.Data["namespace-order"]; raw != "" {
+		for _, ns := range strings.Split(raw, ",") {
+			ns = strings.TrimSpace(ns)
+			if ns == "" {
+				return nil, fmt.Errorf("invalid namespace-order %q: empty namespace entry", raw)
+			}
+			tuning.NamespaceOrder = append(tuning.NamespaceOrder, ns)
+		}
+	}
+
+	return tuning, nil
+}
+
+// parsePauseWindow parses a "HH:MM-HH:MM" UTC range.
+func parsePauseWindow(spec string) (pauseWindow, error) {
+	parts := strings.Split(spec, "-")
+	if len(parts) != 2 {
+		return pauseWindow{}, fmt.Errorf("invalid pause window %q: expected HH:MM-HH:MM", spec)
+	}
+	start, err := parseClockMinute(parts[0])
+	if err != nil {
+		return pauseWindow{}, fmt.Errorf("invalid pause window %q: %v", spec, err)
+	}
+	end, err := parseClockMinute(parts[1])
+	if err != nil {
+		return pauseWindow{}, fmt.Errorf("invalid pause window %q: %v", spec, err)
+	}
+	if start == end {
+		return pauseWindow{}, fmt.Errorf("invalid pause window %q: start and end are equal", spec)
+	}
+	return pauseWindow{start: start, end: end}, nil
+}
+
+func parseClockMinute(raw string) (int, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid HH:MM time", raw)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// applyTuning folds the effective pacing into the migration status data: the
+// parallel conversion budget, the remaining conversion slots given how many
+// nodes are currently mid-conversion, whether conversion is paused right now,
+// and the namespace conversion order.
+func applyTuning(data map[string]string, tuning *migrationTuning, counts map[string]int, now time.Time) {
+	converting := counts[migrationStateRoutesInstalled] + counts[migrationStateCNISwitched]
+	slots := tuning.MaxUnavailable - converting
+	if slots < 0 {
+		slots = 0
+	}
+
+	data["max-unavailable"] = strconv.Itoa(tuning.MaxUnavailable)
+	data["conversion-slots"] = strconv.Itoa(slots)
+	data["paused"] = strconv.FormatBool(tuning.paused(now))
+	if len(tuning.NamespaceOrder) > 0 {
+		data["namespace-order"] = strings.Join(tuning.NamespaceOrder, ",")
+	}
+}
@@ -0,0 +1,139 @@
+package mtu
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// maxFailurePercentEnv overrides the percentage of failed nodes at which an
+// in-flight MTU migration is paused (default 10).
+const maxFailurePercentEnv = "CNO_MTU_MIGRATION_MAX_FAILURE_PERCENT"
+
+const defaultMaxFailurePercent = 10
+
+// Per-node MTU migration states.
+const (
+	mtuStatePending = "pending"
+	mtuStateApplied = "mtu-applied"
+	mtuStateFailed  = "failed"
+)
+
+// mtuMigrationNodes reports how many nodes are in each MTU migration state.
+var mtuMigrationNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_mtu_migration_nodes",
+	Help: "Number of nodes per MTU migration state.",
+}, []string{"state"})
+
+func init() {
+	prometheus.MustRegister(mtuMigrationNodes)
+}
+
+// trackMTUMigration publishes per-node MTU migration progress as a status
+// ConfigMap and metric. Progress is observed state: the mtu-prober DaemonSet
+// annotates each node with the MTU currently applied on its cluster-network
+// interface (and any probe error), and a node has converged once that matches
+// the configured MTU. When more than the configured percentage of nodes
+// report errors, the migration is paused: the status ConfigMap carries
+// paused=true, and the failed nodes are returned so the caller degrades the
+// MTUConfig condition.
+func (v *mtuValidator) trackMTUMigration(ctx context.Context, nodes []corev1.Node, targetMTU int) []string {
+	if targetMTU <= 0 {
+		return nil
+	}
+	target := strconv.Itoa(targetMTU)
+
+	counts := map[string]int{}
+	data := map[string]string{"target-mtu": target}
+	failedNodes := []string{}
+	tracked := 0
+	for _, node := range nodes {
+		applied, hasApplied := node.Annotations[names.NodeAppliedMTUAnnotation]
+		mtuError := node.Annotations[names.NodeMTUErrorAnnotation]
+		if !hasApplied && mtuError == "" {
+			continue
+		}
+		tracked++
+
+		state := mtuStatePending
+		switch {
+		case mtuError != "":
+			state = mtuStateFailed
+			failedNodes = append(failedNodes, fmt.Sprintf("node %q: %s", node.Name, mtuError))
+		case hasApplied && applied == target:
+			state = mtuStateApplied
+		}
+		counts[state]++
+		data["node."+node.Name] = state
+	}
+	for _, state := range []string{mtuStatePending, mtuStateApplied, mtuStateFailed} {
+		mtuMigrationNodes.WithLabelValues(state).Set(float64(counts[state]))
+	}
+	if tracked == 0 {
+		return nil
+	}
+
+	paused := counts[mtuStateFailed]*100 > maxFailurePercent()*tracked
+	data["paused"] = strconv.FormatBool(paused)
+
+	if err := v.publishMigrationStatus(ctx, data); err != nil {
+		klog.Errorf("Failed to publish MTU migration status: %v", err)
+	}
+
+	if !paused {
+		return nil
+	}
+	sort.Strings(failedNodes)
+	return []string{fmt.Sprintf("MTU migration paused: %d of %d nodes reported MTU errors\n%s",
+		counts[mtuStateFailed], tracked, strings.Join(failedNodes, "\n"))}
+}
+
+func maxFailurePercent() int {
+	if raw := os.Getenv(maxFailurePercentEnv); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed >= 0 && parsed <= 100 {
+			return parsed
+		}
+		klog.Warningf("Ignoring invalid %s value %q", maxFailurePercentEnv, raw)
+	}
+	return defaultMaxFailurePercent
+}
+
+// publishMigrationStatus writes the per-node progress to the MTU migration
+// status ConfigMap.
+func (v *mtuValidator) publishMigrationStatus(ctx context.Context, data map[string]string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.MTU_MIGRATION_STATUS_CONFIGMAP}
+		err := v.client.Get(ctx, nsn, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: data,
+			}
+			return v.client.Create(ctx, cm)
+		} else if err != nil {
+			return err
+		}
+		if equalStringMaps(cm.Data, data) {
+			return nil
+		}
+		cm.Data = data
+		return v.client.Update(ctx, cm)
+	})
+}
@@ -0,0 +1,145 @@
+package mtu
+
+// The mtu controller continuously validates that the configured clusterNetwork
+// MTU still fits the uplink MTU of every node. Nodes publish their uplink MTU
+// via an annotation; when a node is detected whose uplink is smaller than the
+// encapsulation-adjusted MTU, the operator raises a warning condition and a
+// metric so the mismatch is caught before pods start dropping packets.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ResyncPeriod is how often node MTUs are revalidated.
+var ResyncPeriod = 5 * time.Minute
+
+// Encapsulation overhead per network type, matching the headroom the
+// defaulting logic reserves when computing the cluster MTU from the host MTU.
+const (
+	ovnOverhead = 100
+	sdnOverhead = 50
+)
+
+// nodesMTUTooSmall reports the number of nodes whose uplink MTU is too small
+// for the configured cluster network MTU.
+var nodesMTUTooSmall = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_nodes_uplink_mtu_too_small",
+	Help: "Number of nodes whose uplink MTU cannot carry the configured cluster network MTU.",
+})
+
+func init() {
+	prometheus.MustRegister(nodesMTUTooSmall)
+}
+
+// Add registers the node MTU validator with the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	validator := &mtuValidator{client: mgr.GetClient(), status: status}
+	return mgr.Add(manager.RunnableFunc(validator.run))
+}
+
+type mtuValidator struct {
+	client client.Client
+	status *statusmanager.StatusManager
+}
+
+func (v *mtuValidator) run(ctx context.Context) error {
+	ticker := time.NewTicker(ResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			v.check(ctx)
+		}
+	}
+}
+
+// check compares the encapsulation-adjusted cluster MTU against the uplink
+// MTU published by each node. Nodes without the annotation are skipped.
+func (v *mtuValidator) check(ctx context.Context) {
+	nodes := &corev1.NodeList{}
+	if err := v.client.List(ctx, nodes); err != nil {
+		klog.Errorf("Failed to list nodes for MTU validation: %v", err)
+		return
+	}
+
+	problems := v.sampleUplinkMTUs(ctx, nodes.Items)
+
+	clusterMTU, overhead, haveMTU := v.configuredClusterMTU(ctx)
+	problems = append(problems, v.trackMTUMigration(ctx, nodes.Items, clusterMTU)...)
+
+	if haveMTU {
+		requiredMTU := clusterMTU + overhead
+		tooSmall := 0
+		for _, node := range nodes.Items {
+			raw, ok := node.Annotations[names.NodeUplinkMTUAnnotation]
+			if !ok {
+				continue
+			}
+			uplinkMTU, err := strconv.Atoi(raw)
+			if err != nil || uplinkMTU <= 0 {
+				klog.Warningf("Node %s has invalid %s annotation %q, skipping", node.Name, names.NodeUplinkMTUAnnotation, raw)
+				continue
+			}
+			if uplinkMTU < requiredMTU {
+				tooSmall++
+				problems = append(problems, fmt.Sprintf("node %q uplink MTU %d is smaller than the required %d (cluster network MTU plus encapsulation overhead)", node.Name, uplinkMTU, requiredMTU))
+			}
+		}
+		nodesMTUTooSmall.Set(float64(tooSmall))
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		v.status.SetDegraded(statusmanager.MTUConfig, "NodeMTUTooSmall", strings.Join(problems, "\n"))
+	} else {
+		v.status.SetNotDegraded(statusmanager.MTUConfig)
+	}
+}
+
+// configuredClusterMTU returns the configured cluster network MTU and the
+// encapsulation overhead of the default network, or false when they cannot be
+// determined.
+func (v *mtuValidator) configuredClusterMTU(ctx context.Context) (int, int, bool) {
+	operConfig := &operv1.Network{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: names.OPERATOR_CONFIG}, operConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to get operator configuration for MTU validation: %v", err)
+		}
+		return 0, 0, false
+	}
+
+	dn := operConfig.Spec.DefaultNetwork
+	switch dn.Type {
+	case operv1.NetworkTypeOVNKubernetes:
+		if dn.OVNKubernetesConfig == nil || dn.OVNKubernetesConfig.MTU == nil {
+			return 0, 0, false
+		}
+		return int(*dn.OVNKubernetesConfig.MTU), ovnOverhead, true
+	case operv1.NetworkTypeOpenShiftSDN:
+		if dn.OpenShiftSDNConfig == nil || dn.OpenShiftSDNConfig.MTU == nil {
+			return 0, 0, false
+		}
+		return int(*dn.OpenShiftSDNConfig.MTU), sdnOverhead, true
+	default:
+		return 0, 0, false
+	}
+}
@@ -0,0 +1,195 @@
+package mtu
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// samplePerPoolEnv limits how many annotated nodes are sampled per machine
+// pool (0 or unset samples all of them).
+const samplePerPoolEnv = "CNO_MTU_SAMPLE_PER_POOL"
+
+const roleLabelPrefix = "node-role.kubernetes.io/"
+
+// sampleUplinkMTUs probes the uplink MTU across machine pools using the
+// per-node annotations: it computes the minimum per pool, publishes the
+// results in the MTU probe ConfigMap, and feeds the cluster-wide minimum into
+// the MTU defaulting logic. It returns a problem description per disagreeing
+// pool so heterogeneous clusters surface a condition instead of silently
+// running with a wrong MTU.
+func (v *mtuValidator) sampleUplinkMTUs(ctx context.Context, nodes []corev1.Node) []string {
+	samplePerPool := 0
+	if raw := os.Getenv(samplePerPoolEnv); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			samplePerPool = parsed
+		} else {
+			klog.Warningf("Ignoring invalid %s value %q", samplePerPoolEnv, raw)
+		}
+	}
+
+	// Group the annotated nodes by machine pool (node role).
+	byPool := map[string][]int{}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	for _, node := range nodes {
+		raw, ok := node.Annotations[names.NodeUplinkMTUAnnotation]
+		if !ok {
+			continue
+		}
+		uplinkMTU, err := strconv.Atoi(raw)
+		if err != nil || uplinkMTU <= 0 {
+			continue
+		}
+		for _, pool := range nodePools(&node) {
+			if samplePerPool > 0 && len(byPool[pool]) >= samplePerPool {
+				continue
+			}
+			byPool[pool] = append(byPool[pool], uplinkMTU)
+		}
+	}
+	if len(byPool) == 0 {
+		// No annotated workers (e.g. a hosted cluster before any NodePool is
+		// scaled up): fall back to a control-plane-side probe or an MTU hint,
+		// so the probe ConfigMap is still populated deterministically.
+		v.publishFallbackMTU(ctx)
+		return nil
+	}
+
+	// Per-pool minimum, and the cluster-wide minimum across pools.
+	data := map[string]string{}
+	poolMins := map[string]int{}
+	clusterMin := 0
+	for pool, mtus := range byPool {
+		min := mtus[0]
+		for _, mtu := range mtus {
+			if mtu < min {
+				min = mtu
+			}
+		}
+		poolMins[pool] = min
+		data["pool."+pool] = strconv.Itoa(min)
+		if clusterMin == 0 || min < clusterMin {
+			clusterMin = min
+		}
+	}
+	data["minimum"] = strconv.Itoa(clusterMin)
+
+	if err := v.publishMTUReport(ctx, data); err != nil {
+		klog.Errorf("Failed to publish MTU probe report: %v", err)
+	}
+	network.SetProbedHostMTU(clusterMin)
+
+	problems := []string{}
+	for pool, min := range poolMins {
+		if min != clusterMin {
+			problems = append(problems, fmt.Sprintf("machine pool %q reports uplink MTU %d while the cluster minimum is %d; the minimum is used", pool, min, clusterMin))
+		}
+	}
+	return problems
+}
+
+// publishFallbackMTU populates the MTU probe ConfigMap when no worker has
+// published an uplink MTU yet. An explicit hint on the operator configuration
+// wins; otherwise the MTU of the operator's own (control-plane) uplink is
+// used.
+func (v *mtuValidator) publishFallbackMTU(ctx context.Context) {
+	mtu := 0
+	source := ""
+
+	operConfig := &operv1.Network{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: names.OPERATOR_CONFIG}, operConfig); err == nil {
+		if raw, ok := operConfig.Annotations[names.MTUHintAnnotation]; ok {
+			if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+				mtu, source = parsed, "hint"
+			} else {
+				klog.Warningf("Ignoring invalid %s annotation %q", names.MTUHintAnnotation, raw)
+			}
+		}
+	}
+	if mtu == 0 {
+		probed, err := network.LocalHostMTU()
+		if err != nil {
+			klog.Errorf("Control-plane MTU probe failed: %v", err)
+			return
+		}
+		mtu, source = probed, "control-plane"
+	}
+
+	data := map[string]string{
+		"minimum": strconv.Itoa(mtu),
+		"source":  source,
+	}
+	if err := v.publishMTUReport(ctx, data); err != nil {
+		klog.Errorf("Failed to publish fallback MTU report: %v", err)
+	}
+	network.SetProbedHostMTU(mtu)
+}
+
+// nodePools returns the machine pools (node roles) a node belongs to, or
+// "unknown" if it has no role label.
+func nodePools(node *corev1.Node) []string {
+	pools := []string{}
+	for label := range node.Labels {
+		if strings.HasPrefix(label, roleLabelPrefix) {
+			pools = append(pools, strings.TrimPrefix(label, roleLabelPrefix))
+		}
+	}
+	if len(pools) == 0 {
+		pools = append(pools, "unknown")
+	}
+	sort.Strings(pools)
+	return pools
+}
+
+// publishMTUReport writes the per-pool probe results to the MTU probe
+// ConfigMap.
+func (v *mtuValidator) publishMTUReport(ctx context.Context, data map[string]string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.MTU_PROBE_CONFIGMAP}
+		err := v.client.Get(ctx, nsn, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: data,
+			}
+			return v.client.Create(ctx, cm)
+		} else if err != nil {
+			return err
+		}
+		if equalStringMaps(cm.Data, data) {
+			return nil
+		}
+		cm.Data = data
+		return v.client.Update(ctx, cm)
+	})
+}
+
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package nodereadiness
+
+// nodereadiness measures how long a new Node takes to become usable for pod
+// networking: the CNI is installed (NetworkUnavailable is cleared), the
+// per-node network daemon pod is ready and, for OVN-Kubernetes, the node has
+// been annotated with its subnets. The elapsed time from Node creation is
+// exported as a histogram so autoscaling latency regressions are visible.
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ovnNodeSubnetsAnnotation is set by ovnkube-master once the node has been
+// allocated its subnets.
+const ovnNodeSubnetsAnnotation = "k8s.ovn.org/node-subnets"
+
+// nodeRoleLabelPrefix is used to derive the node pool label on the metrics.
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// pendingRequeuePeriod is how often a not-yet-ready node is rechecked; the
+// daemon pod becoming ready does not generate a Node event.
+const pendingRequeuePeriod = 30 * time.Second
+
+// nodeNetworkReadyDuration reports, per node pool, the time from Node
+// creation to network readiness.
+var nodeNetworkReadyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "cno_node_network_ready_duration_seconds",
+	Help:    "Time from Node creation until the node network is ready (CNI installed, network daemon pod ready, node annotated).",
+	Buckets: prometheus.ExponentialBuckets(10, 2, 10),
+}, []string{"pool"})
+
+// nodesNetworkPending reports how many nodes created since operator start
+// are still waiting for their network to become ready.
+var nodesNetworkPending = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_nodes_network_pending",
+	Help: "Number of recently created nodes whose network is not yet ready.",
+})
+
+func init() {
+	prometheus.MustRegister(nodeNetworkReadyDuration)
+	prometheus.MustRegister(nodesNetworkPending)
+}
+
+// Add attaches the node readiness controller to the manager.
+func Add(mgr manager.Manager, _ *statusmanager.StatusManager) error {
+	r := &ReconcileNodeReadiness{
+		client:   mgr.GetClient(),
+		start:    time.Now(),
+		observed: map[string]bool{},
+		pending:  map[string]bool{},
+	}
+
+	c, err := controller.New("node-readiness-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(&source.Kind{Type: &corev1.Node{}}, &handler.EnqueueRequestForObject{})
+}
+
+var _ reconcile.Reconciler = &ReconcileNodeReadiness{}
+
+// ReconcileNodeReadiness records the time-to-network-ready of each node
+// created after the operator started. Nodes that were already ready when the
+// operator (re)started are ignored so restarts do not skew the histogram.
+type ReconcileNodeReadiness struct {
+	client crclient.Client
+	start  time.Time
+
+	observed map[string]bool
+	pending  map[string]bool
+}
+
+func (r *ReconcileNodeReadiness) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	node := &corev1.Node{}
+	err := r.client.Get(ctx, request.NamespacedName, node)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			delete(r.observed, request.Name)
+			delete(r.pending, request.Name)
+			nodesNetworkPending.Set(float64(len(r.pending)))
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if r.observed[node.Name] {
+		return reconcile.Result{}, nil
+	}
+
+	readyTime, ready, err := r.networkReadyTime(ctx, node)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Only time nodes that appeared after we started; for everything older
+	// we cannot tell when the network actually came up.
+	fresh := node.CreationTimestamp.Time.After(r.start)
+
+	if !ready {
+		if fresh && !r.pending[node.Name] {
+			r.pending[node.Name] = true
+			nodesNetworkPending.Set(float64(len(r.pending)))
+		}
+		return reconcile.Result{RequeueAfter: pendingRequeuePeriod}, nil
+	}
+
+	r.observed[node.Name] = true
+	delete(r.pending, node.Name)
+	nodesNetworkPending.Set(float64(len(r.pending)))
+
+	if fresh {
+		elapsed := readyTime.Sub(node.CreationTimestamp.Time)
+		klog.Infof("Node %s network became ready after %s", node.Name, elapsed.Round(time.Second))
+		nodeNetworkReadyDuration.WithLabelValues(nodePool(node)).Observe(elapsed.Seconds())
+	}
+	return reconcile.Result{}, nil
+}
+
+// networkReadyTime reports whether the node network is ready and, if so, the
+// time it became so: the latest of the kubelet Ready and NetworkUnavailable
+// transitions and the network daemon pod becoming ready.
+func (r *ReconcileNodeReadiness) networkReadyTime(ctx context.Context, node *corev1.Node) (time.Time, bool, error) {
+	readyTime := time.Time{}
+
+	networkAvailable := false
+	for _, cond := range node.Status.Conditions {
+		switch {
+		case cond.Type == corev1.NodeNetworkUnavailable && cond.Status == corev1.ConditionFalse:
+			networkAvailable = true
+		case cond.Type == corev1.NodeReady && cond.Status != corev1.ConditionTrue:
+			return time.Time{}, false, nil
+		case cond.Type != corev1.NodeNetworkUnavailable && cond.Type != corev1.NodeReady:
+			continue
+		}
+		if cond.LastTransitionTime.Time.After(readyTime) {
+			readyTime = cond.LastTransitionTime.Time
+		}
+	}
+	if !networkAvailable {
+		return time.Time{}, false, nil
+	}
+
+	podTime, podReady, isOVN, err := r.daemonPodReadyTime(ctx, node.Name)
+	if err != nil || !podReady {
+		return time.Time{}, false, err
+	}
+	if podTime.After(readyTime) {
+		readyTime = podTime
+	}
+
+	// ovnkube-master annotates the node with its subnets once allocated.
+	if isOVN {
+		if _, ok := node.Annotations[ovnNodeSubnetsAnnotation]; !ok {
+			return time.Time{}, false, nil
+		}
+	}
+	return readyTime, true, nil
+}
+
+// daemonPodReadyTime finds the per-node network daemon pod (ovnkube-node or
+// sdn) on the given node and reports whether it is ready and since when.
+func (r *ReconcileNodeReadiness) daemonPodReadyTime(ctx context.Context, nodeName string) (time.Time, bool, bool, error) {
+	for _, sel := range []struct {
+		namespace string
+		app       string
+		isOVN     bool
+	}{
+		{"openshift-ovn-kubernetes", "ovnkube-node", true},
+		{"openshift-sdn", "sdn", false},
+	} {
+		pods := &corev1.PodList{}
+		err := r.client.List(ctx, pods, crclient.InNamespace(sel.namespace), crclient.MatchingLabels{"app": sel.app})
+		if err != nil {
+			return time.Time{}, false, false, err
+		}
+		if len(pods.Items) == 0 {
+			continue
+		}
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName != nodeName {
+				continue
+			}
+			for _, cond := range pod.Status.Conditions {
+				if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+					return cond.LastTransitionTime.Time, true, sel.isOVN, nil
+				}
+			}
+		}
+		// The daemonset exists but this node's pod is not ready yet.
+		return time.Time{}, false, sel.isOVN, nil
+	}
+	// No network daemonset deployed (e.g. third-party plugin); don't gate
+	// readiness on it.
+	return time.Time{}, true, false, nil
+}
+
+// nodePool derives a stable pool identifier from the node-role labels.
+func nodePool(node *corev1.Node) string {
+	roles := []string{}
+	for label := range node.Labels {
+		if strings.HasPrefix(label, nodeRoleLabelPrefix) {
+			roles = append(roles, strings.TrimPrefix(label, nodeRoleLabelPrefix))
+		}
+	}
+	if len(roles) == 0 {
+		return "unknown"
+	}
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
@@ -0,0 +1,183 @@
+package operconfig
+
+// Lifecycle tracking for additional networks. Each additionalNetworks entry
+// is rendered into a NetworkAttachmentDefinition; this tracker periodically
+// verifies the NAD applied and its namespace exists, counts the pods that
+// reference it, and publishes the result as a status condition and metrics
+// instead of only logging namespace errors during apply.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AdditionalNetworkCheckPeriod is how often additional network state is
+// re-verified.
+var AdditionalNetworkCheckPeriod = 2 * time.Minute
+
+// podNetworksAnnotation is how pods request attachment to an additional
+// network.
+const podNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
+
+var additionalNetworkNADGVK = schema.GroupVersionKind{
+	Group: "k8s.cni.cncf.io", Version: "v1", Kind: "NetworkAttachmentDefinition",
+}
+
+var (
+	additionalNetworkApplied = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cno_additional_network_applied",
+		Help: "1 if the NetworkAttachmentDefinition for the additional network exists, 0 otherwise.",
+	}, []string{"network"})
+
+	additionalNetworkPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cno_additional_network_pods",
+		Help: "Number of pods referencing the additional network.",
+	}, []string{"network"})
+)
+
+func init() {
+	prometheus.MustRegister(additionalNetworkApplied)
+	prometheus.MustRegister(additionalNetworkPods)
+}
+
+// runAdditionalNetworkTracker periodically verifies the state of every
+// configured additional network.
+func (r *ReconcileOperConfig) runAdditionalNetworkTracker(ctx context.Context) {
+	ticker := time.NewTicker(AdditionalNetworkCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.trackAdditionalNetworks(ctx)
+		}
+	}
+}
+
+func (r *ReconcileOperConfig) trackAdditionalNetworks(ctx context.Context) {
+	operConfig := &operv1.Network{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: names.OPERATOR_CONFIG}, operConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to retrieve operator configuration for additional network tracking: %v", err)
+		}
+		return
+	}
+
+	additionalNetworkApplied.Reset()
+	additionalNetworkPods.Reset()
+
+	problems := []string{}
+	for _, an := range operConfig.Spec.AdditionalNetworks {
+		namespace := an.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		if problem := r.checkAdditionalNetwork(ctx, an.Name, namespace); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	sort.Strings(problems)
+	r.status.SetAdditionalNetworkProblems(problems)
+}
+
+// checkAdditionalNetwork verifies one additional network and updates its
+// metrics, returning a problem description if it is not fully applied.
+func (r *ReconcileOperConfig) checkAdditionalNetwork(ctx context.Context, name, namespace string) string {
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		additionalNetworkApplied.WithLabelValues(name).Set(0)
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("namespace %s for additional network %s does not exist", namespace, name)
+		}
+		return fmt.Sprintf("unable to verify namespace %s for additional network %s: %v", namespace, name, err)
+	}
+
+	nad := &uns.Unstructured{}
+	nad.SetGroupVersionKind(additionalNetworkNADGVK)
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, nad); err != nil {
+		additionalNetworkApplied.WithLabelValues(name).Set(0)
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("NetworkAttachmentDefinition %s/%s has not been applied", namespace, name)
+		}
+		return fmt.Sprintf("unable to verify NetworkAttachmentDefinition %s/%s: %v", namespace, name, err)
+	}
+	additionalNetworkApplied.WithLabelValues(name).Set(1)
+
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, crclient.InNamespace(namespace)); err != nil {
+		klog.Errorf("Failed to list pods in %s for additional network %s: %v", namespace, name, err)
+		return ""
+	}
+	referencing := 0
+	for i := range pods.Items {
+		if podReferencesNetwork(&pods.Items[i], name) {
+			referencing++
+		}
+	}
+	additionalNetworkPods.WithLabelValues(name).Set(float64(referencing))
+	return ""
+}
+
+// podReferencesNetwork reports whether the pod's networks annotation names
+// the given additional network.
+func podReferencesNetwork(pod *corev1.Pod, name string) bool {
+	annotation := pod.Annotations[podNetworksAnnotation]
+	if annotation == "" {
+		return false
+	}
+	for _, element := range splitNetworkAnnotation(annotation) {
+		if element == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitNetworkAnnotation extracts the network names from either form of the
+// networks annotation: a comma-separated list ("net1,ns/net2@eth1") or a
+// JSON list of network selection elements.
+func splitNetworkAnnotation(annotation string) []string {
+	if strings.HasPrefix(strings.TrimSpace(annotation), "[") {
+		var refs []struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal([]byte(annotation), &refs); err != nil {
+			return nil
+		}
+		networks := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			networks = append(networks, ref.Name)
+		}
+		return networks
+	}
+
+	var networks []string
+	for _, element := range strings.Split(annotation, ",") {
+		element = strings.TrimSpace(element)
+		if idx := strings.LastIndex(element, "/"); idx >= 0 {
+			element = element[idx+1:]
+		}
+		if idx := strings.Index(element, "@"); idx >= 0 {
+			element = element[:idx]
+		}
+		networks = append(networks, element)
+	}
+	return networks
+}
@@ -0,0 +1,212 @@
+package operconfig
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/apply"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// applyParallelism bounds the number of concurrent apply calls against the
+// apiserver when applying independent rendered objects.
+const applyParallelism = 4
+
+// isApplyPrerequisite reports whether an object must be applied before any
+// other rendered object may reference it.
+func isApplyPrerequisite(obj *uns.Unstructured) bool {
+	switch obj.GetKind() {
+	case "Namespace", "CustomResourceDefinition", "ServiceAccount":
+		return true
+	}
+	return false
+}
+
+// pausedOperands parses the pause-operand annotation on the operator config
+// into the set of operand names whose objects must not be applied. Entries
+// may be either "name" or "namespace/name".
+func pausedOperands(operConfig *operv1.Network) sets.String {
+	paused := sets.String{}
+	if val, ok := operConfig.GetAnnotations()[names.PauseOperandAnnotation]; ok {
+		for _, entry := range strings.Split(val, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				paused.Insert(entry)
+			}
+		}
+	}
+	return paused
+}
+
+// isPaused reports whether the object matches an entry in the paused set.
+func isPaused(paused sets.String, obj *uns.Unstructured) bool {
+	if paused.Has(obj.GetName()) {
+		return true
+	}
+	return obj.GetNamespace() != "" && paused.Has(obj.GetNamespace()+"/"+obj.GetName())
+}
+
+// applyObjects applies the rendered objects to the cluster and returns any
+// per-object failures. Prerequisite objects (namespaces, CRDs,
+// ServiceAccounts) and the applied-configuration record at objs[0] are
+// applied serially in render order; the remaining objects do not depend on
+// one another and are applied by a bounded worker pool. Objects matching the
+// paused set are skipped entirely.
+func (r *ReconcileOperConfig) applyObjects(ctx context.Context, objs []*uns.Unstructured, paused sets.String) []error {
+	if paused.Len() > 0 {
+		unpaused := []*uns.Unstructured{}
+		for _, obj := range objs {
+			if isPaused(paused, obj) {
+				log.Printf("Operand (%s) %s/%s is paused via the %s annotation, not applying",
+					obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), names.PauseOperandAnnotation)
+				continue
+			}
+			unpaused = append(unpaused, obj)
+		}
+		objs = unpaused
+	}
+	prereqs := []*uns.Unstructured{}
+	independent := []*uns.Unstructured{}
+	for i, obj := range objs {
+		if i == 0 || isApplyPrerequisite(obj) {
+			prereqs = append(prereqs, obj)
+		} else {
+			independent = append(independent, obj)
+		}
+	}
+
+	applyErrs := []error{}
+	for _, obj := range prereqs {
+		if err := r.applyOne(ctx, obj); err != nil {
+			applyErrs = append(applyErrs, err)
+		}
+	}
+	// Don't pile dependent-object failures on top of a broken prerequisite.
+	if len(applyErrs) > 0 {
+		return applyErrs
+	}
+
+	// Apply the remaining objects segment by segment. A segment ends at a
+	// rollout barrier: nothing past the barrier is applied until the barrier
+	// workload reports availability.
+	segment := []*uns.Unstructured{}
+	for _, obj := range independent {
+		segment = append(segment, obj)
+		barrier, timeout := applyBarrier(obj)
+		if !barrier {
+			continue
+		}
+		applyErrs = append(applyErrs, r.applyPool(ctx, segment)...)
+		segment = nil
+		// Don't proceed past a barrier whose segment failed to apply.
+		if len(applyErrs) > 0 {
+			return applyErrs
+		}
+		log.Printf("Waiting up to %v for rollout barrier (%s) %s/%s",
+			timeout, obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		if err := r.waitForBarrier(ctx, obj, timeout); err != nil {
+			return append(applyErrs, err)
+		}
+	}
+	applyErrs = append(applyErrs, r.applyPool(ctx, segment)...)
+
+	return applyErrs
+}
+
+// applyPool applies a batch of mutually independent objects with a bounded
+// worker pool.
+func (r *ReconcileOperConfig) applyPool(ctx context.Context, objs []*uns.Unstructured) []error {
+	applyErrs := []error{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	work := make(chan *uns.Unstructured)
+	for i := 0; i < applyParallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for obj := range work {
+				if err := r.applyOne(ctx, obj); err != nil {
+					mu.Lock()
+					applyErrs = append(applyErrs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, obj := range objs {
+		work <- obj
+	}
+	close(work)
+	wg.Wait()
+
+	return applyErrs
+}
+
+// applyRetryBackoff governs how transient per-object apply failures are
+// retried before the failure is surfaced to the reconcile loop.
+var applyRetryBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    4,
+}
+
+// isTransientApplyError classifies apply failures. Conflicts, timeouts and
+// temporarily unavailable webhooks are worth retrying in place; anything else
+// (e.g. validation failures) will not get better by itself.
+func isTransientApplyError(err error) bool {
+	cause := errors.Cause(err)
+	return apierrors.IsConflict(cause) ||
+		apierrors.IsServerTimeout(cause) ||
+		apierrors.IsTimeout(cause) ||
+		apierrors.IsTooManyRequests(cause) ||
+		apierrors.IsServiceUnavailable(cause) ||
+		apierrors.IsInternalError(cause)
+}
+
+// applyOne applies a single rendered object, retrying transient failures
+// with exponential backoff and honoring the ignore-errors annotation.
+func (r *ReconcileOperConfig) applyOne(ctx context.Context, obj *uns.Unstructured) error {
+	var lastErr error
+	// Open question: should an error here indicate we will never retry?
+	waitErr := wait.ExponentialBackoff(applyRetryBackoff, func() (bool, error) {
+		lastErr = apply.ApplyObjectWithFieldManager(ctx, r.client, obj, "cluster-network-operator/operconfig")
+		if lastErr == nil {
+			return true, nil
+		}
+		if !isTransientApplyError(lastErr) {
+			// Permanent failure; retrying in place won't help.
+			return false, lastErr
+		}
+		applyRetryCounter.WithLabelValues(obj.GetKind(), obj.GetNamespace(), obj.GetName()).Inc()
+		log.Printf("Transient failure applying (%s) %s/%s, will retry: %v",
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), lastErr)
+		return false, nil
+	})
+	if waitErr != nil {
+		err := errors.Wrapf(lastErr, "could not apply (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		log.Println(err)
+
+		// Ignore errors if we've asked to do so.
+		anno := obj.GetAnnotations()
+		if anno != nil {
+			if _, ok := anno[names.IgnoreObjectErrorAnnotation]; ok {
+				log.Println("Object has ignore-errors annotation set, continuing")
+				return nil
+			}
+		}
+		applyFailureCounter.WithLabelValues(obj.GetKind(), obj.GetNamespace(), obj.GetName()).Inc()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package operconfig
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// defaultBarrierTimeout bounds how long the apply path waits at a rollout
+// barrier that does not specify its own timeout.
+const defaultBarrierTimeout = 5 * time.Minute
+
+// barrierPollInterval is how often a barrier workload is re-checked for
+// availability.
+const barrierPollInterval = 5 * time.Second
+
+// applyBarrier reports whether the object is a rollout barrier and, if so,
+// the timeout to wait for its availability.
+func applyBarrier(obj *uns.Unstructured) (bool, time.Duration) {
+	val, ok := obj.GetAnnotations()[names.ApplyBarrierAnnotation]
+	if !ok {
+		return false, 0
+	}
+	if timeout, err := time.ParseDuration(val); err == nil && timeout > 0 {
+		return true, timeout
+	}
+	return true, defaultBarrierTimeout
+}
+
+// waitForBarrier blocks until the barrier workload reports availability, the
+// timeout expires, or the context is cancelled.
+func (r *ReconcileOperConfig) waitForBarrier(ctx context.Context, obj *uns.Unstructured, timeout time.Duration) error {
+	nsn := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	err := wait.PollImmediate(barrierPollInterval, timeout, func() (bool, error) {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+		switch obj.GetKind() {
+		case "Deployment":
+			dep := &appsv1.Deployment{}
+			if err := r.client.Get(ctx, nsn, dep); err != nil {
+				return false, nil
+			}
+			return dep.Status.ObservedGeneration >= dep.Generation &&
+				dep.Status.UpdatedReplicas == dep.Status.Replicas &&
+				dep.Status.AvailableReplicas > 0, nil
+		case "DaemonSet":
+			ds := &appsv1.DaemonSet{}
+			if err := r.client.Get(ctx, nsn, ds); err != nil {
+				return false, nil
+			}
+			return ds.Status.ObservedGeneration >= ds.Generation &&
+				ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+				ds.Status.NumberUnavailable == 0, nil
+		default:
+			// Only workloads have a meaningful notion of rollout progress.
+			return true, nil
+		}
+	})
+	if err != nil {
+		return errors.Wrapf(err, "rollout barrier (%s) %s/%s did not become available within %v",
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), timeout)
+	}
+	return nil
+}
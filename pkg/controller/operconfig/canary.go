@@ -0,0 +1,199 @@
+package operconfig
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ovnNamespace   = "openshift-ovn-kubernetes"
+	ovnNodeDS      = "ovnkube-node"
+	ovnNodeAppName = "ovnkube-node"
+)
+
+// canaryBatchSize is how many non-canary pods are rolled at a time once the
+// canary nodes are verified healthy.
+const canaryBatchSize = 10
+
+// canaryVerifyTimeout bounds how long each canary phase waits for rolled pods
+// to report Ready again.
+const canaryVerifyTimeout = 3 * time.Minute
+
+// prepareCanaryRollout switches the rendered ovnkube-node DaemonSet to the
+// OnDelete update strategy, so that pod replacement is under the operator's
+// control rather than the DaemonSet controller's. Returns true if the
+// DaemonSet was found in the render set.
+func prepareCanaryRollout(objs []*uns.Unstructured) bool {
+	for _, obj := range objs {
+		if obj.GetKind() != "DaemonSet" || obj.GetNamespace() != ovnNamespace || obj.GetName() != ovnNodeDS {
+			continue
+		}
+		_ = uns.SetNestedField(obj.Object, "OnDelete", "spec", "updateStrategy", "type")
+		uns.RemoveNestedField(obj.Object, "spec", "updateStrategy", "rollingUpdate")
+		return true
+	}
+	return false
+}
+
+// currentDSRevisionHash returns the controller-revision-hash of the latest
+// ControllerRevision of the given DaemonSet, or "" if the DaemonSet
+// controller has not recorded one yet.
+func (r *ReconcileOperConfig) currentDSRevisionHash(ctx context.Context, ds *appsv1.DaemonSet) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse DaemonSet selector")
+	}
+	revisions := &appsv1.ControllerRevisionList{}
+	err = r.client.List(ctx, revisions, client.InNamespace(ds.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list ControllerRevisions")
+	}
+	var latest *appsv1.ControllerRevision
+	for i := range revisions.Items {
+		rev := &revisions.Items[i]
+		if !metav1.IsControlledBy(rev, ds) {
+			continue
+		}
+		if latest == nil || rev.Revision > latest.Revision {
+			latest = rev
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+// runCanaryRollout rolls the ovnkube-node DaemonSet out in two phases: pods
+// on nodes matching the canary selector are replaced and verified healthy
+// first, then the remaining pods are replaced in bounded batches. This gives
+// large clusters blast-radius control over CNI daemon updates.
+//
+// Only pods that are not on the DaemonSet's current revision are rolled, so
+// when the template has not changed (e.g. a periodic resync) this is a no-op.
+func (r *ReconcileOperConfig) runCanaryRollout(ctx context.Context, selector labels.Selector) error {
+	ds := &appsv1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: ovnNamespace, Name: ovnNodeDS}, ds); err != nil {
+		return errors.Wrap(err, "failed to get ovnkube-node DaemonSet")
+	}
+	if ds.Generation != ds.Status.ObservedGeneration {
+		// The DaemonSet controller has not cut a revision for the updated
+		// template yet; the watch will re-trigger reconciliation once it has.
+		log.Printf("Canary rollout: waiting for DaemonSet controller to observe %s generation %d", ovnNodeDS, ds.Generation)
+		return nil
+	}
+	currentHash, err := r.currentDSRevisionHash(ctx, ds)
+	if err != nil {
+		return err
+	}
+	if currentHash == "" {
+		log.Printf("Canary rollout: no ControllerRevision for %s yet; nothing to roll", ovnNodeDS)
+		return nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodes, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return errors.Wrap(err, "failed to list canary nodes")
+	}
+	canaryNodes := map[string]bool{}
+	for _, node := range nodes.Items {
+		canaryNodes[node.Name] = true
+	}
+	if len(canaryNodes) == 0 {
+		return errors.Errorf("canary selector %q matches no nodes", selector.String())
+	}
+
+	pods := &corev1.PodList{}
+	err = r.client.List(ctx, pods, client.InNamespace(ovnNamespace), client.MatchingLabels{"app": ovnNodeAppName})
+	if err != nil {
+		return errors.Wrap(err, "failed to list ovnkube-node pods")
+	}
+
+	canaryPods := []corev1.Pod{}
+	restPods := []corev1.Pod{}
+	for _, pod := range pods.Items {
+		// Pods already on the current revision survived (or came from) the
+		// rollout; deleting them again would just churn the dataplane.
+		if pod.Labels[appsv1.DefaultDaemonSetUniqueLabelKey] == currentHash {
+			continue
+		}
+		if canaryNodes[pod.Spec.NodeName] {
+			canaryPods = append(canaryPods, pod)
+		} else {
+			restPods = append(restPods, pod)
+		}
+	}
+	if len(canaryPods) == 0 && len(restPods) == 0 {
+		return nil
+	}
+
+	log.Printf("Canary rollout: replacing %d ovnkube-node pods on canary nodes first", len(canaryPods))
+	if err := r.rollPodBatch(ctx, canaryPods); err != nil {
+		return errors.Wrap(err, "canary phase failed; not proceeding cluster-wide")
+	}
+
+	for len(restPods) > 0 {
+		n := canaryBatchSize
+		if n > len(restPods) {
+			n = len(restPods)
+		}
+		batch := restPods[:n]
+		restPods = restPods[n:]
+		if err := r.rollPodBatch(ctx, batch); err != nil {
+			return errors.Wrap(err, "batched rollout failed")
+		}
+	}
+	log.Printf("Canary rollout of %s complete", ovnNodeDS)
+	return nil
+}
+
+// rollPodBatch deletes the given pods and waits until the DaemonSet reports
+// no unavailable pods again.
+func (r *ReconcileOperConfig) rollPodBatch(ctx context.Context, pods []corev1.Pod) error {
+	for i := range pods {
+		if err := r.client.Delete(ctx, &pods[i]); err != nil {
+			return errors.Wrapf(err, "failed to delete pod %s/%s", pods[i].Namespace, pods[i].Name)
+		}
+	}
+	return wait.PollImmediate(verifyPollInterval, canaryVerifyTimeout, func() (bool, error) {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+		ds := &appsv1.DaemonSet{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: ovnNamespace, Name: ovnNodeDS}, ds); err != nil {
+			return false, nil
+		}
+		return ds.Status.NumberUnavailable == 0 && ds.Status.NumberReady == ds.Status.DesiredNumberScheduled, nil
+	})
+}
+
+// canarySelector parses the canary annotation on the operator config into a
+// node label selector, if present.
+func canarySelector(annotations map[string]string) (labels.Selector, bool) {
+	val, ok := annotations[names.OVNCanarySelectorAnnotation]
+	if !ok || val == "" {
+		return nil, false
+	}
+	selector, err := labels.Parse(val)
+	if err != nil {
+		log.Printf("Ignoring invalid %s annotation %q: %v", names.OVNCanarySelectorAnnotation, val, err)
+		return nil, false
+	}
+	return selector, true
+}
@@ -0,0 +1,113 @@
+package operconfig
+
+// Annotation-triggered runtime debug dumps. Annotating the operator config
+// with networkoperator.openshift.io/debug-dump (value "goroutine", "heap" or
+// both, comma-separated) makes the operator write the requested dumps to the
+// network-operator-debug-dump ConfigMap, so support can inspect a live
+// operator without network access to its debug endpoints. The handled value
+// is recorded in a second annotation; changing the request value (e.g. to a
+// timestamped one) triggers a fresh dump.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// maxGoroutineDumpBytes bounds the goroutine dump so the ConfigMap stays
+// under the object size limit; heap profiles are compact and not truncated.
+const maxGoroutineDumpBytes = 900 * 1024
+
+// handleDebugDump acts on the debug-dump annotation, if present and not yet
+// handled. Dump failures are logged but never fail the reconcile.
+func (r *ReconcileOperConfig) handleDebugDump(ctx context.Context, operConfig *operv1.Network) {
+	requested := operConfig.GetAnnotations()[names.DebugDumpAnnotation]
+	if requested == "" || requested == operConfig.GetAnnotations()[names.DebugDumpHandledAnnotation] {
+		return
+	}
+
+	klog.Infof("Debug dump %q requested via annotation", requested)
+	if err := r.writeDebugDump(ctx, requested); err != nil {
+		klog.Errorf("Failed to write debug dump: %v", err)
+		return
+	}
+
+	// Record the handled value so the dump is not repeated every reconcile.
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config := &operv1.Network{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: operConfig.Name}, config); err != nil {
+			return err
+		}
+		if config.Annotations == nil {
+			config.Annotations = map[string]string{}
+		}
+		config.Annotations[names.DebugDumpHandledAnnotation] = requested
+		return r.client.Update(ctx, config)
+	})
+	if err != nil {
+		klog.Errorf("Failed to record handled debug dump annotation: %v", err)
+	}
+}
+
+// writeDebugDump collects the requested dumps and stores them in the
+// DEBUG_DUMP_CONFIGMAP.
+func (r *ReconcileOperConfig) writeDebugDump(ctx context.Context, requested string) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.DEBUG_DUMP_CONFIGMAP,
+		},
+		Data: map[string]string{
+			"requested":    requested,
+			"collected-at": time.Now().UTC().Format(time.RFC3339),
+		},
+		BinaryData: map[string][]byte{},
+	}
+
+	for _, kind := range strings.Split(requested, ",") {
+		switch strings.TrimSpace(kind) {
+		case "goroutine":
+			buf := &bytes.Buffer{}
+			if err := pprof.Lookup("goroutine").WriteTo(buf, 2); err != nil {
+				return err
+			}
+			dump := buf.String()
+			if len(dump) > maxGoroutineDumpBytes {
+				dump = dump[:maxGoroutineDumpBytes] + "\n... truncated ...\n"
+			}
+			cm.Data["goroutine"] = dump
+		case "heap":
+			buf := &bytes.Buffer{}
+			if err := pprof.Lookup("heap").WriteTo(buf, 0); err != nil {
+				return err
+			}
+			cm.BinaryData["heap"] = buf.Bytes()
+		default:
+			return fmt.Errorf("unknown debug dump kind %q, supported kinds are \"goroutine\" and \"heap\"", kind)
+		}
+	}
+
+	existing := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, existing)
+	if apierrors.IsNotFound(err) {
+		return r.client.Create(ctx, cm)
+	} else if err != nil {
+		return err
+	}
+	existing.Data = cm.Data
+	existing.BinaryData = cm.BinaryData
+	return r.client.Update(ctx, existing)
+}
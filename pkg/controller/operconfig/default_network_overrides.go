@@ -0,0 +1,166 @@
+package operconfig
+
+// Per-namespace default network overrides. Multus honors the
+// v1.multus-cni.io/default-network annotation on a namespace, but hand-managed
+// annotations come with no validation. The override syncer reads the desired
+// overrides from a ConfigMap, validates that the referenced
+// NetworkAttachmentDefinitions exist, and manages the namespace annotations,
+// removing them again when an override is withdrawn.
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// DefaultNetworkOverrideCheckPeriod is how often the configured overrides are
+// reconciled against the namespace annotations.
+var DefaultNetworkOverrideCheckPeriod = time.Minute
+
+// defaultNetworkAnnotation is the namespace annotation multus consults for an
+// alternative default network.
+const defaultNetworkAnnotation = "v1.multus-cni.io/default-network"
+
+// overrideManagedAnnotation marks namespace annotations that were written by
+// the operator, so user-managed annotations are never touched.
+const overrideManagedAnnotation = "network.operator.openshift.io/default-network-override"
+
+// runDefaultNetworkOverrideSyncer periodically applies the configured
+// per-namespace default network overrides.
+func (r *ReconcileOperConfig) runDefaultNetworkOverrideSyncer(ctx context.Context) {
+	ticker := time.NewTicker(DefaultNetworkOverrideCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.syncDefaultNetworkOverrides(ctx)
+		}
+	}
+}
+
+func (r *ReconcileOperConfig) syncDefaultNetworkOverrides(ctx context.Context) {
+	desired := map[string]string{}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.DEFAULT_NETWORK_OVERRIDES_CONFIGMAP}
+	if err := r.client.Get(ctx, nsn, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to retrieve ConfigMap %s: %v", names.DEFAULT_NETWORK_OVERRIDES_CONFIGMAP, err)
+			return
+		}
+		// no ConfigMap: any managed annotations are removed below
+	}
+
+	for namespace, ref := range cm.Data {
+		if !r.validateDefaultNetworkOverride(ctx, namespace, ref) {
+			continue
+		}
+		desired[namespace] = ref
+	}
+
+	for namespace, ref := range desired {
+		if err := r.setDefaultNetworkOverride(ctx, namespace, ref); err != nil {
+			klog.Errorf("Failed to set default network override on namespace %s: %v", namespace, err)
+		}
+	}
+
+	// Remove the annotations from namespaces whose override was withdrawn.
+	namespaces := &corev1.NamespaceList{}
+	if err := r.client.List(ctx, namespaces); err != nil {
+		klog.Errorf("Failed to list namespaces for default network override cleanup: %v", err)
+		return
+	}
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if _, managed := ns.Annotations[overrideManagedAnnotation]; !managed {
+			continue
+		}
+		if _, wanted := desired[ns.Name]; wanted {
+			continue
+		}
+		if err := r.setDefaultNetworkOverride(ctx, ns.Name, ""); err != nil {
+			klog.Errorf("Failed to remove default network override from namespace %s: %v", ns.Name, err)
+		}
+	}
+}
+
+// validateDefaultNetworkOverride checks one override entry: the reference must
+// be a NetworkAttachmentDefinition name (optionally namespace-qualified) that
+// resolves to an existing NAD. Invalid entries are logged and skipped rather
+// than failing the rest of the overrides.
+func (r *ReconcileOperConfig) validateDefaultNetworkOverride(ctx context.Context, namespace, ref string) bool {
+	nadNamespace := namespace
+	nadName := ref
+	if parts := strings.SplitN(ref, "/", 2); len(parts) == 2 {
+		nadNamespace = parts[0]
+		nadName = parts[1]
+	}
+	if nadNamespace == "" || nadName == "" || strings.Contains(nadName, "/") {
+		klog.Warningf("Invalid default network override %q for namespace %s in ConfigMap %s: must be name or namespace/name", ref, namespace, names.DEFAULT_NETWORK_OVERRIDES_CONFIGMAP)
+		return false
+	}
+
+	nad := &uns.Unstructured{}
+	nad.SetGroupVersionKind(additionalNetworkNADGVK)
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: nadNamespace, Name: nadName}, nad); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.Warningf("Default network override for namespace %s references NetworkAttachmentDefinition %s/%s, which does not exist", namespace, nadNamespace, nadName)
+		} else {
+			klog.Errorf("Failed to verify NetworkAttachmentDefinition %s/%s for namespace %s: %v", nadNamespace, nadName, namespace, err)
+		}
+		return false
+	}
+	return true
+}
+
+// setDefaultNetworkOverride updates the override annotations on a namespace;
+// an empty ref removes them.
+func (r *ReconcileOperConfig) setDefaultNetworkOverride(ctx context.Context, namespace, ref string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		ns := &corev1.Namespace{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			if apierrors.IsNotFound(err) {
+				if ref != "" {
+					klog.Warningf("Namespace %s with a default network override does not exist", namespace)
+				}
+				return nil
+			}
+			return err
+		}
+		if ref == "" {
+			if _, managed := ns.Annotations[overrideManagedAnnotation]; !managed {
+				return nil
+			}
+			delete(ns.Annotations, overrideManagedAnnotation)
+			delete(ns.Annotations, defaultNetworkAnnotation)
+		} else {
+			if ns.Annotations[defaultNetworkAnnotation] == ref && ns.Annotations[overrideManagedAnnotation] == ref {
+				return nil
+			}
+			// A pre-existing annotation without the managed marker was put
+			// there by hand; leave it alone rather than stomping it.
+			if existing, ok := ns.Annotations[defaultNetworkAnnotation]; ok {
+				if _, managed := ns.Annotations[overrideManagedAnnotation]; !managed {
+					klog.Warningf("Namespace %s already has a user-managed %s annotation (%q); not overriding it with %q", namespace, defaultNetworkAnnotation, existing, ref)
+					return nil
+				}
+			}
+			if ns.Annotations == nil {
+				ns.Annotations = map[string]string{}
+			}
+			ns.Annotations[defaultNetworkAnnotation] = ref
+			ns.Annotations[overrideManagedAnnotation] = ref
+		}
+		return r.client.Update(ctx, ns)
+	})
+}
@@ -0,0 +1,114 @@
+package operconfig
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/apply"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	k8sutil "github.com/openshift/cluster-network-operator/pkg/util/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DriftCheckPeriod is how often the last-rendered manifests are compared
+// against live cluster state.
+var DriftCheckPeriod = 5 * time.Minute
+
+// setRenderedObjects records the most recently rendered (and applied) objects
+// for the drift detector to compare against.
+func (r *ReconcileOperConfig) setRenderedObjects(objs []*uns.Unstructured) {
+	r.renderedMu.Lock()
+	defer r.renderedMu.Unlock()
+	r.renderedObjects = objs
+}
+
+// runDriftDetector periodically compares the last-rendered manifests against
+// live cluster state and publishes a report of objects that have been
+// modified out-of-band, without reverting them. This makes manual hotfixes
+// that the next reconcile would stomp on visible to SREs.
+func (r *ReconcileOperConfig) runDriftDetector(ctx context.Context) {
+	ticker := time.NewTicker(DriftCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.checkDrift(ctx); err != nil {
+				log.Printf("Drift check failed: %v", err)
+			}
+		}
+	}
+}
+
+// checkDrift computes the set of drifted objects and publishes the report.
+func (r *ReconcileOperConfig) checkDrift(ctx context.Context) error {
+	r.renderedMu.Lock()
+	objs := r.renderedObjects
+	r.renderedMu.Unlock()
+	if objs == nil {
+		// Nothing has been rendered yet.
+		return nil
+	}
+
+	drifted := []string{}
+	for _, obj := range objs {
+		objDesc := fmt.Sprintf("(%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		existing := &uns.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+		if apierrors.IsNotFound(err) {
+			drifted = append(drifted, objDesc+" (deleted)")
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// Replay the same merge the apply path uses; if the result still
+		// differs from the live object, something was changed out-of-band.
+		desired := obj.DeepCopy()
+		if err := apply.MergeObjectForUpdate(existing, desired); err != nil {
+			return err
+		}
+		if !equality.Semantic.DeepEqual(existing, desired) {
+			drifted = append(drifted, objDesc)
+		}
+	}
+
+	driftedObjectsGauge.Set(float64(len(drifted)))
+	r.status.SetDrifted(drifted)
+	return r.writeDriftReport(ctx, drifted)
+}
+
+// writeDriftReport records the drift report in a ConfigMap for must-gather
+// and ad-hoc inspection.
+func (r *ReconcileOperConfig) writeDriftReport(ctx context.Context, drifted []string) error {
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.DRIFT_REPORT_CONFIGMAP,
+		},
+		Data: map[string]string{
+			"checked": time.Now().UTC().Format(time.RFC3339),
+			"drifted": strings.Join(drifted, "\n"),
+		},
+	}
+	us, err := k8sutil.ToUnstructured(cm)
+	if err != nil {
+		return fmt.Errorf("failed to transmute drift report ConfigMap: %v", err)
+	}
+	return apply.ApplyObject(ctx, r.client, us)
+}
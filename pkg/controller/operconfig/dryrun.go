@@ -0,0 +1,53 @@
+package operconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/openshift/cluster-network-operator/pkg/apply"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	k8sutil "github.com/openshift/cluster-network-operator/pkg/util/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// writeDryRunManifests records the rendered manifests in a ConfigMap instead
+// of applying them to the cluster, so administrators can preview exactly which
+// objects a configuration change would touch before committing to it.
+func (r *ReconcileOperConfig) writeDryRunManifests(ctx context.Context, objs []*uns.Unstructured) error {
+	data := map[string]string{}
+	for _, obj := range objs {
+		key := strings.ToLower(obj.GetKind()) + "-" + obj.GetName()
+		if ns := obj.GetNamespace(); ns != "" {
+			key = strings.ToLower(obj.GetKind()) + "-" + ns + "-" + obj.GetName()
+		}
+		buf, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal (%s) %s/%s for dry-run: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+		}
+		data[key] = string(buf)
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.DRY_RUN_CONFIGMAP,
+		},
+		Data: data,
+	}
+
+	us, err := k8sutil.ToUnstructured(cm)
+	if err != nil {
+		return fmt.Errorf("failed to transmute dry-run ConfigMap: %v", err)
+	}
+	return apply.ApplyObject(ctx, r.client, us)
+}
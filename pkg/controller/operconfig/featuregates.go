@@ -0,0 +1,96 @@
+package operconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// networkFeatureGates are the feature gates that affect what we render.
+// Changes to any other gate are ignored.
+var networkFeatureGates = []string{
+	"IPv6DualStack",
+	"SCTPSupport",
+	"ServiceNodeExclusion",
+	"NodeDisruptionExclusion",
+}
+
+// observeFeatureGates compares the network-relevant feature gates against the
+// set observed by the previous reconcile. The FeatureGate watch already
+// enqueued us, so by the time a change is detected here the re-render it
+// requires is underway; this records the change in the log and in status
+// rather than requiring an operator restart to pick it up.
+func (r *ReconcileOperConfig) observeFeatureGates(ctx context.Context) {
+	fg := &configv1.FeatureGate{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: "cluster"}, fg)
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to retrieve FeatureGate: %v", err)
+		return
+	}
+
+	observed := networkFeatureGateState(fg)
+	if r.featureGates == nil {
+		// First observation: this process renders from these gates, so any
+		// change recorded by a previous process has been absorbed. Clear the
+		// condition rather than leaving a stale True behind.
+		r.featureGates = observed
+		r.status.SetFeatureGatesChanged(nil)
+		return
+	}
+	changed := diffFeatureGates(r.featureGates, observed)
+	if len(changed) == 0 {
+		return
+	}
+	klog.Infof("Network-relevant feature gates changed: %s", strings.Join(changed, ", "))
+	r.status.SetFeatureGatesChanged(changed)
+	r.featureGates = observed
+}
+
+// networkFeatureGateState resolves the FeatureGate selection to the
+// enabled/disabled state of each network-relevant gate. A nil or unselected
+// FeatureGate resolves through the Default feature set.
+func networkFeatureGateState(fg *configv1.FeatureGate) map[string]bool {
+	enabled := map[string]bool{}
+	if fg.Spec.FeatureSet == configv1.CustomNoUpgrade {
+		if fg.Spec.CustomNoUpgrade != nil {
+			for _, gate := range fg.Spec.CustomNoUpgrade.Enabled {
+				enabled[gate] = true
+			}
+		}
+	} else if selection, ok := configv1.FeatureSets[fg.Spec.FeatureSet]; ok {
+		for _, gate := range selection.Enabled {
+			enabled[gate] = true
+		}
+	} else {
+		klog.Warningf("Unknown feature set %q; treating network-relevant gates as defaulted", fg.Spec.FeatureSet)
+		for _, gate := range configv1.FeatureSets[configv1.Default].Enabled {
+			enabled[gate] = true
+		}
+	}
+
+	state := make(map[string]bool, len(networkFeatureGates))
+	for _, gate := range networkFeatureGates {
+		state[gate] = enabled[gate]
+	}
+	return state
+}
+
+// diffFeatureGates returns a sorted "gate: old -> new" entry for every gate
+// whose state differs between the two observations.
+func diffFeatureGates(before, after map[string]bool) []string {
+	changed := []string{}
+	for gate, now := range after {
+		if was := before[gate]; was != now {
+			changed = append(changed, fmt.Sprintf("%s: %t -> %t", gate, was, now))
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,78 @@
+package operconfig
+
+import (
+	"context"
+	"log"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// preservedFromGC lists kinds that are never garbage-collected even when no
+// longer rendered: namespaces may hold user data, and deleting a CRD would
+// cascade to user-created CRs.
+func preservedFromGC(kind string) bool {
+	switch kind {
+	case "Namespace", "CustomResourceDefinition":
+		return true
+	}
+	return false
+}
+
+// cleanupOrphanedObjects deletes objects that were rendered by the previously
+// applied configuration but are no longer rendered (e.g. after disabling
+// multus networking). It can be opted out of with the GCDisabledAnnotation on
+// the operator config.
+func (r *ReconcileOperConfig) cleanupOrphanedObjects(ctx context.Context, operConfig *operv1.Network, prevRendered []renderedObjectRef, objs []*uns.Unstructured) {
+	if len(prevRendered) == 0 {
+		return
+	}
+	gcDisabled := false
+	if _, ok := operConfig.GetAnnotations()[names.GCDisabledAnnotation]; ok {
+		log.Printf("Orphaned object cleanup is disabled via the %s annotation", names.GCDisabledAnnotation)
+		gcDisabled = true
+	}
+
+	current := map[renderedObjectRef]bool{}
+	for _, obj := range objs {
+		current[renderedObjectRef{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		}] = true
+	}
+
+	for _, ref := range prevRendered {
+		// Objects registered for pruning are deleted unconditionally once no
+		// longer rendered; everything else follows the normal GC gating.
+		prune := ref.Prune
+		ref.Prune = false
+		if current[ref] {
+			continue
+		}
+		if !prune && (gcDisabled || preservedFromGC(ref.Kind)) {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			log.Printf("Failed to parse apiVersion %q of orphaned object: %v", ref.APIVersion, err)
+			continue
+		}
+		obj := &uns.Unstructured{}
+		obj.SetGroupVersionKind(gv.WithKind(ref.Kind))
+		obj.SetNamespace(ref.Namespace)
+		obj.SetName(ref.Name)
+		log.Printf("Deleting orphaned rendered object (%s) %s/%s", obj.GroupVersionKind(), ref.Namespace, ref.Name)
+		err = r.client.Delete(ctx, obj, client.PropagationPolicy("Background"))
+		if err != nil && !apierrors.IsNotFound(err) {
+			// Leave it for the next reconcile; the record still lists it.
+			log.Printf("Failed to delete orphaned object (%s) %s/%s: %v", obj.GroupVersionKind(), ref.Namespace, ref.Name, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package operconfig
+
+import (
+	"log"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// hibernationRequested reports whether the control-plane workloads should be
+// scaled to zero.
+func hibernationRequested(operConfig *operv1.Network) bool {
+	return operConfig.GetAnnotations()[names.HibernationAnnotation] == "true"
+}
+
+// hibernateWorkloads scales every rendered Deployment to zero replicas. The
+// rendered DaemonSets are left alone: their pods disappear with the nodes,
+// and scaling them is not expressible anyway. Because the replica count is
+// rewritten before apply, removing the hibernation annotation restores the
+// configured counts on the next reconcile without any extra bookkeeping.
+func hibernateWorkloads(objs []*uns.Unstructured) error {
+	for _, obj := range objs {
+		if obj.GetAPIVersion() != "apps/v1" || obj.GetKind() != "Deployment" {
+			continue
+		}
+		if err := uns.SetNestedField(obj.Object, int64(0), "spec", "replicas"); err != nil {
+			return err
+		}
+		log.Printf("Hibernation: scaling Deployment %s/%s to zero replicas", obj.GetNamespace(), obj.GetName())
+	}
+	return nil
+}
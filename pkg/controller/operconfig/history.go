@@ -0,0 +1,151 @@
+package operconfig
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/apply"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+	k8sutil "github.com/openshift/cluster-network-operator/pkg/util/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// AppliedHistoryLimit is how many previously applied configurations are
+// retained for rollback.
+const AppliedHistoryLimit = 10
+
+// appliedHistoryEntry is one generation of applied configuration.
+type appliedHistoryEntry struct {
+	AppliedAt string `json:"appliedAt"`
+	Spec      string `json:"spec"`
+}
+
+// getAppliedHistory retrieves the applied-configuration history, oldest
+// first. Returns nil with no error if no history exists yet.
+func (r *ReconcileOperConfig) getAppliedHistory(ctx context.Context) ([]appliedHistoryEntry, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.APPLIED_HISTORY_CONFIGMAP}, cm)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	entries := []appliedHistoryEntry{}
+	if raw, ok := cm.Data["history"]; ok {
+		if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// recordAppliedHistory appends the just-applied configuration to the history,
+// trimming it to AppliedHistoryLimit entries. Unchanged configurations are
+// not re-recorded on periodic resyncs.
+func (r *ReconcileOperConfig) recordAppliedHistory(ctx context.Context, operConfig *operv1.Network) error {
+	spec, err := json.Marshal(operConfig.Spec)
+	if err != nil {
+		return err
+	}
+
+	entries, err := r.getAppliedHistory(ctx)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 && entries[len(entries)-1].Spec == string(spec) {
+		return nil
+	}
+	entries = append(entries, appliedHistoryEntry{
+		AppliedAt: time.Now().UTC().Format(time.RFC3339),
+		Spec:      string(spec),
+	})
+	if len(entries) > AppliedHistoryLimit {
+		entries = entries[len(entries)-AppliedHistoryLimit:]
+	}
+
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.APPLIED_HISTORY_CONFIGMAP,
+		},
+		Data: map[string]string{
+			"history": string(raw),
+		},
+	}
+	us, err := k8sutil.ToUnstructured(cm)
+	if err != nil {
+		return err
+	}
+	return apply.ApplyObject(ctx, r.client, us)
+}
+
+// maybeRollback handles the rollback annotation: if it names a timestamp from
+// the applied-configuration history, the operator config spec is rolled back
+// to that generation (subject to the usual change-safety checks) and the
+// annotation is removed. Returns true if a rollback was committed; the write
+// re-triggers reconciliation with the restored spec.
+func (r *ReconcileOperConfig) maybeRollback(ctx context.Context, operConfig *operv1.Network) (bool, error) {
+	target, ok := operConfig.GetAnnotations()[names.RollbackAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	entries, err := r.getAppliedHistory(ctx)
+	if err != nil {
+		return false, err
+	}
+	var entry *appliedHistoryEntry
+	for i := range entries {
+		if entries[i].AppliedAt == target {
+			entry = &entries[i]
+			break
+		}
+	}
+	if entry == nil {
+		return false, errors.Errorf("no applied configuration with timestamp %q in history", target)
+	}
+
+	targetSpec := &operv1.NetworkSpec{}
+	if err := json.Unmarshal([]byte(entry.Spec), targetSpec); err != nil {
+		return false, errors.Wrap(err, "failed to decode rollback target")
+	}
+
+	// The rollback target must itself be a safe change from what is running.
+	currentSpec := operConfig.Spec.DeepCopy()
+	network.FillDefaults(currentSpec, currentSpec)
+	filledTarget := targetSpec.DeepCopy()
+	network.FillDefaults(filledTarget, currentSpec)
+	if err := network.IsChangeSafe(currentSpec, filledTarget); err != nil {
+		return false, errors.Wrap(err, "rollback target is not a safe change")
+	}
+
+	log.Printf("Rolling operator configuration back to generation applied at %s", entry.AppliedAt)
+	updated := operConfig.DeepCopy()
+	updated.Spec = *targetSpec
+	delete(updated.Annotations, names.RollbackAnnotation)
+	// Update directly rather than through UpdateOperConfig: the apply merge
+	// preserves existing annotations, which would resurrect the rollback
+	// annotation and re-trigger the rollback forever.
+	if err := r.client.Update(ctx, updated); err != nil {
+		return false, err
+	}
+	return true, nil
+}
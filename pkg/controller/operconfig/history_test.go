@@ -0,0 +1,187 @@
+package operconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newHistoryReconciler builds a ReconcileOperConfig over a fake client
+// seeded with the given objects, for exercising the applied-history code.
+func newHistoryReconciler(t *testing.T, initial ...runtime.Object) *ReconcileOperConfig {
+	t.Helper()
+	return &ReconcileOperConfig{
+		client: fake.NewClientBuilder().WithRuntimeObjects(initial...).Build(),
+	}
+}
+
+// historyConfigMap builds the applied-history ConfigMap holding the given
+// entries.
+func historyConfigMap(t *testing.T, entries []appliedHistoryEntry) *corev1.ConfigMap {
+	t.Helper()
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("Failed to marshal history entries: %v", err)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.APPLIED_HISTORY_CONFIGMAP,
+		},
+		Data: map[string]string{"history": string(raw)},
+	}
+}
+
+func specJSON(t *testing.T, spec *operv1.NetworkSpec) string {
+	t.Helper()
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("Failed to marshal spec: %v", err)
+	}
+	return string(raw)
+}
+
+func annotatedOperConfig(rollbackTo string) *operv1.Network {
+	config := &operv1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.OPERATOR_CONFIG,
+			Annotations: map[string]string{names.RollbackAnnotation: rollbackTo},
+		},
+	}
+	config.Spec = *removedTestSpec()
+	return config
+}
+
+func TestMaybeRollbackMissingTimestamp(t *testing.T) {
+	entries := []appliedHistoryEntry{
+		{AppliedAt: "2021-05-01T00:00:00Z", Spec: specJSON(t, removedTestSpec())},
+	}
+	r := newHistoryReconciler(t, historyConfigMap(t, entries))
+
+	rolledBack, err := r.maybeRollback(context.TODO(), annotatedOperConfig("2021-01-01T00:00:00Z"))
+	if rolledBack {
+		t.Error("Expected no rollback for a timestamp not in the history")
+	}
+	if err == nil || !strings.Contains(err.Error(), "no applied configuration") {
+		t.Errorf("Expected a missing-timestamp error, got %v", err)
+	}
+
+	// Same outcome when no history was ever recorded.
+	r = newHistoryReconciler(t)
+	rolledBack, err = r.maybeRollback(context.TODO(), annotatedOperConfig("2021-01-01T00:00:00Z"))
+	if rolledBack || err == nil {
+		t.Errorf("Expected an error with no history, got rolledBack=%v err=%v", rolledBack, err)
+	}
+}
+
+func TestRecordAppliedHistoryDedupeAndTrim(t *testing.T) {
+	r := newHistoryReconciler(t)
+	config := annotatedOperConfig("")
+
+	// Recording the same spec twice must not duplicate the entry.
+	for i := 0; i < 2; i++ {
+		if err := r.recordAppliedHistory(context.TODO(), config); err != nil {
+			t.Fatalf("recordAppliedHistory failed: %v", err)
+		}
+	}
+	entries, err := r.getAppliedHistory(context.TODO())
+	if err != nil {
+		t.Fatalf("getAppliedHistory failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected an unchanged spec to be recorded once, got %d entries", len(entries))
+	}
+
+	// Recording more distinct specs than the limit keeps only the newest
+	// AppliedHistoryLimit generations.
+	total := AppliedHistoryLimit + 5
+	for i := 0; i < total; i++ {
+		config.Spec.ServiceNetwork = []string{fmt.Sprintf("172.%d.0.0/16", 16+i)}
+		if err := r.recordAppliedHistory(context.TODO(), config); err != nil {
+			t.Fatalf("recordAppliedHistory failed: %v", err)
+		}
+	}
+	entries, err = r.getAppliedHistory(context.TODO())
+	if err != nil {
+		t.Fatalf("getAppliedHistory failed: %v", err)
+	}
+	if len(entries) != AppliedHistoryLimit {
+		t.Fatalf("Expected the history to be trimmed to %d entries, got %d", AppliedHistoryLimit, len(entries))
+	}
+	oldest := &operv1.NetworkSpec{}
+	if err := json.Unmarshal([]byte(entries[0].Spec), oldest); err != nil {
+		t.Fatalf("Failed to decode oldest history entry: %v", err)
+	}
+	wantOldest := fmt.Sprintf("172.%d.0.0/16", 16+total-AppliedHistoryLimit)
+	if len(oldest.ServiceNetwork) != 1 || oldest.ServiceNetwork[0] != wantOldest {
+		t.Errorf("Expected the oldest retained entry to have ServiceNetwork %q, got %v", wantOldest, oldest.ServiceNetwork)
+	}
+}
+
+func TestMaybeRollbackUnsafeTarget(t *testing.T) {
+	// The recorded generation has a different ServiceNetwork, which is not a
+	// safe change from the running configuration.
+	unsafeSpec := removedTestSpec()
+	unsafeSpec.ServiceNetwork = []string{"172.31.0.0/16"}
+	entries := []appliedHistoryEntry{
+		{AppliedAt: "2021-05-01T00:00:00Z", Spec: specJSON(t, unsafeSpec)},
+	}
+	config := annotatedOperConfig("2021-05-01T00:00:00Z")
+	r := newHistoryReconciler(t, historyConfigMap(t, entries), config)
+
+	rolledBack, err := r.maybeRollback(context.TODO(), config)
+	if rolledBack {
+		t.Error("Expected an unsafe rollback target not to be applied")
+	}
+	if err == nil || !strings.Contains(err.Error(), "not a safe change") {
+		t.Errorf("Expected a change-safety error, got %v", err)
+	}
+
+	// The config must be untouched: spec unchanged, annotation still present.
+	current := &operv1.Network{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: names.OPERATOR_CONFIG}, current); err != nil {
+		t.Fatalf("Failed to fetch operator config: %v", err)
+	}
+	if current.Spec.ServiceNetwork[0] != removedTestSpec().ServiceNetwork[0] {
+		t.Errorf("Expected the spec not to change, got ServiceNetwork %v", current.Spec.ServiceNetwork)
+	}
+	if _, ok := current.Annotations[names.RollbackAnnotation]; !ok {
+		t.Error("Expected the rollback annotation to remain after a refused rollback")
+	}
+}
+
+func TestMaybeRollbackSafeTarget(t *testing.T) {
+	entries := []appliedHistoryEntry{
+		{AppliedAt: "2021-05-01T00:00:00Z", Spec: specJSON(t, removedTestSpec())},
+	}
+	config := annotatedOperConfig("2021-05-01T00:00:00Z")
+	r := newHistoryReconciler(t, historyConfigMap(t, entries), config)
+
+	rolledBack, err := r.maybeRollback(context.TODO(), config)
+	if err != nil {
+		t.Fatalf("maybeRollback failed: %v", err)
+	}
+	if !rolledBack {
+		t.Fatal("Expected the rollback to be committed")
+	}
+
+	current := &operv1.Network{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: names.OPERATOR_CONFIG}, current); err != nil {
+		t.Fatalf("Failed to fetch operator config: %v", err)
+	}
+	if _, ok := current.Annotations[names.RollbackAnnotation]; ok {
+		t.Error("Expected the rollback annotation to be removed after the rollback")
+	}
+}
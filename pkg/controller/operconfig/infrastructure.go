@@ -0,0 +1,92 @@
+package operconfig
+
+// Several rendered manifests embed addresses from the Infrastructure status
+// (API/ingress VIPs, platform load balancer configuration). Changes to those
+// are observed here, analogous to observeFeatureGates: the Infrastructure
+// watch already enqueued the re-render, this records what changed and holds
+// a condition until the new addresses have propagated.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// observeInfrastructure compares the network-relevant platform addresses
+// against the set observed by the previous reconcile. On a change the
+// PlatformAddressesPropagated condition turns False; it is cleared once the
+// reconcile that re-renders them succeeds.
+func (r *ReconcileOperConfig) observeInfrastructure(ctx context.Context) {
+	infra := &configv1.Infrastructure{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: "cluster"}, infra)
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to retrieve Infrastructure: %v", err)
+		return
+	}
+
+	observed := platformAddressState(infra)
+	if r.infraAddresses == nil {
+		// First observation; nothing to diff against.
+		r.infraAddresses = observed
+		return
+	}
+	changed := diffPlatformAddresses(r.infraAddresses, observed)
+	if len(changed) == 0 {
+		return
+	}
+	klog.Infof("Platform addresses changed: %s", strings.Join(changed, ", "))
+	r.status.SetPlatformAddressesPropagating(changed)
+	r.infraAddresses = observed
+	r.infraChanged = true
+}
+
+// platformAddressState extracts the addresses that rendered manifests depend
+// on.
+func platformAddressState(infra *configv1.Infrastructure) map[string]string {
+	state := map[string]string{
+		"apiServerURL":         infra.Status.APIServerURL,
+		"apiServerInternalURL": infra.Status.APIServerInternalURL,
+	}
+	if p := infra.Status.PlatformStatus; p != nil {
+		switch {
+		case p.BareMetal != nil:
+			state["apiServerInternalIP"] = p.BareMetal.APIServerInternalIP
+			state["ingressIP"] = p.BareMetal.IngressIP
+		case p.OpenStack != nil:
+			state["apiServerInternalIP"] = p.OpenStack.APIServerInternalIP
+			state["ingressIP"] = p.OpenStack.IngressIP
+		case p.Ovirt != nil:
+			state["apiServerInternalIP"] = p.Ovirt.APIServerInternalIP
+			state["ingressIP"] = p.Ovirt.IngressIP
+		case p.VSphere != nil:
+			state["apiServerInternalIP"] = p.VSphere.APIServerInternalIP
+			state["ingressIP"] = p.VSphere.IngressIP
+		}
+	}
+	return state
+}
+
+// diffPlatformAddresses describes the addresses whose value differs between
+// the two observations.
+func diffPlatformAddresses(previous, observed map[string]string) []string {
+	changed := []string{}
+	for key, value := range observed {
+		if previous[key] != value {
+			changed = append(changed, fmt.Sprintf("%s (%q -> %q)", key, previous[key], value))
+		}
+	}
+	for key, value := range previous {
+		if _, ok := observed[key]; !ok {
+			changed = append(changed, fmt.Sprintf("%s (%q -> %q)", key, value, ""))
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
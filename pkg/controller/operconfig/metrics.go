@@ -0,0 +1,65 @@
+package operconfig
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// applyFailureCounter counts failures to apply individual rendered objects,
+// so that all broken operands are visible at once rather than just the last
+// one to fail.
+var applyFailureCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cno_object_apply_failures_total",
+		Help: "Total number of failures to apply a rendered object, labeled by object kind, namespace and name.",
+	},
+	[]string{"kind", "namespace", "name"},
+)
+
+// reconcilePhaseDuration observes how long each stage of the operconfig
+// reconcile loop takes, so operators can see where reconcile time goes on
+// large clusters.
+var reconcilePhaseDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "cno_reconcile_phase_duration_seconds",
+		Help:    "Duration of each operconfig reconcile phase.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+	},
+	[]string{"phase"},
+)
+
+// reconcileCounter counts operconfig reconcile attempts by outcome
+// ("success", "failure" or "skipped").
+var reconcileCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cno_reconciles_total",
+		Help: "Total number of operconfig reconcile attempts, labeled by outcome.",
+	},
+	[]string{"outcome"},
+)
+
+// applyRetryCounter counts backoff retries of transient per-object apply
+// failures.
+var applyRetryCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cno_object_apply_retries_total",
+		Help: "Total number of retries of transient apply failures, labeled by object kind, namespace and name.",
+	},
+	[]string{"kind", "namespace", "name"},
+)
+
+// driftedObjectsGauge reports how many rendered objects currently differ from
+// live cluster state due to out-of-band modification.
+var driftedObjectsGauge = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "cno_drifted_objects",
+		Help: "Number of rendered objects that have been modified out-of-band.",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(applyFailureCounter)
+	prometheus.MustRegister(applyRetryCounter)
+	prometheus.MustRegister(driftedObjectsGauge)
+	prometheus.MustRegister(reconcilePhaseDuration)
+	prometheus.MustRegister(reconcileCounter)
+}
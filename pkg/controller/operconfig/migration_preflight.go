@@ -0,0 +1,176 @@
+package operconfig
+
+// Pre-flight validation for a default network migration. When
+// Spec.Migration.NetworkType requests a different network type, the
+// configuration and the live cluster are checked for things the target plugin
+// cannot carry over (egress router pods, multitenant isolation, MTU headroom).
+// The findings are published as a report ConfigMap and the migration is
+// refused until the administrator acknowledges them with an annotation.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// egressRouterMacvlanAnnotation marks openshift-sdn egress router pods, which
+// have no OVN-Kubernetes equivalent.
+const egressRouterMacvlanAnnotation = "pod.network.openshift.io/assign-macvlan"
+
+// ovnEncapOverhead is the per-packet overhead OVN-Kubernetes needs on top of
+// the cluster MTU (geneve), used to validate MTU headroom before migrating.
+const ovnEncapOverhead = 100
+
+// preflightMigration validates a requested default network migration before
+// any migration rendering happens. It returns an error when the migration must
+// not start yet; the report ConfigMap always reflects the latest findings.
+func (r *ReconcileOperConfig) preflightMigration(ctx context.Context, operConfig *operv1.Network) error {
+	migration := operConfig.Spec.Migration
+	if migration == nil || migration.NetworkType == "" ||
+		migration.NetworkType == operConfig.Spec.DefaultNetwork.Type {
+		// No migration is starting; any previous report is stale.
+		r.deletePreflightReport(ctx)
+		return nil
+	}
+
+	problems := []string{}
+	problems = append(problems, r.checkEgressRouterPods(ctx)...)
+	problems = append(problems, checkMultitenantIsolation(&operConfig.Spec)...)
+	problems = append(problems, checkMigrationMTU(&operConfig.Spec, migration.NetworkType)...)
+
+	acknowledged := operConfig.GetAnnotations()[names.MigrationAckAnnotation] == "true"
+	result := "passed"
+	if len(problems) > 0 {
+		if acknowledged {
+			result = "acknowledged"
+		} else {
+			result = "blocked"
+		}
+	}
+
+	if err := r.publishPreflightReport(ctx, string(migration.NetworkType), result, problems); err != nil {
+		klog.Errorf("Failed to publish migration pre-flight report (continuing): %v", err)
+	}
+
+	if len(problems) > 0 && !acknowledged {
+		return fmt.Errorf("migration to %s blocked by pre-flight validation: %s. Review the %s/%s ConfigMap and annotate the operator configuration with %s=true to proceed anyway",
+			migration.NetworkType, strings.Join(problems, "; "),
+			names.APPLIED_NAMESPACE, names.MIGRATION_PREFLIGHT_CONFIGMAP, names.MigrationAckAnnotation)
+	}
+	return nil
+}
+
+// checkEgressRouterPods reports openshift-sdn egress router pods, which stop
+// working once the SDN plugin is removed.
+func (r *ReconcileOperConfig) checkEgressRouterPods(ctx context.Context) []string {
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods); err != nil {
+		return []string{fmt.Sprintf("could not check for egress router pods: %v", err)}
+	}
+
+	routers := []string{}
+	for _, pod := range pods.Items {
+		if _, ok := pod.Annotations[egressRouterMacvlanAnnotation]; ok {
+			routers = append(routers, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	if len(routers) == 0 {
+		return nil
+	}
+	sort.Strings(routers)
+	return []string{fmt.Sprintf("cluster has %d openshift-sdn egress router pod(s) that will stop working after the migration: %s",
+		len(routers), strings.Join(routers, ", "))}
+}
+
+// checkMultitenantIsolation reports that multitenant isolation has no direct
+// equivalent on the target plugin and must be recreated as NetworkPolicies.
+func checkMultitenantIsolation(conf *operv1.NetworkSpec) []string {
+	sdn := conf.DefaultNetwork.OpenShiftSDNConfig
+	if conf.DefaultNetwork.Type != operv1.NetworkTypeOpenShiftSDN || sdn == nil {
+		return nil
+	}
+	if sdn.Mode == operv1.SDNModeMultitenant {
+		return []string{"openshift-sdn is running in Multitenant mode; project isolation is not migrated and must be recreated with NetworkPolicies before migrating"}
+	}
+	return nil
+}
+
+// checkMigrationMTU verifies the current cluster MTU still fits under the
+// probed host MTU once the target plugin's encapsulation overhead applies.
+func checkMigrationMTU(conf *operv1.NetworkSpec, target operv1.NetworkType) []string {
+	if target != operv1.NetworkTypeOVNKubernetes {
+		return nil
+	}
+	clusterMTU := 0
+	if sdn := conf.DefaultNetwork.OpenShiftSDNConfig; sdn != nil && sdn.MTU != nil {
+		clusterMTU = int(*sdn.MTU)
+	}
+	hostMTU := network.ProbedHostMTU()
+	if clusterMTU == 0 || hostMTU == 0 {
+		return nil
+	}
+	if clusterMTU+ovnEncapOverhead > hostMTU {
+		return []string{fmt.Sprintf("cluster MTU %d leaves no room for the OVN-Kubernetes encapsulation overhead (%d) under the host MTU %d; lower the cluster MTU (MTU migration) before migrating",
+			clusterMTU, ovnEncapOverhead, hostMTU)}
+	}
+	return nil
+}
+
+// publishPreflightReport writes the pre-flight findings to the report
+// ConfigMap in APPLIED_NAMESPACE.
+func (r *ReconcileOperConfig) publishPreflightReport(ctx context.Context, target, result string, problems []string) error {
+	data := map[string]string{
+		"target":     target,
+		"result":     result,
+		"checked-at": time.Now().UTC().Format(time.RFC3339),
+	}
+	for i, problem := range problems {
+		data[fmt.Sprintf("problem.%d", i+1)] = problem
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.MIGRATION_PREFLIGHT_CONFIGMAP}
+		err := r.client.Get(ctx, nsn, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: data,
+			}
+			return r.client.Create(ctx, cm)
+		} else if err != nil {
+			return err
+		}
+		cm.Data = data
+		return r.client.Update(ctx, cm)
+	})
+}
+
+// deletePreflightReport removes a stale report once no migration is pending.
+func (r *ReconcileOperConfig) deletePreflightReport(ctx context.Context) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.MIGRATION_PREFLIGHT_CONFIGMAP,
+		},
+	}
+	if err := r.client.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to delete migration pre-flight report (continuing): %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package operconfig
+
+import (
+	"sync"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// NodeEventCoalesceWindow is how long node events are coalesced before one
+// reconcile of the operator configuration is triggered. On clusters with
+// hundreds of nodes and frequent label churn, enqueueing a full reconcile per
+// node event causes reconcile storms.
+var NodeEventCoalesceWindow = 15 * time.Second
+
+// nodeEventDebouncer funnels bursts of node events into a single enqueue of
+// the operator configuration per coalescing window.
+type nodeEventDebouncer struct {
+	mu      sync.Mutex
+	pending bool
+	events  chan event.GenericEvent
+}
+
+func newNodeEventDebouncer() *nodeEventDebouncer {
+	return &nodeEventDebouncer{events: make(chan event.GenericEvent)}
+}
+
+var _ handler.EventHandler = &nodeEventDebouncer{}
+
+// noteEvent schedules a single flush per window; events arriving while a
+// flush is pending are absorbed.
+func (d *nodeEventDebouncer) noteEvent() {
+	d.mu.Lock()
+	if d.pending {
+		d.mu.Unlock()
+		return
+	}
+	d.pending = true
+	d.mu.Unlock()
+
+	time.AfterFunc(NodeEventCoalesceWindow, func() {
+		d.mu.Lock()
+		d.pending = false
+		d.mu.Unlock()
+		d.events <- event.GenericEvent{
+			Object: &operv1.Network{ObjectMeta: metav1.ObjectMeta{Name: names.OPERATOR_CONFIG}},
+		}
+	})
+}
+
+func (d *nodeEventDebouncer) Create(_ event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	d.noteEvent()
+}
+
+func (d *nodeEventDebouncer) Update(_ event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	d.noteEvent()
+}
+
+func (d *nodeEventDebouncer) Delete(_ event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	d.noteEvent()
+}
+
+func (d *nodeEventDebouncer) Generic(_ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	d.noteEvent()
+}
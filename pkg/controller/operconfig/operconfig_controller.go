@@ -3,8 +3,8 @@ package operconfig
 import (
 	"context"
 	"fmt"
-	"log"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -17,12 +17,17 @@ import (
 	"github.com/openshift/cluster-network-operator/pkg/network"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utiluuid "k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -54,6 +59,7 @@ func newReconciler(mgr manager.Manager, status *statusmanager.StatusManager) *Re
 		scheme:        mgr.GetScheme(),
 		status:        status,
 		mapper:        mgr.GetRESTMapper(),
+		recorder:      mgr.GetEventRecorderFor("operconfig-controller"),
 		podReconciler: newPodReconciler(status),
 	}
 }
@@ -72,6 +78,68 @@ func add(mgr manager.Manager, r *ReconcileOperConfig) error {
 		return err
 	}
 
+	// Node events are coalesced: many node changes in a short window produce
+	// a single reconcile of the operator configuration.
+	debouncer := newNodeEventDebouncer()
+	err = c.Watch(&source.Kind{Type: &corev1.Node{}}, debouncer)
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Channel{Source: debouncer.events}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	// Feature gate changes re-render the operator configuration; the diff is
+	// logged and recorded in status by observeFeatureGates.
+	err = c.Watch(&source.Kind{Type: &configv1.FeatureGate{}}, handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: names.OPERATOR_CONFIG}}}
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Infrastructure status changes (API/ingress VIPs, platform load
+	// balancer configuration) re-render the manifests embedding them; the
+	// diff is recorded by observeInfrastructure.
+	err = c.Watch(&source.Kind{Type: &configv1.Infrastructure{}}, handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: names.OPERATOR_CONFIG}}}
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Changes to the well-known input ConfigMaps re-render immediately, and
+	// mark the reconcile so the apply step can be narrowed to the objects
+	// that actually depend on them (see targeted.go).
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		if obj.GetNamespace() != names.APPLIED_NAMESPACE {
+			return nil
+		}
+		if _, ok := renderTargets[obj.GetName()]; !ok {
+			return nil
+		}
+		r.noteTargetedInput(obj.GetName())
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: names.OPERATOR_CONFIG}}}
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Secret changes in the operand namespaces (IPsec CAs, serving certs,
+	// cloud credentials) re-render rather than waiting out the resync
+	// period; the informers are namespaced so the cache stays small.
+	secretEvents, err := startSecretInformers(mgr)
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Channel{Source: secretEvents}, handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: names.OPERATOR_CONFIG}}}
+	}))
+	if err != nil {
+		return err
+	}
+
 	// Likewise for the Pod reconciler
 	c, err = controller.New("pod-controller", mgr, controller.Options{Reconciler: r.podReconciler})
 	if err != nil {
@@ -86,6 +154,33 @@ func add(mgr manager.Manager, r *ReconcileOperConfig) error {
 		return err
 	}
 
+	// Periodically compare the rendered manifests against live cluster state.
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		r.runDriftDetector(ctx)
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Periodically verify the state of the configured additional networks.
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		r.runAdditionalNetworkTracker(ctx)
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Periodically apply the configured per-namespace default network overrides.
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		r.runDefaultNetworkOverrideSyncer(ctx)
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -99,17 +194,42 @@ type ReconcileOperConfig struct {
 	scheme        *runtime.Scheme
 	status        *statusmanager.StatusManager
 	mapper        meta.RESTMapper
+	recorder      record.EventRecorder
 	podReconciler *ReconcilePods
+
+	// featureGates is the network-relevant feature gate state observed by the
+	// previous reconcile, for change detection.
+	featureGates map[string]bool
+
+	// infraAddresses is the platform address state observed by the previous
+	// reconcile; infraChanged is set while an observed change has yet to be
+	// re-rendered successfully.
+	infraAddresses map[string]string
+	infraChanged   bool
+
+	// targeted tracks changed input ConfigMaps so their reconcile can apply
+	// only the dependent objects.
+	targeted targetedState
+
+	// Most recently rendered objects, retained for out-of-band drift
+	// detection.
+	renderedMu      sync.Mutex
+	renderedObjects []*uns.Unstructured
 }
 
 // Reconcile updates the state of the cluster to match that which is desired
 // in the operator configuration (Network.operator.openshift.io)
 func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	log.Printf("Reconciling Network.operator.openshift.io %s\n", request.Name)
+	reconcileID := utiluuid.NewUUID()
+	klog.InfoS("Reconciling Network.operator.openshift.io", "name", request.Name, "reconcileID", reconcileID)
+
+	outcome := "failure"
+	defer func() { reconcileCounter.WithLabelValues(outcome).Inc() }()
 
 	// We won't create more than one network
 	if request.Name != names.OPERATOR_CONFIG {
-		log.Printf("Ignoring Network.operator.openshift.io without default name")
+		klog.InfoS("Ignoring Network.operator.openshift.io without default name", "reconcileID", reconcileID)
+		outcome = "skipped"
 		return reconcile.Result{}, nil
 	}
 
@@ -127,20 +247,57 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
-		log.Printf("Unable to retrieve Network.operator.openshift.io object: %v", err)
+		klog.ErrorS(err, "Unable to retrieve Network.operator.openshift.io object", "reconcileID", reconcileID)
 		// FIXME: operator status?
 		return reconcile.Result{}, err
 	}
 
 	if operConfig.Spec.ManagementState == operv1.Unmanaged {
-		log.Printf("Operator configuration state is %s - skipping operconfig reconciliation", operConfig.Spec.ManagementState)
+		klog.InfoS("Skipping operconfig reconciliation", "managementState", operConfig.Spec.ManagementState, "reconcileID", reconcileID)
+		outcome = "skipped"
+		return reconcile.Result{}, nil
+	}
+
+	if operConfig.Spec.ManagementState == operv1.Removed {
+		klog.InfoS("Tearing down operands", "managementState", operConfig.Spec.ManagementState, "reconcileID", reconcileID)
+		return r.reconcileRemoved(ctx, operConfig)
+	}
+
+	// Configure the per-source Degraded debounce windows, if requested.
+	gracePeriods, err := statusmanager.ParseGracePeriods(operConfig.GetAnnotations()[names.DegradedGracePeriodAnnotation])
+	if err != nil {
+		klog.ErrorS(err, "Ignoring invalid degraded-grace-periods annotation", "reconcileID", reconcileID)
+	}
+	r.status.SetDegradedGracePeriods(gracePeriods)
+
+	// Serve any pending annotation-triggered debug dump request.
+	r.handleDebugDump(ctx, operConfig)
+
+	// Detect and record feature gate changes; the render below picks them up.
+	r.observeFeatureGates(ctx)
+
+	// Likewise for platform address changes (API/ingress VIPs).
+	r.observeInfrastructure(ctx)
+
+	// An explicit rollback request takes precedence over normal reconciliation.
+	if rolledBack, err := r.maybeRollback(ctx, operConfig); err != nil {
+		klog.ErrorS(err, "Failed to roll back operator configuration", "reconcileID", reconcileID)
+		r.status.SetDegraded(statusmanager.OperatorConfig, "RollbackError",
+			fmt.Sprintf("Failed to roll back operator configuration: %v", err))
+		return reconcile.Result{}, err
+	} else if rolledBack {
+		// The config update re-triggers reconciliation with the restored spec.
+		outcome = "success"
 		return reconcile.Result{}, nil
 	}
 
 	// Merge in the cluster configuration, in case the administrator has updated some "downstream" fields
 	// This will also commit the change back to the apiserver.
-	if err := r.MergeClusterConfig(ctx, operConfig); err != nil {
-		log.Printf("Failed to merge the cluster configuration: %v", err)
+	mergeDone := timePhase(reconcileID, phaseMergeClusterConfig)
+	err = r.MergeClusterConfig(ctx, operConfig)
+	mergeDone()
+	if err != nil {
+		klog.ErrorS(err, "Failed to merge the cluster configuration", "reconcileID", reconcileID)
 		r.status.SetDegraded(statusmanager.OperatorConfig, "MergeClusterConfig",
 			fmt.Sprintf("Internal error while merging cluster configuration and operator configuration: %v", err))
 		return reconcile.Result{}, err
@@ -150,8 +307,12 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 	network.DeprecatedCanonicalize(&operConfig.Spec)
 
 	// Validate the configuration
-	if err := network.Validate(&operConfig.Spec); err != nil {
-		log.Printf("Failed to validate Network.operator.openshift.io.Spec: %v", err)
+	validateDone := timePhase(reconcileID, phaseValidate)
+	err = network.Validate(&operConfig.Spec)
+	validateDone()
+	if err != nil {
+		klog.ErrorS(err, "Failed to validate Network.operator.openshift.io.Spec", "reconcileID", reconcileID)
+		r.eventf(operConfig, corev1.EventTypeWarning, "InvalidOperatorConfig", "The operator configuration is invalid: %v", err)
 		r.status.SetDegraded(statusmanager.OperatorConfig, "InvalidOperatorConfig",
 			fmt.Sprintf("The operator configuration is invalid (%v). Use 'oc edit network.operator.openshift.io cluster' to fix.", err))
 		return reconcile.Result{}, err
@@ -160,10 +321,18 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 	// Retrieve the previously applied operator configuration
 	prev, err := GetAppliedConfiguration(ctx, r.client, operConfig.ObjectMeta.Name)
 	if err != nil {
-		log.Printf("Failed to retrieve previously applied configuration: %v", err)
+		klog.ErrorS(err, "Failed to retrieve previously applied configuration", "reconcileID", reconcileID)
 		// FIXME: operator status?
 		return reconcile.Result{}, err
 	}
+	// Retrieve the objects rendered by the previous configuration, so that
+	// objects no longer rendered can be garbage-collected after apply.
+	prevRendered, err := GetAppliedRenderedObjects(ctx, r.client, operConfig.ObjectMeta.Name)
+	if err != nil {
+		klog.ErrorS(err, "Failed to retrieve previously rendered objects", "reconcileID", reconcileID)
+		return reconcile.Result{}, err
+	}
+
 	// up-convert Prev by filling defaults
 	if prev != nil {
 		network.FillDefaults(prev, prev)
@@ -179,19 +348,40 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 		// upconversion scheme -- if we add additional fields to the config.
 		err = network.IsChangeSafe(prev, &operConfig.Spec)
 		if err != nil {
-			log.Printf("Not applying unsafe change: %v", err)
-			r.status.SetDegraded(statusmanager.OperatorConfig, "InvalidOperatorConfig",
-				fmt.Sprintf("Not applying unsafe configuration change: %v. Use 'oc edit network.operator.openshift.io cluster' to undo the change.", err))
-			return reconcile.Result{}, err
+			hash := unsafeChangeHash(&operConfig.Spec)
+			if unsafeChangeAcknowledged(operConfig, hash) {
+				klog.InfoS("Applying acknowledged unsafe change", "hash", hash, "reconcileID", reconcileID)
+				r.eventf(operConfig, corev1.EventTypeWarning, "UnsafeChangeAcknowledged",
+					"Applying unsafe configuration change acknowledged by annotation (hash %s): %v", hash, err)
+				r.status.SetUnsafeChangeAcknowledged(hash)
+			} else {
+				klog.ErrorS(err, "Not applying unsafe change", "reconcileID", reconcileID)
+				r.eventf(operConfig, corev1.EventTypeWarning, "UnsafeChangeRejected", "Not applying unsafe configuration change: %v", err)
+				r.status.SetDegraded(statusmanager.OperatorConfig, "InvalidOperatorConfig",
+					fmt.Sprintf("Not applying unsafe configuration change: %v. Use 'oc edit network.operator.openshift.io cluster' to undo the change, or annotate the object with %s=%s to accept the disruption.", err, names.UnsafeChangeAcknowledgeAnnotation, hash))
+				return reconcile.Result{}, err
+			}
 		}
 	}
 
+	// A requested default network migration must pass (or have its findings
+	// acknowledged) pre-flight validation before any migration rendering.
+	if err := r.preflightMigration(ctx, operConfig); err != nil {
+		klog.ErrorS(err, "Migration pre-flight validation failed", "reconcileID", reconcileID)
+		r.eventf(operConfig, corev1.EventTypeWarning, "MigrationPreflightBlocked", "%v", err)
+		r.status.SetDegraded(statusmanager.OperatorConfig, "MigrationPreflightBlocked", err.Error())
+		return reconcile.Result{}, err
+	}
+
 	newOperConfig := operConfig.DeepCopy()
 
 	// Bootstrap any resources
+	bootstrapDone := timePhase(reconcileID, phaseBootstrap)
 	bootstrapResult, err := network.Bootstrap(newOperConfig, r.client)
+	bootstrapDone()
 	if err != nil {
-		log.Printf("Failed to reconcile platform networking resources: %v", err)
+		klog.ErrorS(err, "Failed to reconcile platform networking resources", "reconcileID", reconcileID)
+		r.eventf(operConfig, corev1.EventTypeWarning, "BootstrapError", "Failed to reconcile platform networking resources: %v", err)
 		r.status.SetDegraded(statusmanager.OperatorConfig, "BootstrapError",
 			fmt.Sprintf("Internal error while reconciling platform networking resources: %v", err))
 		return reconcile.Result{}, err
@@ -199,7 +389,7 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 
 	if !reflect.DeepEqual(operConfig, newOperConfig) {
 		if err := r.UpdateOperConfig(newOperConfig); err != nil {
-			log.Printf("Failed to update the operator configuration: %v", err)
+			klog.ErrorS(err, "Failed to update the operator configuration", "reconcileID", reconcileID)
 			r.status.SetDegraded(statusmanager.OperatorConfig, "UpdateOperatorConfig",
 				fmt.Sprintf("Internal error while updating operator configuration: %v", err))
 			return reconcile.Result{}, err
@@ -207,18 +397,63 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 	}
 
 	// Generate the objects
+	renderDone := timePhase(reconcileID, phaseRender)
 	objs, err := network.Render(&operConfig.Spec, bootstrapResult, ManifestPath)
+	renderDone()
 	if err != nil {
-		log.Printf("Failed to render: %v", err)
+		klog.ErrorS(err, "Failed to render", "reconcileID", reconcileID)
+		r.eventf(operConfig, corev1.EventTypeWarning, "RenderError", "Failed to render operator configuration: %v", err)
 		r.status.SetDegraded(statusmanager.OperatorConfig, "RenderError",
 			fmt.Sprintf("Internal error while rendering operator configuration: %v", err))
 		return reconcile.Result{}, err
 	}
 
+	// Apply any operand resource overrides before the objects are recorded or
+	// applied, so the dry-run output and the applied configuration both
+	// reflect the tuned values.
+	overrides, err := r.getResourceOverrides(ctx)
+	if err == nil {
+		err = applyResourceOverrides(objs, overrides)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to apply resource overrides", "reconcileID", reconcileID)
+		r.status.SetDegraded(statusmanager.OperatorConfig, "InvalidResourceOverrides",
+			fmt.Sprintf("Error while applying operand resource overrides: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	// While the cluster is hibernated, hold the rendered control-plane
+	// Deployments at zero replicas instead of fighting whatever suspended the
+	// cluster.
+	if hibernationRequested(operConfig) {
+		klog.InfoS("Hibernation annotation set, scaling rendered Deployments to zero", "reconcileID", reconcileID)
+		if err := hibernateWorkloads(objs); err != nil {
+			klog.ErrorS(err, "Failed to hibernate workloads", "reconcileID", reconcileID)
+			r.status.SetDegraded(statusmanager.OperatorConfig, "RenderError",
+				fmt.Sprintf("Internal error while hibernating rendered workloads: %v", err))
+			return reconcile.Result{}, err
+		}
+	}
+
+	// If a dry-run render was requested, record what would have been applied
+	// and stop before touching any operand.
+	if _, ok := operConfig.GetAnnotations()[names.DryRunAnnotation]; ok {
+		klog.InfoS("Dry-run annotation set, writing rendered manifests without applying",
+			"objects", len(objs), "namespace", names.APPLIED_NAMESPACE, "configmap", names.DRY_RUN_CONFIGMAP, "reconcileID", reconcileID)
+		if err := r.writeDryRunManifests(ctx, objs); err != nil {
+			klog.ErrorS(err, "Failed to write dry-run manifests", "reconcileID", reconcileID)
+			r.status.SetDegraded(statusmanager.OperatorConfig, "RenderError",
+				fmt.Sprintf("Internal error while writing dry-run manifests: %v", err))
+			return reconcile.Result{}, err
+		}
+		outcome = "success"
+		return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
+	}
+
 	// The first object we create should be the record of our applied configuration. The last object we create is config.openshift.io/v1/Network.Status
-	app, err := AppliedConfiguration(operConfig)
+	app, err := AppliedConfiguration(operConfig, objs)
 	if err != nil {
-		log.Printf("Failed to render applied: %v", err)
+		klog.ErrorS(err, "Failed to render applied", "reconcileID", reconcileID)
 		r.status.SetDegraded(statusmanager.OperatorConfig, "RenderError",
 			fmt.Sprintf("Internal error while recording new operator configuration: %v", err))
 		return reconcile.Result{}, err
@@ -228,8 +463,12 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 	// Set up the Pod reconciler before we start creating DaemonSets/Deployments
 	daemonSets := []types.NamespacedName{}
 	deployments := []types.NamespacedName{}
+	machineConfigs := []string{}
 	relatedObjects := []configv1.ObjectReference{}
 	for _, obj := range objs {
+		if obj.GetAPIVersion() == "machineconfiguration.openshift.io/v1" && obj.GetKind() == "MachineConfig" {
+			machineConfigs = append(machineConfigs, obj.GetName())
+		}
 		if obj.GetAPIVersion() == "apps/v1" && obj.GetKind() == "DaemonSet" {
 			daemonSets = append(daemonSets, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
 		} else if obj.GetAPIVersion() == "apps/v1" && obj.GetKind() == "Deployment" {
@@ -237,7 +476,7 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 		}
 		restMapping, err := r.mapper.RESTMapping(obj.GroupVersionKind().GroupKind())
 		if err != nil {
-			log.Printf("Failed to get REST mapping for storing related object: %v", err)
+			klog.ErrorS(err, "Failed to get REST mapping for storing related object", "reconcileID", reconcileID)
 			continue
 		}
 		relatedObjects = append(relatedObjects, configv1.ObjectReference{
@@ -278,6 +517,7 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 
 	r.status.SetDaemonSets(daemonSets)
 	r.status.SetDeployments(deployments)
+	r.status.SetMachineConfigs(machineConfigs)
 	r.status.SetRelatedObjects(relatedObjects)
 
 	allResources := []types.NamespacedName{}
@@ -285,43 +525,106 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 	allResources = append(allResources, deployments...)
 	r.podReconciler.SetResources(allResources)
 
-	// Apply the objects to the cluster
+	// Mark the objects to be GC'd if the owner is deleted.
 	for _, obj := range objs {
-		// Mark the object to be GC'd if the owner is deleted.
 		if err := controllerutil.SetControllerReference(operConfig, obj, r.scheme); err != nil {
 			err = errors.Wrapf(err, "could not set reference for (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
-			log.Println(err)
+			klog.ErrorS(err, "Failed to set controller reference", "reconcileID", reconcileID)
 			r.status.SetDegraded(statusmanager.OperatorConfig, "InternalError",
 				fmt.Sprintf("Internal error while updating operator configuration: %v", err))
 			return reconcile.Result{}, err
 		}
+	}
 
-		// Open question: should an error here indicate we will never retry?
-		if err := apply.ApplyObject(ctx, r.client, obj); err != nil {
-			err = errors.Wrapf(err, "could not apply (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
-			log.Println(err)
-
-			// Ignore errors if we've asked to do so.
-			anno := obj.GetAnnotations()
-			if anno != nil {
-				if _, ok := anno[names.IgnoreObjectErrorAnnotation]; ok {
-					log.Println("Object has ignore-errors annotation set, continuing")
-					continue
-				}
-			}
-			r.status.SetDegraded(statusmanager.OperatorConfig, "ApplyOperatorConfig",
-				fmt.Sprintf("Error while updating operator configuration: %v", err))
+	// If a canary rollout is requested, put ovnkube-node pod replacement
+	// under the operator's control before the updated DaemonSet is applied.
+	canarySel, canaryEnabled := canarySelector(operConfig.GetAnnotations())
+	if canaryEnabled {
+		canaryEnabled = prepareCanaryRollout(objs)
+	}
+
+	// Apply the objects to the cluster. Collect all per-object failures
+	// rather than stopping at the first, so that every broken operand is
+	// surfaced in the Degraded condition at once.
+	applyObjs := objs
+	if filter := r.targetedApplyFilter(operConfig); filter != nil {
+		applyObjs = filterObjects(objs, filter)
+		klog.InfoS("Applying only objects dependent on the changed inputs",
+			"objects", len(applyObjs), "rendered", len(objs), "reconcileID", reconcileID)
+	}
+	applyDone := timePhase(reconcileID, phaseApply)
+	applyErrs := r.applyObjects(ctx, applyObjs, pausedOperands(operConfig))
+	applyDone()
+	if len(applyErrs) > 0 {
+		err := utilerrors.NewAggregate(applyErrs)
+		r.eventf(operConfig, corev1.EventTypeWarning, "ApplyOperatorConfig", "Error while applying rendered objects: %v", err)
+		r.status.SetDegraded(statusmanager.OperatorConfig, "ApplyOperatorConfig",
+			fmt.Sprintf("Error while updating operator configuration: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	// Record this configuration in the rollback history.
+	if err := r.recordAppliedHistory(ctx, operConfig); err != nil {
+		klog.ErrorS(err, "Failed to record applied-configuration history", "reconcileID", reconcileID)
+	}
+
+	// Delete objects that are no longer rendered by this configuration.
+	r.cleanupOrphanedObjects(ctx, operConfig, prevRendered, objs)
+
+	// Surface any fields the apply path skipped because of the
+	// preserve-fields annotation.
+	r.status.SetPreservedFields(apply.PreservedFieldsSummary())
+
+	// Roll ovnkube-node pods canary-first, then in bounded batches. A canary
+	// failure degrades the operator without touching the rest of the fleet.
+	if canaryEnabled {
+		if err := r.runCanaryRollout(ctx, canarySel); err != nil {
+			klog.ErrorS(err, "Canary rollout failed", "reconcileID", reconcileID)
+			r.eventf(operConfig, corev1.EventTypeWarning, "CanaryRollout", "Canary rollout failed: %v", err)
+			r.status.SetDegraded(statusmanager.PodDeployment, "CanaryRolloutFailed",
+				fmt.Sprintf("Canary rollout of ovnkube-node failed: %v", err))
 			return reconcile.Result{}, err
 		}
 	}
 
+	// Retain the rendered objects for the drift detector, and the
+	// configuration they were applied for so later reconciles can narrow
+	// their apply step.
+	r.setRenderedObjects(objs)
+	r.recordAppliedState(operConfig)
+
 	// Run a pod status check just to clear any initial inconsitencies at startup of the CNO
 	r.status.SetFromPods()
 
+	// On HyperShift hosted clusters, break operand health down per NodePool.
+	r.status.SetFromNodePools()
+
+	// Hold Progressing while MachineConfigPools affected by rendered
+	// MachineConfigs are still rolling out.
+	r.status.SetFromMachineConfigPools()
+
+	// If requested, verify that critical operands actually report Ready
+	// before declaring this reconcile a success.
+	if val, ok := operConfig.GetAnnotations()[names.VerifyRolloutAnnotation]; ok {
+		timeout := defaultVerifyTimeout
+		if d, err := time.ParseDuration(val); err == nil && d > 0 {
+			timeout = d
+		}
+		if err := r.verifyCriticalOperands(ctx, objs, timeout); err != nil {
+			klog.ErrorS(err, "Post-apply verification failed", "reconcileID", reconcileID)
+			r.eventf(operConfig, corev1.EventTypeWarning, "RolloutVerification",
+				"Post-apply verification failed: %v", err)
+			// Leave Progressing as-is and retry sooner than the full resync.
+			return reconcile.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
+
 	// Update Network.config.openshift.io.Status
+	statusDone := timePhase(reconcileID, phaseStatusUpdate)
+	defer statusDone()
 	status, err := r.ClusterNetworkStatus(context.TODO(), operConfig)
 	if err != nil {
-		log.Printf("Could not generate network status: %v", err)
+		klog.ErrorS(err, "Could not generate network status", "reconcileID", reconcileID)
 		r.status.SetDegraded(statusmanager.OperatorConfig, "StatusError",
 			fmt.Sprintf("Could not update cluster configuration status: %v", err))
 		return reconcile.Result{}, err
@@ -331,7 +634,7 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 		// the status of our owner.
 		if err := apply.ApplyObject(context.TODO(), r.client, status); err != nil {
 			err = errors.Wrapf(err, "could not apply (%s) %s/%s", status.GroupVersionKind(), status.GetNamespace(), status.GetName())
-			log.Println(err)
+			klog.ErrorS(err, "Failed to apply network status", "reconcileID", reconcileID)
 			r.status.SetDegraded(statusmanager.OperatorConfig, "StatusError",
 				fmt.Sprintf("Could not update cluster configuration status: %v", err))
 			return reconcile.Result{}, err
@@ -340,7 +643,25 @@ func (r *ReconcileOperConfig) Reconcile(ctx context.Context, request reconcile.R
 
 	r.status.SetNotDegraded(statusmanager.OperatorConfig)
 
+	// Any platform address change observed at the start of this reconcile
+	// has now been re-rendered and applied.
+	if r.infraChanged {
+		r.status.SetPlatformAddressesPropagating(nil)
+		r.infraChanged = false
+	}
+
 	// All was successful. Request that this be re-triggered after ResyncPeriod,
 	// so we can reconcile state again.
+	outcome = "success"
 	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
 }
+
+// eventf emits a Kubernetes Event on the given object, so that users can
+// learn why a change was rejected without scraping pod logs. It is a no-op
+// if no recorder was configured (e.g. in unit tests).
+func (r *ReconcileOperConfig) eventf(obj runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Eventf(obj, eventtype, reason, messageFmt, args...)
+}
@@ -44,6 +44,7 @@ func (r *ReconcilePods) Reconcile(ctx context.Context, request reconcile.Request
 
 	log.Printf("Reconciling update to %s/%s\n", request.Namespace, request.Name)
 	r.status.SetFromPods()
+	r.status.SetFromNodePools()
 
 	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
 }
@@ -3,6 +3,8 @@ package operconfig
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
 
 	operv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/cluster-network-operator/pkg/names"
@@ -16,9 +18,51 @@ import (
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// GetAppliedConfiguration retrieves the configuration we applied.
-// Returns nil with no error if no previous configuration was observed.
-func GetAppliedConfiguration(ctx context.Context, client k8sclient.Client, name string) (*operv1.NetworkSpec, error) {
+// appliedConfigSchemaVersion is the schema version this operator writes.
+// Bump it when the record format changes, and add a conversion case to
+// convertAppliedConfig for the old version.
+//
+// Version history:
+//
+//	1: raw NetworkSpec JSON under "applied", rendered-object refs under
+//	   "rendered" (no schema-version key).
+//	2: typed appliedConfigRecord under "record"; the v1 keys are still
+//	   written so an older operator can parse the record after a downgrade.
+const appliedConfigSchemaVersion = 2
+
+// appliedConfigRecord is the typed, versioned form of the configuration we
+// applied. All parsing of the applied-configuration ConfigMap goes through
+// this type so that adding fields (or changing the layout) is an explicit,
+// converted schema change rather than a silent unmarshalling difference
+// between operator versions.
+type appliedConfigRecord struct {
+	SchemaVersion int `json:"schemaVersion"`
+
+	// Spec is the NetworkSpec that was applied.
+	Spec *operv1.NetworkSpec `json:"spec"`
+
+	// Rendered is the set of objects the applied configuration rendered,
+	// used to garbage-collect objects that are no longer rendered.
+	Rendered []renderedObjectRef `json:"rendered,omitempty"`
+}
+
+// renderedObjectRef identifies one rendered object in the applied-configuration
+// record, so that a later reconcile can detect objects that are no longer
+// rendered and garbage-collect them.
+type renderedObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+
+	// Prune records that the object carried the PruneOnRemovalAnnotation when
+	// rendered, so it must be deleted once no longer rendered.
+	Prune bool `json:"prune,omitempty"`
+}
+
+// getAppliedConfigRecord retrieves and up-converts the applied-configuration
+// record. Returns nil with no error if no previous configuration was observed.
+func getAppliedConfigRecord(ctx context.Context, client k8sclient.Client, name string) (*appliedConfigRecord, error) {
 	cm := &corev1.ConfigMap{}
 	err := client.Get(ctx, types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.APPLIED_PREFIX + name}, cm)
 	if err != nil && apierrors.IsNotFound(err) {
@@ -26,22 +70,121 @@ func GetAppliedConfiguration(ctx context.Context, client k8sclient.Client, name
 	} else if err != nil {
 		return nil, err
 	}
+	return convertAppliedConfig(cm)
+}
 
-	spec := &operv1.NetworkSpec{}
-	err = json.Unmarshal([]byte(cm.Data["applied"]), spec)
-	if err != nil {
+// convertAppliedConfig parses the applied-configuration ConfigMap at whatever
+// schema version it was written and converts it to the current one.
+func convertAppliedConfig(cm *corev1.ConfigMap) (*appliedConfigRecord, error) {
+	version := 1
+	if raw, ok := cm.Data["schema-version"]; ok {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("applied configuration %s has invalid schema-version %q", cm.Name, raw)
+		}
+		version = parsed
+	}
+
+	switch {
+	case version == 1:
+		return parseAppliedConfigV1(cm)
+	case version == appliedConfigSchemaVersion:
+		return parseAppliedConfigV2(cm)
+	case version > appliedConfigSchemaVersion:
+		// Written by a newer operator (we were downgraded). Newer versions
+		// guarantee the v1 keys stay parseable, so down-convert from those.
+		return parseAppliedConfigV1(cm)
+	default:
+		return nil, fmt.Errorf("applied configuration %s has unknown schema version %d", cm.Name, version)
+	}
+}
+
+// parseAppliedConfigV1 parses the original layout: spec under "applied",
+// rendered-object refs (if present) under "rendered".
+func parseAppliedConfigV1(cm *corev1.ConfigMap) (*appliedConfigRecord, error) {
+	record := &appliedConfigRecord{
+		SchemaVersion: appliedConfigSchemaVersion,
+		Spec:          &operv1.NetworkSpec{},
+	}
+	if err := json.Unmarshal([]byte(cm.Data["applied"]), record.Spec); err != nil {
+		return nil, err
+	}
+	if raw, ok := cm.Data["rendered"]; ok {
+		if err := json.Unmarshal([]byte(raw), &record.Rendered); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// parseAppliedConfigV2 parses the typed record under "record".
+func parseAppliedConfigV2(cm *corev1.ConfigMap) (*appliedConfigRecord, error) {
+	record := &appliedConfigRecord{}
+	if err := json.Unmarshal([]byte(cm.Data["record"]), record); err != nil {
+		return nil, err
+	}
+	if record.Spec == nil {
+		return nil, fmt.Errorf("applied configuration %s has no spec in its record", cm.Name)
+	}
+	return record, nil
+}
+
+// GetAppliedConfiguration retrieves the configuration we applied.
+// Returns nil with no error if no previous configuration was observed.
+func GetAppliedConfiguration(ctx context.Context, client k8sclient.Client, name string) (*operv1.NetworkSpec, error) {
+	record, err := getAppliedConfigRecord(ctx, client, name)
+	if err != nil || record == nil {
 		return nil, err
 	}
-	return spec, nil
+	return record.Spec, nil
+}
+
+// GetAppliedRenderedObjects retrieves the list of objects rendered by the
+// previously applied configuration. Returns nil with no error if no record
+// exists (e.g. on upgrade from a version that did not track it).
+func GetAppliedRenderedObjects(ctx context.Context, client k8sclient.Client, name string) ([]renderedObjectRef, error) {
+	record, err := getAppliedConfigRecord(ctx, client, name)
+	if err != nil || record == nil {
+		return nil, err
+	}
+	return record.Rendered, nil
 }
 
 // AppliedConfiguration renders the ConfigMap in which we store the configuration
-// we've applied.
-func AppliedConfiguration(applied *operv1.Network) (*uns.Unstructured, error) {
+// we've applied, along with the set of objects it rendered.
+func AppliedConfiguration(applied *operv1.Network, objs []*uns.Unstructured) (*uns.Unstructured, error) {
+	refs := make([]renderedObjectRef, 0, len(objs))
+	for _, obj := range objs {
+		_, prune := obj.GetAnnotations()[names.PruneOnRemovalAnnotation]
+		refs = append(refs, renderedObjectRef{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			Prune:      prune,
+		})
+	}
+	record := appliedConfigRecord{
+		SchemaVersion: appliedConfigSchemaVersion,
+		Spec:          &applied.Spec,
+		Rendered:      refs,
+	}
+	rawRecord, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// Also write the v1 keys so an older operator can still parse the record
+	// after a downgrade.
 	app, err := json.Marshal(applied.Spec)
 	if err != nil {
 		return nil, err
 	}
+	rendered, err := json.Marshal(refs)
+	if err != nil {
+		return nil, err
+	}
+
 	cm := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -52,7 +195,10 @@ func AppliedConfiguration(applied *operv1.Network) (*uns.Unstructured, error) {
 			Name:      names.APPLIED_PREFIX + applied.Name,
 		},
 		Data: map[string]string{
-			"applied": string(app),
+			"schema-version": strconv.Itoa(appliedConfigSchemaVersion),
+			"record":         string(rawRecord),
+			"applied":        string(app),
+			"rendered":       string(rendered),
 		},
 	}
 
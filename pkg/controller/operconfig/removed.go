@@ -0,0 +1,110 @@
+package operconfig
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// teardownPhases orders the teardown of rendered operands when
+// ManagementState is Removed. Webhook configurations go first so no admission
+// path points at a backend we are about to remove, then the workloads
+// themselves, then whatever else was rendered.
+var teardownPhases = []func(obj *uns.Unstructured) bool{
+	func(obj *uns.Unstructured) bool {
+		return obj.GetKind() == "ValidatingWebhookConfiguration" || obj.GetKind() == "MutatingWebhookConfiguration"
+	},
+	func(obj *uns.Unstructured) bool { return obj.GetKind() == "Deployment" },
+	func(obj *uns.Unstructured) bool { return obj.GetKind() == "DaemonSet" },
+	func(obj *uns.Unstructured) bool { return true },
+}
+
+// preservedOnRemoval reports whether an object survives operand teardown.
+// CRDs stay so user-created CRs (NetworkAttachmentDefinitions, EgressFirewalls, ...)
+// are not garbage collected, and namespaces stay so user data in them survives
+// a transition back to Managed.
+func preservedOnRemoval(obj *uns.Unstructured) bool {
+	switch obj.GetKind() {
+	case "CustomResourceDefinition", "Namespace":
+		return true
+	}
+	return false
+}
+
+// reconcileRemoved tears down the operands rendered from the previously
+// applied configuration, preserving CRDs and user data, and reflects the
+// teardown progress in the status conditions.
+func (r *ReconcileOperConfig) reconcileRemoved(ctx context.Context, operConfig *operv1.Network) (reconcile.Result, error) {
+	prev, err := GetAppliedConfiguration(ctx, r.client, operConfig.ObjectMeta.Name)
+	if err != nil {
+		log.Printf("Failed to retrieve previously applied configuration: %v", err)
+		return reconcile.Result{}, err
+	}
+	if prev == nil {
+		// Nothing was ever applied; nothing to tear down.
+		r.status.SetNotDegraded(statusmanager.OperatorConfig)
+		return reconcile.Result{}, nil
+	}
+	network.FillDefaults(prev, prev)
+
+	prevConfig := &operv1.Network{ObjectMeta: operConfig.ObjectMeta}
+	prevConfig.Spec = *prev
+	bootstrapResult, err := network.Bootstrap(prevConfig, r.client)
+	if err != nil {
+		log.Printf("Failed to reconcile platform networking resources: %v", err)
+		r.status.SetDegraded(statusmanager.OperatorConfig, "BootstrapError",
+			fmt.Sprintf("Internal error while tearing down operands: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	objs, err := network.Render(prev, bootstrapResult, ManifestPath)
+	if err != nil {
+		log.Printf("Failed to render previously applied configuration: %v", err)
+		r.status.SetDegraded(statusmanager.OperatorConfig, "RenderError",
+			fmt.Sprintf("Internal error while tearing down operands: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted := 0
+	remaining := 0
+	torndown := map[*uns.Unstructured]bool{}
+	for _, phase := range teardownPhases {
+		for _, obj := range objs {
+			if torndown[obj] || !phase(obj) {
+				continue
+			}
+			torndown[obj] = true
+			if preservedOnRemoval(obj) {
+				continue
+			}
+			err := r.client.Delete(ctx, obj, client.PropagationPolicy("Background"))
+			if apierrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				log.Printf("Failed to delete (%s) %s/%s: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+				remaining++
+				continue
+			}
+			deleted++
+		}
+	}
+
+	if remaining > 0 {
+		r.status.SetDegraded(statusmanager.OperatorConfig, "OperandTeardown",
+			fmt.Sprintf("Failed to remove %d operand objects; will retry", remaining))
+		return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
+	}
+
+	log.Printf("ManagementState is %s - removed %d operand objects", operConfig.Spec.ManagementState, deleted)
+	r.status.SetNotDegraded(statusmanager.OperatorConfig)
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,286 @@
+package operconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/openshift/cluster-network-operator/pkg/network"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+//nolint:errcheck
+func init() {
+	configv1.AddToScheme(scheme.Scheme)
+	operv1.AddToScheme(scheme.Scheme)
+}
+
+// fakeRESTMapper is a minimal RESTMapper stub for the StatusManager.
+type fakeRESTMapper struct{}
+
+func (f *fakeRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return schema.GroupVersionKind{Group: "test", Version: "test", Kind: "test"}, nil
+}
+
+func (f *fakeRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return nil, nil
+}
+
+func (f *fakeRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return schema.GroupVersionResource{}, nil
+}
+
+func (f *fakeRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return nil, nil
+}
+
+func (f *fakeRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return nil, nil
+}
+
+func (f *fakeRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	return nil, nil
+}
+
+func (f *fakeRESTMapper) ResourceSingularizer(resource string) (singular string, err error) {
+	return "", nil
+}
+
+// deletion records one Delete call seen by the recording client.
+type deletion struct {
+	kind string
+	name string
+}
+
+// recordingClient delegates reads to the wrapped client but intercepts
+// Delete, recording the order of deletions and optionally failing them for
+// selected kinds.
+type recordingClient struct {
+	client.Client
+	deleted   []deletion
+	failKinds map[string]bool
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	c.deleted = append(c.deleted, deletion{kind: kind, name: obj.GetName()})
+	if c.failKinds[kind] {
+		return errors.New("injected deletion failure")
+	}
+	return nil
+}
+
+// teardownPhaseIndex mirrors the ordering encoded in teardownPhases, so the
+// tests can assert that deletions never go backwards through the phases.
+func teardownPhaseIndex(kind string) int {
+	switch kind {
+	case "ValidatingWebhookConfiguration", "MutatingWebhookConfiguration":
+		return 0
+	case "Deployment":
+		return 1
+	case "DaemonSet":
+		return 2
+	}
+	return 3
+}
+
+// removedTestSpec is a minimal openshift-sdn configuration, matching what a
+// managed cluster would have recorded as its applied configuration.
+func removedTestSpec() *operv1.NetworkSpec {
+	return &operv1.NetworkSpec{
+		ServiceNetwork: []string{"172.30.0.0/16"},
+		ClusterNetwork: []operv1.ClusterNetworkEntry{
+			{CIDR: "10.128.0.0/15", HostPrefix: 23},
+		},
+		DefaultNetwork: operv1.DefaultNetworkDefinition{
+			Type: operv1.NetworkTypeOpenShiftSDN,
+		},
+	}
+}
+
+// newRemovedReconciler builds a ReconcileOperConfig whose client has the
+// applied-configuration record and the cluster objects Bootstrap needs, with
+// all deletions going through the returned recordingClient.
+func newRemovedReconciler(t *testing.T) (*ReconcileOperConfig, *recordingClient) {
+	t.Helper()
+
+	record := appliedConfigRecord{
+		SchemaVersion: appliedConfigSchemaVersion,
+		Spec:          removedTestSpec(),
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Failed to marshal applied-configuration record: %v", err)
+	}
+	appliedCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.APPLIED_PREFIX + names.OPERATOR_CONFIG,
+		},
+		Data: map[string]string{
+			"schema-version": strconv.Itoa(appliedConfigSchemaVersion),
+			"record":         string(raw),
+		},
+	}
+	infra := &configv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+	}
+	operConfig := removedOperConfig()
+
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(appliedCM, infra, operConfig).Build()
+	recorder := &recordingClient{Client: fakeClient, failKinds: map[string]bool{}}
+	return &ReconcileOperConfig{
+		client: recorder,
+		status: statusmanager.New(fakeClient, &fakeRESTMapper{}, "testing"),
+	}, recorder
+}
+
+func setTestManifestPath(t *testing.T) {
+	t.Helper()
+	prev := ManifestPath
+	ManifestPath = "../../../bindata"
+	t.Cleanup(func() { ManifestPath = prev })
+}
+
+func removedOperConfig() *operv1.Network {
+	return &operv1.Network{
+		ObjectMeta: metav1.ObjectMeta{Name: names.OPERATOR_CONFIG},
+		Spec: operv1.NetworkSpec{
+			OperatorSpec: operv1.OperatorSpec{ManagementState: operv1.Removed},
+		},
+	}
+}
+
+func TestReconcileRemovedTeardownOrder(t *testing.T) {
+	setTestManifestPath(t)
+	r, recorder := newRemovedReconciler(t)
+
+	result, err := r.reconcileRemoved(context.TODO(), removedOperConfig())
+	if err != nil {
+		t.Fatalf("reconcileRemoved failed: %v", err)
+	}
+	if result.Requeue || result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue after successful teardown, got %+v", result)
+	}
+	if len(recorder.deleted) == 0 {
+		t.Fatal("Expected operand objects to be deleted")
+	}
+
+	lastPhase := 0
+	sawDaemonSet := false
+	for _, d := range recorder.deleted {
+		phase := teardownPhaseIndex(d.kind)
+		if phase < lastPhase {
+			t.Errorf("Deletion of (%s) %s out of phase order (phase %d after %d)", d.kind, d.name, phase, lastPhase)
+		}
+		lastPhase = phase
+		if d.kind == "DaemonSet" {
+			sawDaemonSet = true
+		}
+	}
+	if !sawDaemonSet {
+		t.Error("Expected the rendered DaemonSets to be deleted")
+	}
+}
+
+func TestReconcileRemovedPreservesCRDsAndNamespaces(t *testing.T) {
+	setTestManifestPath(t)
+	r, recorder := newRemovedReconciler(t)
+
+	// Confirm the rendered configuration actually contains objects of the
+	// preserved kinds, so the assertions below cannot pass vacuously.
+	spec := removedTestSpec()
+	network.FillDefaults(spec, spec)
+	prevConfig := &operv1.Network{ObjectMeta: metav1.ObjectMeta{Name: names.OPERATOR_CONFIG}}
+	prevConfig.Spec = *spec
+	bootstrapResult, err := network.Bootstrap(prevConfig, r.client)
+	if err != nil {
+		t.Fatalf("Bootstrap failed: %v", err)
+	}
+	objs, err := network.Render(spec, bootstrapResult, ManifestPath)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	rendered := map[string]bool{}
+	for _, obj := range objs {
+		rendered[obj.GetKind()] = true
+	}
+	for _, kind := range []string{"CustomResourceDefinition", "Namespace"} {
+		if !rendered[kind] {
+			t.Fatalf("Expected the rendered configuration to contain a %s", kind)
+		}
+	}
+
+	if _, err := r.reconcileRemoved(context.TODO(), removedOperConfig()); err != nil {
+		t.Fatalf("reconcileRemoved failed: %v", err)
+	}
+	for _, d := range recorder.deleted {
+		if d.kind == "CustomResourceDefinition" || d.kind == "Namespace" {
+			t.Errorf("Deleted (%s) %s, which must be preserved on removal", d.kind, d.name)
+		}
+	}
+}
+
+func TestReconcileRemovedDeletionFailure(t *testing.T) {
+	setTestManifestPath(t)
+	r, recorder := newRemovedReconciler(t)
+	recorder.failKinds["DaemonSet"] = true
+
+	result, err := r.reconcileRemoved(context.TODO(), removedOperConfig())
+	if err != nil {
+		t.Fatalf("reconcileRemoved failed: %v", err)
+	}
+	if result.RequeueAfter != ResyncPeriod {
+		t.Errorf("Expected a requeue after %v while objects remain, got %+v", ResyncPeriod, result)
+	}
+
+	co := &configv1.ClusterOperator{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: "testing"}, co); err != nil {
+		t.Fatalf("Failed to fetch ClusterOperator: %v", err)
+	}
+	degraded := false
+	for _, cond := range co.Status.Conditions {
+		if cond.Type == configv1.OperatorDegraded && cond.Status == configv1.ConditionTrue {
+			degraded = true
+			if cond.Reason != "OperandTeardown" {
+				t.Errorf("Expected Degraded reason OperandTeardown, got %q", cond.Reason)
+			}
+		}
+	}
+	if !degraded {
+		t.Error("Expected the operator to report Degraded while teardown is incomplete")
+	}
+
+	// Once the remaining deletions succeed, the condition clears.
+	recorder.failKinds = map[string]bool{}
+	result, err = r.reconcileRemoved(context.TODO(), removedOperConfig())
+	if err != nil {
+		t.Fatalf("reconcileRemoved retry failed: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue after successful retry, got %+v", result)
+	}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: "testing"}, co); err != nil {
+		t.Fatalf("Failed to fetch ClusterOperator: %v", err)
+	}
+	for _, cond := range co.Status.Conditions {
+		if cond.Type == configv1.OperatorDegraded && cond.Status == configv1.ConditionTrue {
+			t.Errorf("Expected Degraded to clear after retry, still set: %+v", cond)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package operconfig
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// resourceOverride is one entry in the operand resource override ConfigMap:
+// it names a rendered Deployment or DaemonSet and the tuning to apply, so
+// large nodes, tiny SNO clusters and hosted control planes can tune operand
+// footprint and scheduling without unsupported patches. Container-level
+// requests/limits require "container"; replicas, tolerations and the
+// priority class apply to the workload as a whole.
+type resourceOverride struct {
+	Kind              string              `json:"kind"`
+	Namespace         string              `json:"namespace"`
+	Name              string              `json:"name"`
+	Container         string              `json:"container,omitempty"`
+	Requests          map[string]string   `json:"requests,omitempty"`
+	Limits            map[string]string   `json:"limits,omitempty"`
+	Replicas          *int32              `json:"replicas,omitempty"`
+	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	Tolerations       []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// validate checks the override is well-formed and all quantities parse.
+func (o *resourceOverride) validate() error {
+	if o.Kind != "Deployment" && o.Kind != "DaemonSet" {
+		return errors.Errorf("kind must be Deployment or DaemonSet, got %q", o.Kind)
+	}
+	if o.Name == "" || o.Namespace == "" {
+		return errors.Errorf("namespace and name are required")
+	}
+	if (len(o.Requests) > 0 || len(o.Limits) > 0) && o.Container == "" {
+		return errors.Errorf("container is required for requests/limits")
+	}
+	if o.Replicas != nil {
+		if o.Kind != "Deployment" {
+			return errors.Errorf("replicas can only be overridden on a Deployment")
+		}
+		if *o.Replicas < 0 {
+			return errors.Errorf("replicas must not be negative")
+		}
+	}
+	for _, quantities := range []map[string]string{o.Requests, o.Limits} {
+		for name, val := range quantities {
+			if name != "cpu" && name != "memory" {
+				return errors.Errorf("unsupported resource %q", name)
+			}
+			if _, err := resource.ParseQuantity(val); err != nil {
+				return errors.Wrapf(err, "invalid quantity %q for %s", val, name)
+			}
+		}
+	}
+	return nil
+}
+
+// getResourceOverrides reads and validates the operand resource override
+// ConfigMap. Returns nil with no error if it does not exist.
+func (r *ReconcileOperConfig) getResourceOverrides(ctx context.Context) ([]resourceOverride, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.RESOURCE_OVERRIDES_CONFIGMAP}, cm)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	overrides := []resourceOverride{}
+	if raw, ok := cm.Data["overrides"]; ok {
+		if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+			return nil, errors.Wrap(err, "failed to decode resource overrides")
+		}
+	}
+	for i := range overrides {
+		if err := overrides[i].validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid resource override for (%s) %s/%s", overrides[i].Kind, overrides[i].Namespace, overrides[i].Name)
+		}
+	}
+	return overrides, nil
+}
+
+// applyResourceOverrides rewrites the resource requests/limits of rendered
+// operand containers according to the override list.
+func applyResourceOverrides(objs []*uns.Unstructured, overrides []resourceOverride) error {
+	for _, override := range overrides {
+		applied := false
+		for _, obj := range objs {
+			if obj.GetKind() != override.Kind || obj.GetNamespace() != override.Namespace || obj.GetName() != override.Name {
+				continue
+			}
+			if override.Container != "" {
+				if err := overrideContainerResources(obj, override); err != nil {
+					return errors.Wrapf(err, "failed to apply resource override to (%s) %s/%s", override.Kind, override.Namespace, override.Name)
+				}
+			}
+			if err := overrideWorkloadSpec(obj, override); err != nil {
+				return errors.Wrapf(err, "failed to apply workload override to (%s) %s/%s", override.Kind, override.Namespace, override.Name)
+			}
+			applied = true
+		}
+		if !applied {
+			log.Printf("Resource override for (%s) %s/%s matches no rendered object, ignoring", override.Kind, override.Namespace, override.Name)
+		}
+	}
+	return nil
+}
+
+func overrideContainerResources(obj *uns.Unstructured, override resourceOverride) error {
+	containers, found, err := uns.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		return errors.Errorf("failed to read containers: %v", err)
+	}
+	for i := range containers {
+		container, ok := containers[i].(map[string]interface{})
+		if !ok || container["name"] != override.Container {
+			continue
+		}
+		for field, quantities := range map[string]map[string]string{"requests": override.Requests, "limits": override.Limits} {
+			if len(quantities) == 0 {
+				continue
+			}
+			values := map[string]interface{}{}
+			if existing, found, _ := uns.NestedMap(container, "resources", field); found {
+				values = existing
+			}
+			for name, val := range quantities {
+				values[name] = val
+			}
+			if err := uns.SetNestedMap(container, values, "resources", field); err != nil {
+				return err
+			}
+		}
+		containers[i] = container
+		log.Printf("Applying resource override to container %q of (%s) %s/%s", override.Container, override.Kind, override.Namespace, override.Name)
+	}
+	return uns.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+// overrideWorkloadSpec applies the pod- and workload-level parts of an
+// override: replicas, priority class and tolerations.
+func overrideWorkloadSpec(obj *uns.Unstructured, override resourceOverride) error {
+	if override.Replicas != nil {
+		if err := uns.SetNestedField(obj.Object, int64(*override.Replicas), "spec", "replicas"); err != nil {
+			return err
+		}
+		log.Printf("Overriding replicas of (%s) %s/%s to %d", override.Kind, override.Namespace, override.Name, *override.Replicas)
+	}
+	if override.PriorityClassName != "" {
+		if err := uns.SetNestedField(obj.Object, override.PriorityClassName, "spec", "template", "spec", "priorityClassName"); err != nil {
+			return err
+		}
+	}
+	if len(override.Tolerations) > 0 {
+		tolerations := make([]interface{}, 0, len(override.Tolerations))
+		for i := range override.Tolerations {
+			converted, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&override.Tolerations[i])
+			if err != nil {
+				return errors.Wrap(err, "failed to convert toleration")
+			}
+			tolerations = append(tolerations, converted)
+		}
+		if err := uns.SetNestedSlice(obj.Object, tolerations, "spec", "template", "spec", "tolerations"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
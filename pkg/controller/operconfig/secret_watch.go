@@ -0,0 +1,93 @@
+package operconfig
+
+// Secret changes relevant to the rendered operands (IPsec CA material,
+// kube-rbac-proxy serving certs, cloud credentials) should trigger a
+// reconcile rather than wait out the resync period. Watching Secrets
+// through the manager cache would pin every Secret in the cluster in
+// memory, so dedicated informers scoped to the operand namespaces feed a
+// channel source instead.
+
+import (
+	"context"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// watchedSecretNamespaces are the namespaces whose Secrets feed into the
+// rendered manifests.
+var watchedSecretNamespaces = []string{
+	names.APPLIED_NAMESPACE,
+	"openshift-ovn-kubernetes",
+	"openshift-sdn",
+	"openshift-cloud-network-config-controller",
+}
+
+// startSecretInformers creates a namespaced Secret informer per operand
+// namespace and converts its events into GenericEvents on the returned
+// channel, which the caller wires into the controller as a channel source.
+func startSecretInformers(mgr manager.Manager) (chan event.GenericEvent, error) {
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	// Buffered, and sends never block: a dropped event is harmless since
+	// any subsequent event (or the periodic resync) reconciles everything
+	// relevant anyway.
+	events := make(chan event.GenericEvent, 1024)
+	notify := func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				secret, _ = tombstone.Obj.(*corev1.Secret)
+			}
+			if secret == nil {
+				return
+			}
+		}
+		// Service account tokens churn and never affect rendering.
+		if secret.Type == corev1.SecretTypeServiceAccountToken {
+			return
+		}
+		select {
+		case events <- event.GenericEvent{Object: secret}:
+		default:
+			klog.V(2).Info("Dropping secret event, channel full")
+		}
+	}
+
+	for _, namespace := range watchedSecretNamespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithNamespace(namespace))
+		informer := factory.Core().V1().Secrets().Informer()
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: notify,
+			UpdateFunc: func(old, new interface{}) {
+				oldSecret, oldOK := old.(*corev1.Secret)
+				newSecret, newOK := new.(*corev1.Secret)
+				if oldOK && newOK && oldSecret.ResourceVersion == newSecret.ResourceVersion {
+					// Periodic informer resync, nothing changed.
+					return
+				}
+				notify(new)
+			},
+			DeleteFunc: notify,
+		})
+		err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			factory.Start(ctx.Done())
+			<-ctx.Done()
+			return nil
+		}))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
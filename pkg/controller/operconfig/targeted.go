@@ -0,0 +1,135 @@
+package operconfig
+
+// Targeted reconciliation. Every watch event normally funnels into a full
+// reconcile that re-applies several hundred objects. For a handful of
+// well-known input ConfigMaps the dependency on the rendered output is
+// narrow and static, so a change to one of them only needs to re-apply the
+// objects it influences. The full render still runs (it is cheap and keeps
+// the status and drift bookkeeping consistent); only the apply step is
+// narrowed. Anything else - a spec change, a coalesced trigger, the first
+// reconcile after a restart - falls back to a full apply, and the periodic
+// resync remains the backstop for any event the narrowing misjudges.
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// renderTargets maps an input ConfigMap in APPLIED_NAMESPACE to the rendered
+// objects whose content depends on it.
+var renderTargets = map[string]func(obj *uns.Unstructured) bool{
+	// The isolation policy config only renders into NetworkPolicies.
+	names.ISOLATION_POLICY_CONFIGMAP: func(obj *uns.Unstructured) bool {
+		return obj.GetKind() == "NetworkPolicy"
+	},
+	// The primary interface policy renders into ovnkube-node env vars.
+	names.PRIMARY_INTERFACE_POLICY_CONFIGMAP: func(obj *uns.Unstructured) bool {
+		return obj.GetKind() == "DaemonSet" && obj.GetName() == "ovnkube-node"
+	},
+	// The whereabouts tuning renders into the whereabouts bits of the
+	// multus deployment.
+	names.WHEREABOUTS_CONFIGMAP: func(obj *uns.Unstructured) bool {
+		return strings.Contains(obj.GetName(), "whereabouts")
+	},
+}
+
+// targetedState tracks which input ConfigMaps changed since the last
+// reconcile, and the configuration identity of the last applied render.
+type targetedState struct {
+	sync.Mutex
+	pending          map[string]bool
+	lastAppliedState string
+}
+
+// noteTargetedInput records that a mapped input ConfigMap changed; the
+// reconcile it enqueues consumes the set via targetedApplyFilter.
+func (r *ReconcileOperConfig) noteTargetedInput(name string) {
+	r.targeted.Lock()
+	defer r.targeted.Unlock()
+	if r.targeted.pending == nil {
+		r.targeted.pending = map[string]bool{}
+	}
+	r.targeted.pending[name] = true
+}
+
+// targetedApplyFilter returns a predicate selecting the objects worth
+// applying for this reconcile, or nil when a full apply is required. The
+// narrowing is only safe if a full apply already succeeded in this process
+// and the operator configuration itself is unchanged since.
+func (r *ReconcileOperConfig) targetedApplyFilter(operConfig *operv1.Network) func(obj *uns.Unstructured) bool {
+	r.targeted.Lock()
+	defer r.targeted.Unlock()
+	pending := r.targeted.pending
+	r.targeted.pending = nil
+	if len(pending) == 0 {
+		return nil
+	}
+
+	r.renderedMu.Lock()
+	applied := r.renderedObjects != nil
+	r.renderedMu.Unlock()
+	if !applied || configIdentity(operConfig) != r.targeted.lastAppliedState {
+		return nil
+	}
+
+	inputs := []string{}
+	matchers := []func(obj *uns.Unstructured) bool{}
+	for name := range pending {
+		inputs = append(inputs, name)
+		matchers = append(matchers, renderTargets[name])
+	}
+	sort.Strings(inputs)
+	klog.Infof("Narrowing apply to objects depending on: %s", strings.Join(inputs, ", "))
+	return func(obj *uns.Unstructured) bool {
+		for _, matches := range matchers {
+			if matches(obj) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// recordAppliedState remembers the configuration identity the last full
+// render was applied for.
+func (r *ReconcileOperConfig) recordAppliedState(operConfig *operv1.Network) {
+	r.targeted.Lock()
+	defer r.targeted.Unlock()
+	r.targeted.lastAppliedState = configIdentity(operConfig)
+}
+
+// configIdentity condenses everything about the operator configuration that
+// influences rendering: the spec generation and the annotations (which drive
+// hibernation, canary rollouts, pausing and the like).
+func configIdentity(operConfig *operv1.Network) string {
+	hash := fnv.New64a()
+	keys := []string{}
+	for key := range operConfig.Annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s;", key, operConfig.Annotations[key])
+	}
+	return fmt.Sprintf("%d/%x", operConfig.Generation, hash.Sum64())
+}
+
+// filterObjects returns the rendered objects selected by the filter.
+func filterObjects(objs []*uns.Unstructured, filter func(obj *uns.Unstructured) bool) []*uns.Unstructured {
+	selected := []*uns.Unstructured{}
+	for _, obj := range objs {
+		if filter(obj) {
+			selected = append(selected, obj)
+		}
+	}
+	return selected
+}
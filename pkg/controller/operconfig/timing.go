@@ -0,0 +1,32 @@
+package operconfig
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// reconcilePhase identifies one stage of the operconfig reconcile loop.
+type reconcilePhase string
+
+const (
+	phaseMergeClusterConfig reconcilePhase = "MergeClusterConfig"
+	phaseValidate           reconcilePhase = "Validate"
+	phaseBootstrap          reconcilePhase = "Bootstrap"
+	phaseRender             reconcilePhase = "Render"
+	phaseApply              reconcilePhase = "Apply"
+	phaseStatusUpdate       reconcilePhase = "StatusUpdate"
+)
+
+// timePhase returns a func that, when called, logs how long the phase took,
+// keyed by the per-reconcile ID, so slow phases can be identified from logs.
+func timePhase(reconcileID types.UID, phase reconcilePhase) func() {
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		reconcilePhaseDuration.WithLabelValues(string(phase)).Observe(elapsed.Seconds())
+		klog.V(2).InfoS("Reconcile phase complete", "reconcileID", reconcileID,
+			"phase", phase, "duration", elapsed)
+	}
+}
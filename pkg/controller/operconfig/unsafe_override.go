@@ -0,0 +1,34 @@
+package operconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+)
+
+// unsafeChangeHash returns a short content hash of the proposed
+// configuration. An administrator who wants to force through a change that
+// IsChangeSafe rejected must echo this hash back in the acknowledgment
+// annotation, which proves they saw the rejection for this exact spec.
+func unsafeChangeHash(spec *operv1.NetworkSpec) string {
+	buf, err := json.Marshal(spec)
+	if err != nil {
+		// NetworkSpec always marshals; this is belt-and-braces.
+		return ""
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:8])
+}
+
+// unsafeChangeAcknowledged reports whether the operator config carries an
+// acknowledgment annotation matching the hash of the proposed spec.
+func unsafeChangeAcknowledged(operConfig *operv1.Network, hash string) bool {
+	if hash == "" {
+		return false
+	}
+	ack, ok := operConfig.GetAnnotations()[names.UnsafeChangeAcknowledgeAnnotation]
+	return ok && ack == hash
+}
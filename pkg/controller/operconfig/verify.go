@@ -0,0 +1,72 @@
+package operconfig
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// defaultVerifyTimeout bounds the post-apply verification wait when the
+// annotation does not specify its own timeout.
+const defaultVerifyTimeout = 2 * time.Minute
+
+// verifyPollInterval is how often operand readiness is re-checked during
+// post-apply verification.
+const verifyPollInterval = 5 * time.Second
+
+// verifyCriticalOperands waits (bounded) until every critical rendered
+// DaemonSet (ovnkube-node, multus, ...; anything not annotated non-critical)
+// reports Ready pods on the nodes it is scheduled to, shrinking the window in
+// which the operator reports success while the dataplane is still broken.
+func (r *ReconcileOperConfig) verifyCriticalOperands(ctx context.Context, objs []*uns.Unstructured, timeout time.Duration) error {
+	critical := []types.NamespacedName{}
+	for _, obj := range objs {
+		if obj.GetAPIVersion() != "apps/v1" || obj.GetKind() != "DaemonSet" {
+			continue
+		}
+		if _, ok := obj.GetAnnotations()[names.NonCriticalAnnotation]; ok {
+			continue
+		}
+		critical = append(critical, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+	}
+	if len(critical) == 0 {
+		return nil
+	}
+
+	var notReady types.NamespacedName
+	err := wait.PollImmediate(verifyPollInterval, timeout, func() (bool, error) {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+		for _, nsn := range critical {
+			ds := &appsv1.DaemonSet{}
+			if err := r.client.Get(ctx, nsn, ds); err != nil {
+				notReady = nsn
+				return false, nil
+			}
+			// Require the rollout to be observed and at least one Ready pod,
+			// with no more than a tenth of the desired nodes unavailable.
+			if ds.Status.ObservedGeneration < ds.Generation ||
+				ds.Status.NumberReady == 0 ||
+				ds.Status.NumberUnavailable > ds.Status.DesiredNumberScheduled/10 {
+				notReady = nsn
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "critical operand %s/%s did not become ready within %v", notReady.Namespace, notReady.Name, timeout)
+	}
+	return nil
+}
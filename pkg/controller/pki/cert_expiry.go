@@ -0,0 +1,91 @@
+package pki
+
+// Certificate expiry observability for the CAs and target certificates
+// managed by this controller. Expiry timestamps are exported as metrics, and
+// a certificate that gets close to expiry while its rotation is failing
+// blocks upgrades.
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// pkiCertExpiryTimestamp reports the NotAfter timestamp of each certificate
+// managed by the pki controller.
+var pkiCertExpiryTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_pki_cert_expiry_timestamp_seconds",
+	Help: "NotAfter timestamp of each CA and target certificate managed by the pki controller.",
+}, []string{"namespace", "secret"})
+
+func init() {
+	prometheus.MustRegister(pkiCertExpiryTimestamp)
+}
+
+// certExpiryWarningWindow is how close to expiry a managed certificate may
+// get before a failing rotation blocks upgrades. The default can be
+// overridden with the CERT_EXPIRY_WARNING_WINDOW environment variable.
+func certExpiryWarningWindow() time.Duration {
+	raw := os.Getenv("CERT_EXPIRY_WARNING_WINDOW")
+	if raw == "" {
+		return 14 * 24 * time.Hour
+	}
+	window, err := time.ParseDuration(raw)
+	if err != nil || window <= 0 {
+		log.Printf("Invalid CERT_EXPIRY_WARNING_WINDOW %q, using the default", raw)
+		return 14 * 24 * time.Hour
+	}
+	return window
+}
+
+// updateCertExpiry refreshes the expiry metrics for the Secrets belonging to
+// the given PKI and records which of its certificates are within the warning
+// window, for setStatus to combine with the rotation errors.
+func (r *PKIReconciler) updateCertExpiry(ctx context.Context, nsName types.NamespacedName) {
+	window := certExpiryWarningWindow()
+	expiring := []string{}
+	for _, suffix := range []string{"-ca", "-cert"} {
+		secretName := types.NamespacedName{Namespace: nsName.Namespace, Name: nsName.Name + suffix}
+		secret := &corev1.Secret{}
+		if err := r.mgr.GetClient().Get(ctx, secretName, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Printf("Could not inspect Secret %s for certificate expiry: %v", secretName, err)
+			}
+			continue
+		}
+		cert, err := parseCertificate(secret.Data["tls.crt"])
+		if err != nil {
+			log.Printf("Could not parse certificate in Secret %s: %v", secretName, err)
+			continue
+		}
+		pkiCertExpiryTimestamp.WithLabelValues(secretName.Namespace, secretName.Name).Set(float64(cert.NotAfter.Unix()))
+		if time.Until(cert.NotAfter) < window {
+			expiring = append(expiring, fmt.Sprintf("%s/%s (expires %s)", secretName.Namespace, secretName.Name, cert.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	if len(expiring) == 0 {
+		delete(r.expiringCerts, nsName)
+	} else {
+		r.expiringCerts[nsName] = expiring
+	}
+}
+
+// parseCertificate decodes the first PEM-encoded certificate in the supplied
+// bytes.
+func parseCertificate(pemBytes []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("PEM block type must be CERTIFICATE")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
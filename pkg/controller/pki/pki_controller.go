@@ -18,12 +18,14 @@ import (
 	netopv1 "github.com/openshift/cluster-network-operator/pkg/apis/network/v1"
 	"github.com/openshift/cluster-network-operator/pkg/controller/eventrecorder"
 	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/names"
 
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/crypto"
 	"github.com/openshift/library-go/pkg/operator/certrotation"
 	"github.com/pkg/errors"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
@@ -73,6 +75,10 @@ type PKIReconciler struct {
 	pkis map[types.NamespacedName]*pki
 	// For computing status
 	pkiErrs map[types.NamespacedName]error
+	// Forced rotations whose regenerated Secret has yet to be annotated
+	pendingRotations map[types.NamespacedName]string
+	// Certificates within the expiry warning window, per PKI
+	expiringCerts map[types.NamespacedName][]string
 }
 
 // The periodic resync interval.
@@ -93,8 +99,10 @@ func newPKIReconciler(mgr manager.Manager, status *statusmanager.StatusManager)
 		status:    status,
 		clientset: clientset,
 
-		pkis:    map[types.NamespacedName]*pki{},
-		pkiErrs: map[types.NamespacedName]error{},
+		pkis:             map[types.NamespacedName]*pki{},
+		pkiErrs:          map[types.NamespacedName]error{},
+		pendingRotations: map[types.NamespacedName]string{},
+		expiringCerts:    map[types.NamespacedName][]string{},
 	}, nil
 }
 
@@ -135,7 +143,18 @@ func (r *PKIReconciler) Reconcile(ctx context.Context, request reconcile.Request
 		r.pkis[request.NamespacedName] = existing
 	}
 
+	// An updated forced-rotation annotation invalidates the current target
+	// cert before the sync below regenerates it.
+	if err := r.forceRotationIfRequested(ctx, obj); err != nil {
+		log.Println(err)
+		r.pkiErrs[request.NamespacedName] =
+			errors.Wrapf(err, "could not force certificate rotation for PKI %s", request.NamespacedName)
+		r.setStatus()
+		return reconcile.Result{}, err
+	}
+
 	err = existing.sync()
+	r.updateCertExpiry(ctx, request.NamespacedName)
 	if err != nil {
 		log.Println(err)
 		r.pkiErrs[request.NamespacedName] =
@@ -144,12 +163,69 @@ func (r *PKIReconciler) Reconcile(ctx context.Context, request reconcile.Request
 		return reconcile.Result{}, err
 	}
 
+	r.markRotated(ctx, request.NamespacedName)
+
 	log.Println("successful reconciliation")
 	delete(r.pkiErrs, request.NamespacedName)
 	r.setStatus()
 	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
 }
 
+// forceRotationIfRequested honors the forced-rotation annotation on the PKI
+// object: when its value changes, the target cert Secret is deleted so the
+// next sync issues a fresh certificate. The handled value is recorded on the
+// regenerated Secret, making the rotation one-shot per annotation value.
+func (r *PKIReconciler) forceRotationIfRequested(ctx context.Context, obj *netopv1.OperatorPKI) error {
+	want := obj.Annotations[names.IPsecCertRotateAnnotation]
+	if want == "" {
+		return nil
+	}
+
+	secretName := types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name + "-cert"}
+	secret := &corev1.Secret{}
+	err := r.mgr.GetClient().Get(ctx, secretName, secret)
+	if apierrors.IsNotFound(err) {
+		// Nothing issued yet; the sync will create a fresh cert.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if secret.Annotations[names.IPsecCertRotateAnnotation] == want {
+		return nil
+	}
+	log.Printf("Forced certificate rotation %q requested for PKI %s/%s, deleting Secret %s", want, obj.Namespace, obj.Name, secretName)
+	if err := r.mgr.GetClient().Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	r.pendingRotations[types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}] = want
+	return nil
+}
+
+// markRotated records the handled forced-rotation value on the regenerated
+// target cert Secret.
+func (r *PKIReconciler) markRotated(ctx context.Context, nsName types.NamespacedName) {
+	want, ok := r.pendingRotations[nsName]
+	if !ok {
+		return
+	}
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{Namespace: nsName.Namespace, Name: nsName.Name + "-cert"}
+	if err := r.mgr.GetClient().Get(ctx, secretName, secret); err != nil {
+		log.Printf("Could not record forced rotation on Secret %s (continuing): %v", secretName, err)
+		return
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[names.IPsecCertRotateAnnotation] = want
+	if err := r.mgr.GetClient().Update(ctx, secret); err != nil {
+		log.Printf("Could not record forced rotation on Secret %s (continuing): %v", secretName, err)
+		return
+	}
+	delete(r.pendingRotations, nsName)
+}
+
 // setStatus summarizes the status of all PKI objects and updates the statusmanager
 // as appropriate.
 func (r *PKIReconciler) setStatus() {
@@ -162,6 +238,17 @@ func (r *PKIReconciler) setStatus() {
 		}
 		r.status.SetDegraded(statusmanager.PKIConfig, "PKIError", strings.Join(msgs, ", "))
 	}
+
+	// A certificate inside the expiry warning window is only a problem if
+	// its rotation is also failing; a working rotation resolves it on the
+	// next sync.
+	problems := []string{}
+	for nsName, certs := range r.expiringCerts {
+		if r.pkiErrs[nsName] != nil {
+			problems = append(problems, certs...)
+		}
+	}
+	r.status.SetCertificateExpiryProblems(statusmanager.PKIConfig, problems)
 }
 
 // pki is the internal type that represents a single PKI CRD. It manages the
@@ -171,10 +258,59 @@ type pki struct {
 	controller factory.Controller
 }
 
+// rotationParameters computes the validity and refresh durations for the CA
+// and target certificates from the (optional) duration knobs in the spec,
+// defaulting to the historical compile-time values: CA 10 years rotated at
+// 90%, target 6 months rotated at 50%.
+func rotationParameters(spec *netopv1.OperatorPKISpec) (signingValidity, signingRefresh, targetValidity, targetRefresh time.Duration, err error) {
+	signingValidity = 10 * OneYear
+	targetValidity = OneYear / 2
+
+	if spec.SigningDuration != nil {
+		signingValidity = spec.SigningDuration.Duration
+		if signingValidity <= 0 {
+			return 0, 0, 0, 0, errors.Errorf("signingDuration must be positive")
+		}
+	}
+	if spec.TargetDuration != nil {
+		targetValidity = spec.TargetDuration.Duration
+		if targetValidity <= 0 {
+			return 0, 0, 0, 0, errors.Errorf("targetDuration must be positive")
+		}
+	}
+	if targetValidity > signingValidity {
+		return 0, 0, 0, 0, errors.Errorf("targetDuration must not exceed signingDuration")
+	}
+
+	signingRefresh = signingValidity * 9 / 10
+	targetRefresh = targetValidity / 2
+	if spec.RefreshPercent != nil {
+		pct := *spec.RefreshPercent
+		if pct < 1 || pct > 99 {
+			return 0, 0, 0, 0, errors.Errorf("refreshPercent must be between 1 and 99")
+		}
+		signingRefresh = signingValidity * time.Duration(pct) / 100
+		targetRefresh = targetValidity * time.Duration(pct) / 100
+	}
+
+	return signingValidity, signingRefresh, targetValidity, targetRefresh, nil
+}
+
 // newPKI creates a CertRotationController for the supplied configuration
 func newPKI(config *netopv1.OperatorPKI, clientset *kubernetes.Clientset, mgr manager.Manager) (*pki, error) {
 	spec := config.Spec
 
+	// The key algorithm is not yet pluggable in library-go; reject anything
+	// other than the default rather than silently generating the wrong keys.
+	if spec.KeyAlgorithm != "" && spec.KeyAlgorithm != "RSA-2048" {
+		return nil, errors.Errorf("unsupported keyAlgorithm %q, only RSA-2048 is supported", spec.KeyAlgorithm)
+	}
+
+	signingValidity, signingRefresh, targetValidity, targetRefresh, err := rotationParameters(&spec)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ugly: the existing cache + informers used as part of the controller-manager
 	// can't be used, because they're untyped. So, we need to create our own.
 	// However, this has a few advantages - namely, we're creating a namespace-scoped
@@ -191,8 +327,8 @@ func newPKI(config *netopv1.OperatorPKI, clientset *kubernetes.Clientset, mgr ma
 		certrotation.SigningRotation{
 			Namespace:     config.Namespace,
 			Name:          config.Name + "-ca",
-			Validity:      10 * OneYear,
-			Refresh:       9 * OneYear,
+			Validity:      signingValidity,
+			Refresh:       signingRefresh,
 			Informer:      inf.Core().V1().Secrets(),
 			Lister:        inf.Core().V1().Secrets().Lister(),
 			Client:        clientset.CoreV1(),
@@ -209,8 +345,8 @@ func newPKI(config *netopv1.OperatorPKI, clientset *kubernetes.Clientset, mgr ma
 		certrotation.TargetRotation{
 			Namespace: config.Namespace,
 			Name:      config.Name + "-cert",
-			Validity:  OneYear / 2,
-			Refresh:   OneYear / 4,
+			Validity:  targetValidity,
+			Refresh:   targetRefresh,
 			CertCreator: &certrotation.ServingRotation{
 				Hostnames: func() []string { return []string{spec.TargetCert.CommonName} },
 
@@ -0,0 +1,258 @@
+package podlatency
+
+// podlatency is an opt-in sampler for pod sandbox network setup latency. At
+// a configured interval it schedules a short-lived canary pod onto one node
+// per node pool and measures how long the pod takes to get an IP assigned.
+// The samples are exported as a histogram, and pools whose latency exceeds
+// the configured threshold raise a warning condition. The sampler is enabled
+// by creating the pod-latency-sampler ConfigMap in APPLIED_NAMESPACE.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// configCheckPeriod is how often the sampler configuration is re-read;
+	// it bounds how quickly enabling or disabling the sampler takes effect.
+	configCheckPeriod = time.Minute
+
+	// sampleTimeout is how long a canary pod may take to get an IP before
+	// the sample is abandoned and reported as a problem.
+	sampleTimeout = 2 * time.Minute
+
+	// samplePollPeriod bounds the measurement granularity.
+	samplePollPeriod = 2 * time.Second
+
+	defaultInterval         = 10 * time.Minute
+	defaultWarningThreshold = 10 * time.Second
+
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+)
+
+// podNetworkSetupLatency reports sampled sandbox network setup latency per
+// node pool.
+var podNetworkSetupLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "cno_pod_network_setup_latency_seconds",
+	Help:    "Sampled time from canary pod creation until an IP is assigned, per node pool.",
+	Buckets: prometheus.ExponentialBuckets(0.5, 2, 10),
+}, []string{"pool"})
+
+func init() {
+	prometheus.MustRegister(podNetworkSetupLatency)
+}
+
+// Add registers the pod latency sampler with the manager.
+func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	sampler := &latencySampler{client: mgr.GetClient(), status: status}
+	return mgr.Add(manager.RunnableFunc(sampler.run))
+}
+
+type latencySampler struct {
+	client crclient.Client
+	status *statusmanager.StatusManager
+
+	lastSample time.Time
+}
+
+type samplerConfig struct {
+	interval         time.Duration
+	warningThreshold time.Duration
+}
+
+func (s *latencySampler) run(ctx context.Context) error {
+	ticker := time.NewTicker(configCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			config, err := s.loadConfig(ctx)
+			if err != nil {
+				klog.Errorf("Invalid pod latency sampler configuration: %v", err)
+				continue
+			}
+			if config == nil {
+				// Sampler disabled; make sure no stale condition lingers.
+				s.status.SetPodNetworkLatencyProblems(nil)
+				continue
+			}
+			if time.Since(s.lastSample) < config.interval {
+				continue
+			}
+			s.lastSample = time.Now()
+			s.sample(ctx, config)
+		}
+	}
+}
+
+// loadConfig reads the sampler ConfigMap. A nil config means the sampler is
+// disabled.
+func (s *latencySampler) loadConfig(ctx context.Context) (*samplerConfig, error) {
+	cm := &corev1.ConfigMap{}
+	err := s.client.Get(ctx, types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.POD_LATENCY_SAMPLER_CONFIGMAP}, cm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	config := &samplerConfig{
+		interval:         defaultInterval,
+		warningThreshold: defaultWarningThreshold,
+	}
+	if raw := cm.Data["interval"]; raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval < configCheckPeriod {
+			return nil, fmt.Errorf("invalid interval %q in ConfigMap %s/%s: must be a duration of at least %s", raw, names.APPLIED_NAMESPACE, names.POD_LATENCY_SAMPLER_CONFIGMAP, configCheckPeriod)
+		}
+		config.interval = interval
+	}
+	if raw := cm.Data["warning-threshold"]; raw != "" {
+		threshold, err := time.ParseDuration(raw)
+		if err != nil || threshold <= 0 {
+			return nil, fmt.Errorf("invalid warning-threshold %q in ConfigMap %s/%s: must be a positive duration", raw, names.APPLIED_NAMESPACE, names.POD_LATENCY_SAMPLER_CONFIGMAP)
+		}
+		config.warningThreshold = threshold
+	}
+	return config, nil
+}
+
+// sample runs one sampling pass: one canary pod per node pool.
+func (s *latencySampler) sample(ctx context.Context, config *samplerConfig) {
+	image := os.Getenv("NETWORK_CHECK_SOURCE_IMAGE")
+	if image == "" {
+		klog.Warning("Pod latency sampler disabled: NETWORK_CHECK_SOURCE_IMAGE is not set")
+		return
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := s.client.List(ctx, nodes); err != nil {
+		klog.Errorf("Failed to list nodes for latency sampling: %v", err)
+		return
+	}
+
+	// Pick one ready, schedulable node per pool; sorted so the choice is
+	// stable between passes.
+	poolNodes := map[string]string{}
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Spec.Unschedulable || !nodeReady(node) {
+			continue
+		}
+		pool := nodePool(node)
+		if _, ok := poolNodes[pool]; !ok {
+			poolNodes[pool] = node.Name
+		}
+	}
+
+	problems := []string{}
+	for pool, nodeName := range poolNodes {
+		latency, err := s.samplePool(ctx, image, nodeName)
+		if err != nil {
+			klog.Errorf("Latency sample on node %s (pool %s) failed: %v", nodeName, pool, err)
+			problems = append(problems, fmt.Sprintf("pool %s: sampling on node %s failed: %v", pool, nodeName, err))
+			continue
+		}
+		klog.V(2).Infof("Pod network setup on node %s (pool %s) took %s", nodeName, pool, latency.Round(time.Millisecond))
+		podNetworkSetupLatency.WithLabelValues(pool).Observe(latency.Seconds())
+		if latency > config.warningThreshold {
+			problems = append(problems, fmt.Sprintf("pool %s: pod network setup took %s on node %s (threshold %s)", pool, latency.Round(time.Millisecond), nodeName, config.warningThreshold))
+		}
+	}
+	s.status.SetPodNetworkLatencyProblems(problems)
+}
+
+// samplePool creates a canary pod pinned to the given node and measures how
+// long it takes to be assigned an IP. The pod bypasses the scheduler so the
+// measurement covers only sandbox setup, and is deleted afterwards.
+func (s *latencySampler) samplePool(ctx context.Context, image, nodeName string) (time.Duration, error) {
+	graceperiod := int64(0)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "network-latency-canary-",
+			Namespace:    names.APPLIED_NAMESPACE,
+			Labels:       map[string]string{"app": "network-latency-canary"},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:                      nodeName,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: &graceperiod,
+			Tolerations: []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			},
+			Containers: []corev1.Container{{
+				Name:    "canary",
+				Image:   image,
+				Command: []string{"/bin/sleep", "600"},
+			}},
+		},
+	}
+	if err := s.client.Create(ctx, pod); err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := s.client.Delete(context.Background(), pod); err != nil && !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to delete canary pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}()
+
+	deadline := time.Now().Add(sampleTimeout)
+	for time.Now().Before(deadline) {
+		observed := &corev1.Pod{}
+		err := s.client.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, observed)
+		if err == nil && observed.Status.PodIP != "" {
+			return time.Since(pod.CreationTimestamp.Time), nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(samplePollPeriod):
+		}
+	}
+	return 0, fmt.Errorf("canary pod %s got no IP within %s", pod.Name, sampleTimeout)
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// nodePool derives a stable pool identifier from the node-role labels.
+func nodePool(node *corev1.Node) string {
+	roles := []string{}
+	for label := range node.Labels {
+		if strings.HasPrefix(label, nodeRoleLabelPrefix) {
+			roles = append(roles, strings.TrimPrefix(label, nodeRoleLabelPrefix))
+		}
+	}
+	if len(roles) == 0 {
+		return "unknown"
+	}
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
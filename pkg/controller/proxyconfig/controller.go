@@ -31,11 +31,19 @@ func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
 		return fmt.Errorf("failed to create reconciler")
 	}
 
-	return add(mgr, reconciler)
+	if err := add(mgr, reconciler); err != nil {
+		return err
+	}
+
+	// Periodically probe the configured proxy endpoints.
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		reconciler.runProxyProber(ctx)
+		return nil
+	}))
 }
 
-// newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, status *statusmanager.StatusManager) reconcile.Reconciler {
+// newReconciler returns a new ReconcileProxyConfig
+func newReconciler(mgr manager.Manager, status *statusmanager.StatusManager) *ReconcileProxyConfig {
 	return &ReconcileProxyConfig{client: mgr.GetClient(), scheme: mgr.GetScheme(), status: status}
 }
 
@@ -0,0 +1,123 @@
+package proxyconfig
+
+// Operand proxy settings. CNO-rendered operands sometimes need noProxy
+// entries (e.g. internal registries resolved via the pod network) that do not
+// belong in the cluster-wide Proxy resource. Extra entries are read from a
+// ConfigMap, validated, merged with the cluster-wide settings, and published
+// as a managed ConfigMap the operands can consume.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// noProxyDomainRegexp matches a noProxy domain entry, optionally with a
+// leading dot for subdomain matches.
+var noProxyDomainRegexp = regexp.MustCompile(`^\.?[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)*$`)
+
+// operandNoProxyAdditions reads and validates the extra noProxy entries for
+// CNO-rendered operands from the OPERAND_NOPROXY_CONFIGMAP.
+func (r *ReconcileProxyConfig) operandNoProxyAdditions(ctx context.Context) ([]string, error) {
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.OPERAND_NOPROXY_CONFIGMAP}
+	if err := r.client.Get(ctx, nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get configmap '%s/%s': %v", nsn.Namespace, nsn.Name, err)
+	}
+
+	additions := []string{}
+	for _, entry := range strings.Split(cm.Data["no-proxy"], ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if err := validateNoProxyEntry(entry); err != nil {
+			return nil, fmt.Errorf("invalid no-proxy entry %q in configmap '%s/%s': %v", entry, nsn.Namespace, nsn.Name, err)
+		}
+		additions = append(additions, entry)
+	}
+	return additions, nil
+}
+
+// validateNoProxyEntry checks one noProxy entry: a CIDR, an IP address or a
+// domain, optionally with a leading dot.
+func validateNoProxyEntry(entry string) error {
+	if _, _, err := net.ParseCIDR(entry); err == nil {
+		return nil
+	}
+	if net.ParseIP(entry) != nil {
+		return nil
+	}
+	if noProxyDomainRegexp.MatchString(entry) {
+		return nil
+	}
+	return fmt.Errorf("must be a CIDR, IP address or domain")
+}
+
+// syncOperandProxy publishes the effective proxy settings for CNO-rendered
+// operands: the cluster-wide proxy status with the validated extra noProxy
+// entries merged in.
+func (r *ReconcileProxyConfig) syncOperandProxy(ctx context.Context, proxyStatus configv1.ProxyStatus) error {
+	additions, err := r.operandNoProxyAdditions(ctx)
+	if err != nil {
+		return err
+	}
+
+	noProxy := proxyStatus.NoProxy
+	if len(additions) > 0 && noProxy != noProxyWildcard {
+		set := sets.NewString(additions...)
+		for _, entry := range strings.Split(noProxy, ",") {
+			if entry != "" {
+				set.Insert(entry)
+			}
+		}
+		noProxy = strings.Join(set.List(), ",")
+	}
+
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.OPERAND_PROXY_CONFIGMAP,
+			Namespace: names.APPLIED_NAMESPACE,
+		},
+		Data: map[string]string{
+			"HTTP_PROXY":  proxyStatus.HTTPProxy,
+			"HTTPS_PROXY": proxyStatus.HTTPSProxy,
+			"NO_PROXY":    noProxy,
+		},
+	}
+
+	current := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+	if err := r.client.Get(ctx, nsn, current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get configmap '%s/%s': %v", desired.Namespace, desired.Name, err)
+		}
+		if err := r.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create configmap '%s/%s': %v", desired.Namespace, desired.Name, err)
+		}
+		return nil
+	}
+	for _, key := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"} {
+		if !configMapsEqual(key, current, desired) {
+			if err := r.client.Update(ctx, desired); err != nil {
+				return fmt.Errorf("failed to update configmap '%s/%s': %v", desired.Namespace, desired.Name, err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
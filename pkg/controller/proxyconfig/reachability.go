@@ -0,0 +1,158 @@
+package proxyconfig
+
+// Reachability probing of the configured proxy. A proxy that stops accepting
+// connections otherwise only surfaces once dependent operators degrade; the
+// prober dials the configured httpProxy/httpsProxy endpoints periodically and
+// publishes the result as a warning condition and a metric.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// defaultProxyProbePeriod is how often the configured proxy endpoints are
+// probed; PROXY_PROBE_INTERVAL overrides it, "0" disables probing.
+const defaultProxyProbePeriod = 5 * time.Minute
+
+// proxyProbeTimeout bounds a single connect/CONNECT probe.
+const proxyProbeTimeout = 10 * time.Second
+
+// proxyProbeConnectHost is the host the CONNECT probe asks the proxy to
+// reach; only the proxy's response is inspected, no payload is exchanged.
+const proxyProbeConnectHost = "www.redhat.com:443"
+
+var proxyReachable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_proxy_reachable",
+	Help: "1 if the configured proxy endpoint accepts connections, 0 otherwise.",
+}, []string{"proxy"})
+
+func init() {
+	prometheus.MustRegister(proxyReachable)
+}
+
+// proxyProbePeriod resolves the probe interval; zero means disabled.
+func proxyProbePeriod() time.Duration {
+	raw := os.Getenv("PROXY_PROBE_INTERVAL")
+	if raw == "" {
+		return defaultProxyProbePeriod
+	}
+	period, err := time.ParseDuration(raw)
+	if err != nil || period < 0 {
+		klog.Warningf("Ignoring invalid PROXY_PROBE_INTERVAL %q", raw)
+		return defaultProxyProbePeriod
+	}
+	return period
+}
+
+// runProxyProber periodically probes the configured proxy endpoints.
+func (r *ReconcileProxyConfig) runProxyProber(ctx context.Context) {
+	period := proxyProbePeriod()
+	if period == 0 {
+		klog.Infof("Proxy reachability probing is disabled")
+		return
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.probeProxyEndpoints(ctx)
+		}
+	}
+}
+
+func (r *ReconcileProxyConfig) probeProxyEndpoints(ctx context.Context) {
+	proxyConfig := &configv1.Proxy{}
+	if err := r.client.Get(ctx, names.Proxy(), proxyConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to retrieve proxy '%s' for reachability probing: %v", names.PROXY_CONFIG, err)
+		}
+		return
+	}
+
+	endpoints := map[string]string{}
+	if isSpecHTTPProxySet(&proxyConfig.Spec) {
+		endpoints["http"] = proxyConfig.Spec.HTTPProxy
+	}
+	if isSpecHTTPSProxySet(&proxyConfig.Spec) {
+		endpoints["https"] = proxyConfig.Spec.HTTPSProxy
+	}
+	if len(endpoints) == 0 {
+		proxyReachable.Reset()
+		r.status.SetProxyUnreachable(nil)
+		return
+	}
+
+	problems := []string{}
+	for kind, endpoint := range endpoints {
+		if err := probeProxyEndpoint(endpoint); err != nil {
+			proxyReachable.WithLabelValues(kind).Set(0)
+			problems = append(problems, fmt.Sprintf("%sProxy %s is unreachable: %v", kind, endpoint, err))
+			continue
+		}
+		proxyReachable.WithLabelValues(kind).Set(1)
+	}
+	sort.Strings(problems)
+	r.status.SetProxyUnreachable(problems)
+}
+
+// probeProxyEndpoint dials the proxy and, for plaintext proxies, issues a
+// CONNECT for a known host to verify the proxy actually answers.
+func probeProxyEndpoint(endpoint string) error {
+	proxyURL, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %v", err)
+	}
+	host := proxyURL.Host
+	if proxyURL.Port() == "" {
+		port := "80"
+		if proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+
+	conn, err := net.DialTimeout("tcp", host, proxyProbeTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// A TLS handshake would need the proxy's CA; the connect test above is
+	// enough for https proxies.
+	if proxyURL.Scheme != "http" {
+		return nil
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(proxyProbeTimeout)); err != nil {
+		return err
+	}
+	request := fmt.Sprintf("CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", proxyProbeConnectHost, proxyProbeConnectHost)
+	if _, err := conn.Write([]byte(request)); err != nil {
+		return fmt.Errorf("CONNECT request failed: %v", err)
+	}
+	response := make([]byte, 128)
+	n, err := conn.Read(response)
+	if err != nil {
+		return fmt.Errorf("no response to CONNECT: %v", err)
+	}
+	if !strings.HasPrefix(string(response[:n]), "HTTP/") {
+		return fmt.Errorf("unexpected response to CONNECT")
+	}
+	return nil
+}
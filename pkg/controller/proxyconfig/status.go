@@ -38,6 +38,11 @@ func (r *ReconcileProxyConfig) syncProxyStatus(proxy *configv1.Proxy, infra *con
 		}
 	}
 
+	// Publish the effective proxy settings for CNO-rendered operands.
+	if err := r.syncOperandProxy(context.TODO(), updated.Status); err != nil {
+		return fmt.Errorf("failed to sync operand proxy configmap: %v", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,123 @@
+package signer
+
+// External CA integration: clusters with a centralized PKI can defer the
+// signing of CSRs for this signer to an externally managed CA instead of the
+// CA created by the OperatorPKI. The external CA is configured via a Secret
+// in the operator namespace that carries either signing credentials
+// (tls.crt/tls.key) or a signing webhook URL (signing-webhook-url, https
+// only, with an optional ca-bundle.crt to verify the webhook's serving
+// certificate).
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// signingWebhookTimeout bounds a single signing webhook call.
+const signingWebhookTimeout = 30 * time.Second
+
+// externalCA describes where CSRs are signed when the cluster defers to an
+// externally managed CA. Exactly one of the credential pair or the webhook
+// URL is set.
+type externalCA struct {
+	// caCertPEM and caKeyPEM are set when the Secret carries signing
+	// credentials.
+	caCertPEM []byte
+	caKeyPEM  []byte
+
+	// webhookURL is set when the Secret delegates signing to a webhook.
+	webhookURL string
+	// rootCAs verifies the webhook's serving certificate, when provided.
+	rootCAs *x509.CertPool
+}
+
+// getExternalCA loads the external CA configuration from the optional
+// Secret. It returns nil when no external CA is configured, in which case
+// the self-managed CA is used.
+func (r *ReconcileCSR) getExternalCA(ctx context.Context) (*externalCA, error) {
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.CSR_SIGNER_EXTERNAL_CA_SECRET}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if raw := string(secret.Data["signing-webhook-url"]); raw != "" {
+		// Signed certificates travel back over this connection, so it must
+		// be authenticated: only https is accepted.
+		parsed, err := url.Parse(raw)
+		if err != nil || parsed.Scheme != "https" {
+			return nil, fmt.Errorf("invalid signing-webhook-url %q, must be an https URL", raw)
+		}
+		external := &externalCA{webhookURL: raw}
+		if bundle := secret.Data["ca-bundle.crt"]; len(bundle) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(bundle) {
+				return nil, fmt.Errorf("could not parse any certificates from ca-bundle.crt")
+			}
+			external.rootCAs = pool
+		}
+		return external, nil
+	}
+
+	if len(secret.Data["tls.crt"]) == 0 || len(secret.Data["tls.key"]) == 0 {
+		return nil, fmt.Errorf("secret %s/%s must contain either signing-webhook-url or tls.crt and tls.key",
+			names.APPLIED_NAMESPACE, names.CSR_SIGNER_EXTERNAL_CA_SECRET)
+	}
+	if _, err := decodeCertificate(secret.Data["tls.crt"]); err != nil {
+		return nil, fmt.Errorf("could not decode external CA certificate: %v", err)
+	}
+	if _, err := decodePrivateKey(secret.Data["tls.key"]); err != nil {
+		return nil, fmt.Errorf("could not decode external CA private key: %v", err)
+	}
+	return &externalCA{caCertPEM: secret.Data["tls.crt"], caKeyPEM: secret.Data["tls.key"]}, nil
+}
+
+// signViaWebhook posts the PEM-encoded CSR to the signing webhook and
+// returns the PEM-encoded certificate it responds with.
+func (e *externalCA) signViaWebhook(ctx context.Context, request []byte) ([]byte, error) {
+	client := &http.Client{Timeout: signingWebhookTimeout}
+	if e.rootCAs != nil {
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: e.rootCAs}}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.webhookURL, bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/pkcs10")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signing webhook returned %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the webhook actually returned a certificate before putting
+	// it in the CSR status.
+	if _, err := decodeCertificate(body); err != nil {
+		return nil, fmt.Errorf("signing webhook returned an invalid certificate: %v", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,148 @@
+package signer
+
+// IPsec certificate lifecycle observability: the certificates this signer
+// issues (one per node for IPsec) are tracked so admins can see days to
+// expiry and last rotation per node, and are alerted before they lapse.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
+	"github.com/prometheus/client_golang/prometheus"
+
+	csrv1 "k8s.io/api/certificates/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// CertCheckPeriod is how often the issued certificates are re-inspected.
+var CertCheckPeriod = 10 * time.Minute
+
+// certExpiryWarning is how close to expiry a certificate may get before the
+// operator raises a condition. The default can be overridden with the
+// CERT_EXPIRY_WARNING_WINDOW environment variable.
+func certExpiryWarning() time.Duration {
+	raw := os.Getenv("CERT_EXPIRY_WARNING_WINDOW")
+	if raw == "" {
+		return 7 * 24 * time.Hour
+	}
+	window, err := time.ParseDuration(raw)
+	if err != nil || window <= 0 {
+		klog.Warningf("Invalid CERT_EXPIRY_WARNING_WINDOW %q, using the default", raw)
+		return 7 * 24 * time.Hour
+	}
+	return window
+}
+
+// ipsecCertExpiryDays reports the days until expiry of each issued IPsec
+// certificate.
+var ipsecCertExpiryDays = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_ipsec_cert_expiry_days",
+	Help: "Days until expiry of the IPsec certificate issued for each CSR.",
+}, []string{"csr"})
+
+// ipsecCertLastRotation reports when each issued IPsec certificate was last
+// rotated (its NotBefore timestamp).
+var ipsecCertLastRotation = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_ipsec_cert_last_rotation_timestamp_seconds",
+	Help: "NotBefore timestamp of the IPsec certificate issued for each CSR.",
+}, []string{"csr"})
+
+// ipsecCertExpiryTimestamp reports the NotAfter timestamp of each issued
+// IPsec certificate.
+var ipsecCertExpiryTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_ipsec_cert_expiry_timestamp_seconds",
+	Help: "NotAfter timestamp of the IPsec certificate issued for each CSR.",
+}, []string{"csr"})
+
+func init() {
+	prometheus.MustRegister(ipsecCertExpiryDays)
+	prometheus.MustRegister(ipsecCertLastRotation)
+	prometheus.MustRegister(ipsecCertExpiryTimestamp)
+}
+
+type certChecker struct {
+	client client.Client
+	status *statusmanager.StatusManager
+}
+
+// addCertChecker registers the periodic certificate inspection with the
+// manager and hooks certificate expiry into the operator's health probes.
+func addCertChecker(mgr manager.Manager, status *statusmanager.StatusManager) error {
+	checker := &certChecker{client: mgr.GetClient(), status: status}
+	status.RegisterHealthProbe("ipsec-cert-expiry", checker.probe)
+	return mgr.Add(manager.RunnableFunc(checker.run))
+}
+
+func (c *certChecker) run(ctx context.Context) error {
+	ticker := time.NewTicker(CertCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := c.check(ctx); err != nil {
+				klog.Errorf("Failed to inspect IPsec certificates: %v", err)
+			}
+			if err := c.checkNodes(ctx); err != nil {
+				klog.Errorf("Failed to inspect node certificates: %v", err)
+			}
+		}
+	}
+}
+
+// probe fails when any issued certificate is close to (or past) expiry.
+func (c *certChecker) probe(ctx context.Context) error {
+	expiring, err := c.check(ctx)
+	if err != nil {
+		return err
+	}
+	if len(expiring) > 0 {
+		sort.Strings(expiring)
+		return fmt.Errorf("IPsec certificates close to expiry: %s", strings.Join(expiring, ", "))
+	}
+	return nil
+}
+
+// check refreshes the per-certificate metrics and returns descriptions of the
+// certificates within the expiry warning window.
+func (c *certChecker) check(ctx context.Context) ([]string, error) {
+	csrs := &csrv1.CertificateSigningRequestList{}
+	if err := c.client.List(ctx, csrs); err != nil {
+		return nil, err
+	}
+
+	window := certExpiryWarning()
+	expiring := []string{}
+	for _, csr := range csrs.Items {
+		if csr.Spec.SignerName != signerName || len(csr.Status.Certificate) == 0 {
+			continue
+		}
+		cert, err := decodeCertificate(csr.Status.Certificate)
+		if err != nil {
+			klog.Warningf("Could not decode issued certificate of CSR %s: %v", csr.Name, err)
+			continue
+		}
+		untilExpiry := time.Until(cert.NotAfter)
+		ipsecCertExpiryDays.WithLabelValues(csr.Name).Set(untilExpiry.Hours() / 24)
+		ipsecCertLastRotation.WithLabelValues(csr.Name).Set(float64(cert.NotBefore.Unix()))
+		ipsecCertExpiryTimestamp.WithLabelValues(csr.Name).Set(float64(cert.NotAfter.Unix()))
+		if untilExpiry < window {
+			expiring = append(expiring, fmt.Sprintf("%s (expires %s)", csr.Name, cert.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	// Issuance is client-driven: a certificate still inside the warning
+	// window means the node has not re-requested one, so rotation is
+	// effectively failing and upgrades are blocked until it recovers.
+	c.status.SetCertificateExpiryProblems(statusmanager.CertificateSigner, expiring)
+
+	return expiring, nil
+}
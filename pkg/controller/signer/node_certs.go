@@ -0,0 +1,143 @@
+package signer
+
+// Per-node identity certificate tracking: every node is expected to hold a
+// certificate issued by this signer. Nodes whose certificate is missing or
+// expired are exposed via a metric and a status condition, and an annotation
+// on the Node can force the certificate of that single node to be reissued.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	"github.com/prometheus/client_golang/prometheus"
+
+	csrv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// nodeCertMissing reports, per node, whether the node's identity certificate
+// is missing or expired (1) or current (0).
+var nodeCertMissing = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_node_cert_missing",
+	Help: "Whether the identity certificate of each node is missing or expired (1) or current (0).",
+}, []string{"node"})
+
+func init() {
+	prometheus.MustRegister(nodeCertMissing)
+}
+
+// checkNodes correlates Nodes with the certificates this signer has issued,
+// honors forced per-node reissue requests, and publishes which nodes are
+// missing a current certificate.
+func (c *certChecker) checkNodes(ctx context.Context) error {
+	nodes := &corev1.NodeList{}
+	if err := c.client.List(ctx, nodes); err != nil {
+		return err
+	}
+	csrs := &csrv1.CertificateSigningRequestList{}
+	if err := c.client.List(ctx, csrs); err != nil {
+		return err
+	}
+
+	problems := []string{}
+	nodeCertMissing.Reset()
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if err := c.reissueIfRequested(ctx, node, csrs); err != nil {
+			klog.Warningf("Could not handle forced certificate reissue for node %s: %v", node.Name, err)
+		}
+
+		expiry, found := nodeCertExpiry(node.Name, csrs)
+		switch {
+		case !found:
+			problems = append(problems, fmt.Sprintf("%s (no issued certificate)", node.Name))
+			nodeCertMissing.WithLabelValues(node.Name).Set(1)
+		case time.Now().After(expiry):
+			problems = append(problems, fmt.Sprintf("%s (certificate expired %s)", node.Name, expiry.UTC().Format(time.RFC3339)))
+			nodeCertMissing.WithLabelValues(node.Name).Set(1)
+		default:
+			nodeCertMissing.WithLabelValues(node.Name).Set(0)
+		}
+	}
+
+	c.status.SetNodeCertificateProblems(problems)
+	return nil
+}
+
+// nodeCertExpiry returns the latest expiry of the issued certificates that
+// reference the given node, and whether any certificate references it at all.
+func nodeCertExpiry(nodeName string, csrs *csrv1.CertificateSigningRequestList) (time.Time, bool) {
+	var expiry time.Time
+	found := false
+	for _, csr := range csrs.Items {
+		if csr.Spec.SignerName != signerName || len(csr.Status.Certificate) == 0 {
+			continue
+		}
+		if !csrReferencesNode(&csr, nodeName) {
+			continue
+		}
+		cert, err := decodeCertificate(csr.Status.Certificate)
+		if err != nil {
+			continue
+		}
+		found = true
+		if cert.NotAfter.After(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+	return expiry, found
+}
+
+// csrReferencesNode reports whether the CSR belongs to the given node, based
+// on the CSR name or the issued certificate's CommonName matching the node
+// name exactly or ending in ":<node>" (the system:node form).
+func csrReferencesNode(csr *csrv1.CertificateSigningRequest, nodeName string) bool {
+	if csr.Name == nodeName || strings.HasSuffix(csr.Name, ":"+nodeName) {
+		return true
+	}
+	cert, err := decodeCertificate(csr.Status.Certificate)
+	if err != nil {
+		return false
+	}
+	cn := cert.Subject.CommonName
+	return cn == nodeName || strings.HasSuffix(cn, ":"+nodeName)
+}
+
+// reissueIfRequested honors the forced-reissue annotation on a Node: when
+// its value changes, the node's CSRs are deleted so the node requests a
+// fresh certificate, and the handled value is recorded on the Node.
+func (c *certChecker) reissueIfRequested(ctx context.Context, node *corev1.Node, csrs *csrv1.CertificateSigningRequestList) error {
+	want := node.Annotations[names.NodeCertReissueAnnotation]
+	if want == "" || node.Annotations[names.NodeCertReissueHandledAnnotation] == want {
+		return nil
+	}
+
+	for _, csr := range csrs.Items {
+		if csr.Spec.SignerName != signerName || !csrReferencesNode(&csr, node.Name) {
+			continue
+		}
+		klog.Infof("Forced certificate reissue %q requested for node %s, deleting CSR %s", want, node.Name, csr.Name)
+		if err := c.client.Delete(ctx, &csr); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current := &corev1.Node{}
+		if err := c.client.Get(ctx, types.NamespacedName{Name: node.Name}, current); err != nil {
+			return err
+		}
+		if current.Annotations == nil {
+			current.Annotations = map[string]string{}
+		}
+		current.Annotations[names.NodeCertReissueHandledAnnotation] = want
+		return c.client.Update(ctx, current)
+	})
+}
@@ -33,6 +33,9 @@ func Add(mgr manager.Manager, status *statusmanager.StatusManager) error {
 	if err != nil {
 		return err
 	}
+	if err := addCertChecker(mgr, status); err != nil {
+		return err
+	}
 	return add(mgr, reconciler)
 }
 
@@ -147,56 +150,84 @@ func (r *ReconcileCSR) Reconcile(ctx context.Context, request reconcile.Request)
 
 	// From this, point we are dealing with an approved CSR
 
-	// Get our CA that was created by the operatorpki.
-	caSecret := &corev1.Secret{}
-	err = r.client.Get(ctx, types.NamespacedName{Namespace: "openshift-ovn-kubernetes", Name: "signer-ca"}, caSecret)
+	// An externally managed CA, when configured, takes precedence over the
+	// CA created by the OperatorPKI.
+	external, err := r.getExternalCA(ctx)
 	if err != nil {
-		signerFailure(r, csr, "CAFailure",
-			fmt.Sprintf("Could not get CA certificate and key: %v", err))
+		signerFailure(r, csr, "ExternalCAFailure",
+			fmt.Sprintf("Could not load the external CA configuration: %v", err))
 		return reconcile.Result{}, err
 	}
 
-	// Decode the certificate request from PEM format.
-	certReq, err := decodeCertificateRequest(csr.Spec.Request)
-	if err != nil {
-		// We dont degrade the status of the controller as this is due to a
-		// malformed CSR rather than an issue with the controller.
-		updateCSRStatusConditions(r, csr, "CSRDecodeFailure",
-			fmt.Sprintf("Could not decode Certificate Request: %v", err))
-		return reconcile.Result{}, nil
-	}
+	if external != nil && external.webhookURL != "" {
+		// The webhook performs the signing and returns the PEM-encoded
+		// certificate.
+		csr.Status.Certificate, err = external.signViaWebhook(ctx, csr.Spec.Request)
+		if err != nil {
+			signerFailure(r, csr, "ExternalCAFailure",
+				fmt.Sprintf("Signing webhook failed for %v and signer %v: %v", request.Name, signerName, err))
+			return reconcile.Result{}, nil
+		}
+	} else {
+		var caCertPEM, caKeyPEM []byte
+		if external != nil {
+			caCertPEM = external.caCertPEM
+			caKeyPEM = external.caKeyPEM
+		} else {
+			// Get our CA that was created by the operatorpki.
+			caSecret := &corev1.Secret{}
+			err = r.client.Get(ctx, types.NamespacedName{Namespace: "openshift-ovn-kubernetes", Name: "signer-ca"}, caSecret)
+			if err != nil {
+				signerFailure(r, csr, "CAFailure",
+					fmt.Sprintf("Could not get CA certificate and key: %v", err))
+				return reconcile.Result{}, err
+			}
+			caCertPEM = caSecret.Data["tls.crt"]
+			caKeyPEM = caSecret.Data["tls.key"]
+		}
 
-	// Decode the CA certificate from PEM format.
-	caCert, err := decodeCertificate(caSecret.Data["tls.crt"])
-	if err != nil {
-		signerFailure(r, csr, "CorruptCACert",
-			fmt.Sprintf("Unable to decode CA certificate for %v: %v", signerName, err))
-		return reconcile.Result{}, nil
-	}
+		// Decode the certificate request from PEM format.
+		certReq, err := decodeCertificateRequest(csr.Spec.Request)
+		if err != nil {
+			// We dont degrade the status of the controller as this is due to a
+			// malformed CSR rather than an issue with the controller.
+			updateCSRStatusConditions(r, csr, "CSRDecodeFailure",
+				fmt.Sprintf("Could not decode Certificate Request: %v", err))
+			return reconcile.Result{}, nil
+		}
 
-	// Decode the CA key from PEM format.
-	caKey, err := decodePrivateKey(caSecret.Data["tls.key"])
-	if err != nil {
-		signerFailure(r, csr, "CorruptCAKey",
-			fmt.Sprintf("Unable to decode CA private key for %v: %v", signerName, err))
-		return reconcile.Result{}, nil
-	}
+		// Decode the CA certificate from PEM format.
+		caCert, err := decodeCertificate(caCertPEM)
+		if err != nil {
+			signerFailure(r, csr, "CorruptCACert",
+				fmt.Sprintf("Unable to decode CA certificate for %v: %v", signerName, err))
+			return reconcile.Result{}, nil
+		}
 
-	// Create a new certificate using the certificate template and certificate.
-	// We can then sign this using the CA.
-	signedCert, err := signCSR(newCertificateTemplate(certReq), certReq.PublicKey, caCert, caKey)
-	if err != nil {
-		signerFailure(r, csr, "SigningFailure",
-			fmt.Sprintf("Unable to sign certificate for %v and signer %v: %v", request.Name, signerName, err))
-		return reconcile.Result{}, nil
-	}
+		// Decode the CA key from PEM format.
+		caKey, err := decodePrivateKey(caKeyPEM)
+		if err != nil {
+			signerFailure(r, csr, "CorruptCAKey",
+				fmt.Sprintf("Unable to decode CA private key for %v: %v", signerName, err))
+			return reconcile.Result{}, nil
+		}
 
-	// Encode the certificate into PEM format and add to the status of the CSR
-	csr.Status.Certificate, err = crypto.EncodeCertificates(signedCert)
-	if err != nil {
-		signerFailure(r, csr, "EncodeFailure",
-			fmt.Sprintf("Could not encode certificate: %v", err))
-		return reconcile.Result{}, nil
+		// Create a new certificate using the certificate template and certificate.
+		// We can then sign this using the CA.
+		signedCert, err := signCSR(newCertificateTemplate(certReq), certReq.PublicKey, caCert, caKey)
+		if err != nil {
+			signerFailure(r, csr, "SigningFailure",
+				fmt.Sprintf("Unable to sign certificate for %v and signer %v: %v", request.Name, signerName, err))
+			return reconcile.Result{}, nil
+		}
+
+		// Encode the certificate into PEM format and add to the status of the CSR
+		csr.Status.Certificate, err = crypto.EncodeCertificates(signedCert)
+		if err != nil {
+			signerFailure(r, csr, "EncodeFailure",
+				fmt.Sprintf("Could not encode certificate: %v", err))
+			return reconcile.Result{}, nil
+		}
 	}
 
 	err = r.client.Status().Update(ctx, csr)
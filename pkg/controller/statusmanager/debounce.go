@@ -0,0 +1,94 @@
+package statusmanager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+)
+
+// statusLevelByName is the inverse of statusLevelName, used when parsing
+// grace-period configuration.
+func statusLevelByName(name string) (StatusLevel, bool) {
+	for level := StatusLevel(0); level < maxStatusLevel; level++ {
+		if statusLevelName(level) == name {
+			return level, true
+		}
+	}
+	return maxStatusLevel, false
+}
+
+// ParseGracePeriods parses a grace-period spec of the form
+// "PodDeployment=2m,RolloutHung=5m" into per-source debounce windows.
+// Unknown source names and unparseable durations are reported as errors;
+// the valid entries are still returned.
+func ParseGracePeriods(spec string) (map[StatusLevel]time.Duration, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	periods := map[StatusLevel]time.Duration{}
+	var errs []string
+	for _, entry := range strings.Split(spec, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			errs = append(errs, fmt.Sprintf("invalid entry %q", entry))
+			continue
+		}
+		level, ok := statusLevelByName(parts[0])
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown status source %q", parts[0]))
+			continue
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil || d < 0 {
+			errs = append(errs, fmt.Sprintf("invalid duration %q for %s", parts[1], parts[0]))
+			continue
+		}
+		periods[level] = d
+	}
+	if len(errs) > 0 {
+		return periods, fmt.Errorf("invalid grace-period spec: %s", strings.Join(errs, "; "))
+	}
+	return periods, nil
+}
+
+// SetDegradedGracePeriods configures per-source debounce windows, keyed by
+// StatusLevel name. While a source with a grace period has been unhealthy for
+// less than its window, the operator reports Progressing instead of Degraded,
+// so brief pod disruptions (node reboots, evictions) do not flap the Degraded
+// condition. A nil or empty map disables debouncing.
+func (status *StatusManager) SetDegradedGracePeriods(periods map[StatusLevel]time.Duration) {
+	status.Lock()
+	defer status.Unlock()
+	status.gracePeriods = periods
+}
+
+// degradedPending implements the debounce: it returns true if the failure of
+// statusLevel is still within its grace period, in which case it has published
+// a Progressing condition describing the pending failure. Must be called with
+// the lock held.
+func (status *StatusManager) degradedPending(statusLevel StatusLevel, reason, message string) bool {
+	grace := status.gracePeriods[statusLevel]
+	if grace <= 0 {
+		return false
+	}
+	since, ok := status.degradedSince[statusLevel]
+	if !ok {
+		since = time.Now()
+		if status.degradedSince == nil {
+			status.degradedSince = map[StatusLevel]time.Time{}
+		}
+		status.degradedSince[statusLevel] = since
+	}
+	if time.Since(since) >= grace {
+		return false
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    operv1.OperatorStatusTypeProgressing,
+		Status:  operv1.ConditionTrue,
+		Reason:  "DegradedGracePeriod",
+		Message: fmt.Sprintf("%s (unhealthy since %s, reporting Degraded after %v): %s", reason, since.Format(time.RFC3339), grace, message),
+	})
+	return true
+}
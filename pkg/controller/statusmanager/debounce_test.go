@@ -0,0 +1,181 @@
+package statusmanager
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseGracePeriods(t *testing.T) {
+	testcases := []struct {
+		name    string
+		spec    string
+		want    map[StatusLevel]time.Duration
+		wantErr string
+	}{
+		{
+			name: "empty",
+			spec: "",
+			want: nil,
+		},
+		{
+			name: "single entry",
+			spec: "PodDeployment=2m",
+			want: map[StatusLevel]time.Duration{PodDeployment: 2 * time.Minute},
+		},
+		{
+			name: "multiple entries with whitespace",
+			spec: "PodDeployment=2m, RolloutHung=5m",
+			want: map[StatusLevel]time.Duration{
+				PodDeployment: 2 * time.Minute,
+				RolloutHung:   5 * time.Minute,
+			},
+		},
+		{
+			name:    "unknown source",
+			spec:    "NotASource=2m",
+			want:    map[StatusLevel]time.Duration{},
+			wantErr: `unknown status source "NotASource"`,
+		},
+		{
+			name:    "malformed duration",
+			spec:    "PodDeployment=soon",
+			want:    map[StatusLevel]time.Duration{},
+			wantErr: `invalid duration "soon" for PodDeployment`,
+		},
+		{
+			name:    "negative duration",
+			spec:    "PodDeployment=-2m",
+			want:    map[StatusLevel]time.Duration{},
+			wantErr: `invalid duration "-2m" for PodDeployment`,
+		},
+		{
+			name:    "entry without a duration",
+			spec:    "PodDeployment",
+			want:    map[StatusLevel]time.Duration{},
+			wantErr: `invalid entry "PodDeployment"`,
+		},
+		{
+			name:    "valid entries survive invalid ones",
+			spec:    "RolloutHung=5m,NotASource=2m",
+			want:    map[StatusLevel]time.Duration{RolloutHung: 5 * time.Minute},
+			wantErr: `unknown status source "NotASource"`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseGracePeriods(tc.spec)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+			if len(got) != len(tc.want) || (len(got) > 0 && !reflect.DeepEqual(got, tc.want)) {
+				t.Errorf("expected periods %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDegradedGracePeriod(t *testing.T) {
+	client := fake.NewClientBuilder().WithRuntimeObjects().Build()
+	mapper := &fakeRESTMapper{}
+	status := New(client, mapper, "testing")
+
+	oc := &operv1.Network{ObjectMeta: metav1.ObjectMeta{Name: names.OPERATOR_CONFIG}}
+	if err := client.Create(context.TODO(), oc); err != nil {
+		t.Fatalf("error creating network.operator config: %v", err)
+	}
+
+	status.SetDegradedGracePeriods(map[StatusLevel]time.Duration{
+		PodDeployment: time.Hour,
+	})
+
+	// Within the grace window, the failure is reported as Progressing, not
+	// Degraded.
+	status.SetDegraded(PodDeployment, "PodsDown", "pods are down")
+	oc, err := getOC(client)
+	if err != nil {
+		t.Fatalf("error getting network.operator: %v", err)
+	}
+	if !conditionsInclude(oc.Status.Conditions, []operv1.OperatorCondition{{
+		Type:   operv1.OperatorStatusTypeProgressing,
+		Status: operv1.ConditionTrue,
+		Reason: "DegradedGracePeriod",
+	}}) {
+		t.Fatalf("expected Progressing with reason DegradedGracePeriod, got %#v", oc.Status.Conditions)
+	}
+	if conditionsInclude(oc.Status.Conditions, []operv1.OperatorCondition{{
+		Type:   operv1.OperatorStatusTypeDegraded,
+		Status: operv1.ConditionTrue,
+	}}) {
+		t.Fatalf("expected no Degraded condition within the grace window, got %#v", oc.Status.Conditions)
+	}
+
+	// A source without a grace period still degrades immediately.
+	status.SetDegraded(OperatorConfig, "ApplyError", "apply failed")
+	oc, err = getOC(client)
+	if err != nil {
+		t.Fatalf("error getting network.operator: %v", err)
+	}
+	if !conditionsInclude(oc.Status.Conditions, []operv1.OperatorCondition{{
+		Type:   operv1.OperatorStatusTypeDegraded,
+		Status: operv1.ConditionTrue,
+		Reason: "ApplyError",
+	}}) {
+		t.Fatalf("expected immediate Degraded for an undebounced source, got %#v", oc.Status.Conditions)
+	}
+	status.SetNotDegraded(OperatorConfig)
+
+	// Once the failure has outlived its grace window, it is published as
+	// Degraded.
+	status.Lock()
+	status.degradedSince[PodDeployment] = time.Now().Add(-2 * time.Hour)
+	status.Unlock()
+	status.SetDegraded(PodDeployment, "PodsDown", "pods are down")
+	oc, err = getOC(client)
+	if err != nil {
+		t.Fatalf("error getting network.operator: %v", err)
+	}
+	if !conditionsInclude(oc.Status.Conditions, []operv1.OperatorCondition{{
+		Type:   operv1.OperatorStatusTypeDegraded,
+		Status: operv1.ConditionTrue,
+		Reason: "PodsDown",
+	}}) {
+		t.Fatalf("expected Degraded after the grace window expired, got %#v", oc.Status.Conditions)
+	}
+
+	// Recovery resets the window: the next failure starts a fresh grace
+	// period instead of degrading immediately.
+	status.SetNotDegraded(PodDeployment)
+	status.SetDegraded(PodDeployment, "PodsDown", "pods are down again")
+	oc, err = getOC(client)
+	if err != nil {
+		t.Fatalf("error getting network.operator: %v", err)
+	}
+	if !conditionsInclude(oc.Status.Conditions, []operv1.OperatorCondition{{
+		Type:   operv1.OperatorStatusTypeProgressing,
+		Status: operv1.ConditionTrue,
+		Reason: "DegradedGracePeriod",
+	}}) {
+		t.Fatalf("expected a fresh grace window after recovery, got %#v", oc.Status.Conditions)
+	}
+	if conditionsInclude(oc.Status.Conditions, []operv1.OperatorCondition{{
+		Type:   operv1.OperatorStatusTypeDegraded,
+		Status: operv1.ConditionTrue,
+	}}) {
+		t.Fatalf("expected no Degraded condition in the fresh grace window, got %#v", oc.Status.Conditions)
+	}
+}
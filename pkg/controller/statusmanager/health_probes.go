@@ -0,0 +1,84 @@
+package statusmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HealthProbePeriod is how often registered third-party health probes are run.
+var HealthProbePeriod = 30 * time.Second
+
+// healthProbeTimeout bounds a single probe invocation.
+const healthProbeTimeout = 10 * time.Second
+
+// HealthProbe is an additional operand health check contributed by a
+// component that the statusmanager does not watch directly (for example
+// cloud-network-config-controller or an external IPAM daemon). A probe
+// returns nil if the component is healthy.
+type HealthProbe func(ctx context.Context) error
+
+// RegisterHealthProbe adds a named health probe to the set that is run by
+// RunHealthProbes. Registering a probe under an existing name replaces it.
+func (status *StatusManager) RegisterHealthProbe(name string, probe HealthProbe) {
+	status.Lock()
+	defer status.Unlock()
+	if status.healthProbes == nil {
+		status.healthProbes = map[string]HealthProbe{}
+	}
+	status.healthProbes[name] = probe
+}
+
+// UnregisterHealthProbe removes a previously registered probe.
+func (status *StatusManager) UnregisterHealthProbe(name string) {
+	status.Lock()
+	defer status.Unlock()
+	delete(status.healthProbes, name)
+}
+
+// RunHealthProbes runs the registered probes every HealthProbePeriod until
+// the context is cancelled, feeding failures into the Degraded condition via
+// the ExternalHealth status level. It is meant to be started as a goroutine
+// alongside the controllers.
+func (status *StatusManager) RunHealthProbes(ctx context.Context) {
+	ticker := time.NewTicker(HealthProbePeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status.SetFromHealthProbes(ctx)
+		}
+	}
+}
+
+// SetFromHealthProbes runs all registered probes once and updates the
+// ExternalHealth degraded state from the results.
+func (status *StatusManager) SetFromHealthProbes(ctx context.Context) {
+	status.Lock()
+	probes := make(map[string]HealthProbe, len(status.healthProbes))
+	for name, probe := range status.healthProbes {
+		probes[name] = probe
+	}
+	status.Unlock()
+
+	failures := []string{}
+	for name, probe := range probes {
+		probeCtx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+		err := probe(probeCtx)
+		cancel()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("health probe %q failed: %v", name, err))
+		}
+	}
+	sort.Strings(failures)
+
+	if len(failures) > 0 {
+		status.SetDegraded(ExternalHealth, "HealthProbeFailed", strings.Join(failures, "\n"))
+	} else {
+		status.SetNotDegraded(ExternalHealth)
+	}
+}
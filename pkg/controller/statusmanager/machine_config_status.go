@@ -0,0 +1,114 @@
+package statusmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	operv1 "github.com/openshift/api/operator/v1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var machineConfigPoolListGVK = schema.GroupVersionKind{
+	Group:   "machineconfiguration.openshift.io",
+	Version: "v1",
+	Kind:    "MachineConfigPoolList",
+}
+
+// SetMachineConfigs records the names of the MachineConfigs rendered by the
+// operator, so that rollouts of the pools they belong to can gate Progressing.
+func (status *StatusManager) SetMachineConfigs(machineConfigs []string) {
+	status.Lock()
+	defer status.Unlock()
+	status.machineConfigs = machineConfigs
+}
+
+// SetFromMachineConfigPools sets Progressing while any MachineConfigPool
+// affected by a CNO-rendered MachineConfig (e.g. IPsec extensions) has not
+// finished updating, with a per-pool progress message. The MachineConfigPool
+// API is accessed unstructured since not every cluster runs the
+// machine-config-operator.
+func (status *StatusManager) SetFromMachineConfigPools() {
+	status.Lock()
+	defer status.Unlock()
+
+	if len(status.machineConfigs) == 0 {
+		status.clearMachineConfigProgressing()
+		return
+	}
+
+	pools := &uns.UnstructuredList{}
+	pools.SetGroupVersionKind(machineConfigPoolListGVK)
+	if err := status.client.List(context.TODO(), pools); err != nil {
+		if meta.IsNoMatchError(err) {
+			// No machine-config-operator on this cluster.
+			status.clearMachineConfigProgressing()
+			return
+		}
+		log.Printf("Error listing MachineConfigPools: %v", err)
+		return
+	}
+
+	rendered := map[string]bool{}
+	for _, mc := range status.machineConfigs {
+		rendered[mc] = true
+	}
+
+	updating := []string{}
+	for _, pool := range pools.Items {
+		if !poolContainsMachineConfig(&pool, rendered) {
+			continue
+		}
+		machineCount, _, _ := uns.NestedInt64(pool.Object, "status", "machineCount")
+		updatedCount, _, _ := uns.NestedInt64(pool.Object, "status", "updatedMachineCount")
+		if updatedCount < machineCount {
+			updating = append(updating,
+				fmt.Sprintf("%s (%d/%d nodes updated)", pool.GetName(), updatedCount, machineCount))
+		}
+	}
+
+	if len(updating) == 0 {
+		status.clearMachineConfigProgressing()
+		return
+	}
+	status.machineConfigPoolsUpdating = true
+	status.set(false, operv1.OperatorCondition{
+		Type:    operv1.OperatorStatusTypeProgressing,
+		Status:  operv1.ConditionTrue,
+		Reason:  "MachineConfigPoolsUpdating",
+		Message: fmt.Sprintf("MachineConfigPools affected by network MachineConfigs are still updating: %s", strings.Join(updating, ", ")),
+	})
+}
+
+// clearMachineConfigProgressing clears Progressing only if this subsystem set
+// it, so it doesn't stomp on rollout-based Progressing from the pod watcher.
+func (status *StatusManager) clearMachineConfigProgressing() {
+	if !status.machineConfigPoolsUpdating {
+		return
+	}
+	status.machineConfigPoolsUpdating = false
+	status.set(false, operv1.OperatorCondition{
+		Type:   operv1.OperatorStatusTypeProgressing,
+		Status: operv1.ConditionFalse,
+	})
+}
+
+// poolContainsMachineConfig reports whether the pool's current configuration
+// includes one of the rendered MachineConfigs.
+func poolContainsMachineConfig(pool *uns.Unstructured, rendered map[string]bool) bool {
+	sources, _, _ := uns.NestedSlice(pool.Object, "status", "configuration", "source")
+	for _, src := range sources {
+		srcMap, ok := src.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := srcMap["name"].(string); ok && rendered[name] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package statusmanager
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// operandDesiredPods reports the number of pods each managed DaemonSet or
+// Deployment wants to run.
+var operandDesiredPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_operand_desired_pods",
+	Help: "Number of pods desired by a managed DaemonSet or Deployment.",
+}, []string{"kind", "namespace", "name"})
+
+// operandUpdatedPods reports how many of an operand's pods are running the
+// latest template, so rollout progress can be graphed during upgrades.
+var operandUpdatedPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_operand_updated_pods",
+	Help: "Number of pods of a managed DaemonSet or Deployment running the latest template.",
+}, []string{"kind", "namespace", "name"})
+
+// operandAvailablePods reports how many of an operand's pods are available.
+var operandAvailablePods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_operand_available_pods",
+	Help: "Number of available pods of a managed DaemonSet or Deployment.",
+}, []string{"kind", "namespace", "name"})
+
+func init() {
+	prometheus.MustRegister(operandDesiredPods)
+	prometheus.MustRegister(operandUpdatedPods)
+	prometheus.MustRegister(operandAvailablePods)
+}
@@ -0,0 +1,140 @@
+package statusmanager
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nodePoolLabel is the label HyperShift puts on Nodes to record which
+// NodePool they belong to.
+const nodePoolLabel = "hypershift.openshift.io/nodePool"
+
+// NodePoolStatus is the per-NodePool health of the managed DaemonSets, as
+// published in the NODEPOOL_STATUS_CONFIGMAP. It lets hosted-cluster admins
+// see which nodepool's pods are failing instead of a single aggregated
+// condition.
+type NodePoolStatus struct {
+	UpdatedAt string                      `json:"updatedAt"`
+	NodePools map[string]NodePoolOperands `json:"nodePools"`
+}
+
+// NodePoolOperands maps a DaemonSet (namespace/name) to its pod health on
+// one NodePool's nodes.
+type NodePoolOperands map[string]NodePoolOperandStatus
+
+// NodePoolOperandStatus is the pod health of one DaemonSet on one NodePool.
+type NodePoolOperandStatus struct {
+	ReadyPods   int      `json:"readyPods"`
+	TotalPods   int      `json:"totalPods"`
+	FailingPods []string `json:"failingPods,omitempty"`
+}
+
+// SetFromNodePools publishes per-NodePool operand health for the managed
+// DaemonSets. It is a no-op on clusters whose nodes carry no NodePool label
+// (i.e. anything that is not a HyperShift hosted cluster).
+func (status *StatusManager) SetFromNodePools() {
+	status.Lock()
+	daemonSets := make([]types.NamespacedName, len(status.daemonSets))
+	copy(daemonSets, status.daemonSets)
+	status.Unlock()
+
+	nodes := &v1.NodeList{}
+	if err := status.client.List(context.TODO(), nodes, client.HasLabels{nodePoolLabel}); err != nil {
+		log.Printf("Error listing NodePool nodes (continuing): %v", err)
+		return
+	}
+	if len(nodes.Items) == 0 {
+		return
+	}
+	nodePoolByNode := map[string]string{}
+	for _, node := range nodes.Items {
+		nodePoolByNode[node.Name] = node.Labels[nodePoolLabel]
+	}
+
+	report := NodePoolStatus{
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		NodePools: map[string]NodePoolOperands{},
+	}
+	for _, dsName := range daemonSets {
+		ds := &appsv1.DaemonSet{}
+		if err := status.client.Get(context.TODO(), dsName, ds); err != nil {
+			continue
+		}
+		pods := &v1.PodList{}
+		err := status.client.List(context.TODO(), pods, client.InNamespace(dsName.Namespace), client.MatchingLabels(ds.Spec.Selector.MatchLabels))
+		if err != nil {
+			log.Printf("Error listing pods of DaemonSet %q (continuing): %v", dsName.String(), err)
+			continue
+		}
+		for _, pod := range pods.Items {
+			pool, ok := nodePoolByNode[pod.Spec.NodeName]
+			if !ok {
+				continue
+			}
+			if report.NodePools[pool] == nil {
+				report.NodePools[pool] = NodePoolOperands{}
+			}
+			st := report.NodePools[pool][dsName.String()]
+			st.TotalPods++
+			if podReady(&pod) {
+				st.ReadyPods++
+			} else {
+				st.FailingPods = append(st.FailingPods, pod.Name)
+			}
+			report.NodePools[pool][dsName.String()] = st
+		}
+	}
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		log.Printf("Failed to marshal NodePool status (continuing): %v", err)
+		return
+	}
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &v1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.NODEPOOL_STATUS_CONFIGMAP}
+		err := status.client.Get(context.TODO(), nsn, cm)
+		if errors.IsNotFound(err) {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: map[string]string{"status": string(raw)},
+			}
+			return status.client.Create(context.TODO(), cm)
+		} else if err != nil {
+			return err
+		}
+		if cm.Data["status"] == string(raw) {
+			return nil
+		}
+		cm.Data = map[string]string{"status": string(raw)}
+		return status.client.Update(context.TODO(), cm)
+	})
+	if err != nil {
+		log.Printf("Failed to publish NodePool status (continuing): %v", err)
+	}
+}
+
+// podReady reports whether the pod's Ready condition is true.
+func podReady(pod *v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -84,6 +84,10 @@ func (status *StatusManager) SetFromPods() {
 
 		dsProgressing := false
 
+		operandDesiredPods.WithLabelValues("DaemonSet", dsName.Namespace, dsName.Name).Set(float64(ds.Status.DesiredNumberScheduled))
+		operandUpdatedPods.WithLabelValues("DaemonSet", dsName.Namespace, dsName.Name).Set(float64(ds.Status.UpdatedNumberScheduled))
+		operandAvailablePods.WithLabelValues("DaemonSet", dsName.Namespace, dsName.Name).Set(float64(ds.Status.NumberAvailable))
+
 		if isNonCritical(ds) && ds.Status.NumberReady == 0 && !status.everAvailable {
 			progressing = append(progressing, fmt.Sprintf("DaemonSet %q is waiting for other operators to become ready", dsName.String()))
 			dsProgressing = true
@@ -148,9 +152,16 @@ func (status *StatusManager) SetFromPods() {
 
 		depProgressing := false
 
+		operandDesiredPods.WithLabelValues("Deployment", depName.Namespace, depName.Name).Set(float64(dep.Status.Replicas))
+		operandUpdatedPods.WithLabelValues("Deployment", depName.Namespace, depName.Name).Set(float64(dep.Status.UpdatedReplicas))
+		operandAvailablePods.WithLabelValues("Deployment", depName.Namespace, depName.Name).Set(float64(dep.Status.AvailableReplicas))
+
 		if isNonCritical(dep) && dep.Status.UnavailableReplicas > 0 && !status.everAvailable {
 			progressing = append(progressing, fmt.Sprintf("Deployment %q is waiting for other operators to become ready", depName.String()))
 			depProgressing = true
+		} else if dep.Status.UpdatedReplicas < dep.Status.Replicas {
+			progressing = append(progressing, fmt.Sprintf("Deployment %q update is rolling out (%d out of %d updated)", depName.String(), dep.Status.UpdatedReplicas, dep.Status.Replicas))
+			depProgressing = true
 		} else if dep.Status.UnavailableReplicas > 0 {
 			progressing = append(progressing, fmt.Sprintf("Deployment %q is not available (awaiting %d nodes)", depName.String(), dep.Status.UnavailableReplicas))
 			depProgressing = true
@@ -196,6 +207,8 @@ func (status *StatusManager) SetFromPods() {
 		}
 	}
 
+	status.podProgressing = strings.Join(progressing, "\n")
+
 	status.setNotDegraded(PodDeployment)
 	if err := status.setLastPodState(daemonsetStates, deploymentStates); err != nil {
 		log.Printf("Failed to set pod state (continuing): %+v\n", err)
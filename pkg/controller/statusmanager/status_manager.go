@@ -6,7 +6,10 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/ghodss/yaml"
 
@@ -41,6 +44,13 @@ const (
 	EgressRouterConfig
 	RolloutHung
 	CertificateSigner
+	ExternalHealth
+	EgressIPConfig
+	ExternalGatewayConfig
+	MTUConfig
+	IPsecConfig
+	MigrationConfig
+	DashboardConfig
 	maxStatusLevel
 )
 
@@ -58,6 +68,29 @@ type StatusManager struct {
 	daemonSets     []types.NamespacedName
 	deployments    []types.NamespacedName
 	relatedObjects []configv1.ObjectReference
+
+	// machineConfigs are the names of rendered MachineConfigs whose pool
+	// rollouts gate Progressing.
+	machineConfigs             []string
+	machineConfigPoolsUpdating bool
+
+	// podProgressing is the most recent pod-status Progressing message, kept
+	// for the structured status report.
+	podProgressing string
+
+	// gracePeriods are per-source debounce windows before an unhealthy source
+	// is reported Degraded; degradedSince tracks when each source first failed.
+	gracePeriods  map[StatusLevel]time.Duration
+	degradedSince map[StatusLevel]time.Time
+
+	// healthProbes are additional, registerable operand health checks run by
+	// RunHealthProbes.
+	healthProbes map[string]HealthProbe
+
+	// certExpiryProblems records, per source, the managed certificates that
+	// are close to expiry while their rotation is failing. Any entry turns
+	// Upgradeable False.
+	certExpiryProblems map[StatusLevel][]string
 }
 
 func New(client client.Client, mapper meta.RESTMapper, name string) *StatusManager {
@@ -174,12 +207,7 @@ func (status *StatusManager) set(reachedAvailableLevel bool, conditions ...operv
 			)
 		}
 
-		v1helpers.SetOperatorCondition(&oc.Status.Conditions,
-			operv1.OperatorCondition{
-				Type:   operv1.OperatorStatusTypeUpgradeable,
-				Status: operv1.ConditionTrue,
-			},
-		)
+		v1helpers.SetOperatorCondition(&oc.Status.Conditions, status.upgradeableCondition())
 
 		operStatus = &oc.Status
 
@@ -268,6 +296,7 @@ func (status *StatusManager) set(reachedAvailableLevel bool, conditions ...operv
 
 // syncDegraded syncs the current Degraded status
 func (status *StatusManager) syncDegraded() {
+	defer status.syncStructuredStatus()
 	for _, c := range status.failing {
 		if c != nil {
 			status.set(false, *c)
@@ -284,6 +313,9 @@ func (status *StatusManager) syncDegraded() {
 }
 
 func (status *StatusManager) setDegraded(statusLevel StatusLevel, reason, message string) {
+	if status.degradedPending(statusLevel, reason, message) {
+		return
+	}
 	status.failing[statusLevel] = &operv1.OperatorCondition{
 		Type:    operv1.OperatorStatusTypeDegraded,
 		Status:  operv1.ConditionTrue,
@@ -294,6 +326,7 @@ func (status *StatusManager) setDegraded(statusLevel StatusLevel, reason, messag
 }
 
 func (status *StatusManager) setNotDegraded(statusLevel StatusLevel) {
+	delete(status.degradedSince, statusLevel)
 	if status.failing[statusLevel] != nil {
 		status.failing[statusLevel] = nil
 	}
@@ -312,6 +345,364 @@ func (status *StatusManager) SetNotDegraded(statusLevel StatusLevel) {
 	status.setNotDegraded(statusLevel)
 }
 
+// SetPreservedFields publishes a UserPreservedFields condition listing the
+// object fields that the operator is deliberately not reconciling because of
+// the preserve-fields annotation, or clears it if the list is empty.
+func (status *StatusManager) SetPreservedFields(preserved []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(preserved) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "UserPreservedFields",
+			Status: operv1.ConditionFalse,
+		})
+		return
+	}
+	sort.Strings(preserved)
+	status.set(false, operv1.OperatorCondition{
+		Type:    "UserPreservedFields",
+		Status:  operv1.ConditionTrue,
+		Reason:  "PreserveFieldsAnnotation",
+		Message: fmt.Sprintf("The following fields are not reconciled due to the %s annotation: %s", names.PreserveFieldsAnnotation, strings.Join(preserved, "; ")),
+	})
+}
+
+// SetIPsecRollout publishes an IPsecRolloutProgressing condition while an
+// IPsec enable/disable rollout is converging, or clears it once all nodes
+// encrypt.
+func (status *StatusManager) SetIPsecRollout(progressing bool, reason, message string) {
+	status.Lock()
+	defer status.Unlock()
+	if !progressing {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "IPsecRolloutProgressing",
+			Status: operv1.ConditionFalse,
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "IPsecRolloutProgressing",
+		Status:  operv1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// SetFeatureGatesChanged publishes a FeatureGatesChanged condition recording
+// the most recent change to the network-relevant feature gates, so the
+// resulting re-render is visible in the operator status and not only in pod
+// logs. An empty change list clears the condition: the running configuration
+// was rendered from the observed gates.
+func (status *StatusManager) SetFeatureGatesChanged(changed []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(changed) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:    "FeatureGatesChanged",
+			Status:  operv1.ConditionFalse,
+			Reason:  "FeatureGateObservation",
+			Message: "The network-relevant feature gates match the running configuration",
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "FeatureGatesChanged",
+		Status:  operv1.ConditionTrue,
+		Reason:  "FeatureGateObservation",
+		Message: fmt.Sprintf("The following network-relevant feature gates changed and triggered a re-render: %s", strings.Join(changed, ", ")),
+	})
+}
+
+// SetAdditionalNetworkProblems publishes an AdditionalNetworksApplied
+// condition: True when every configured additional network's NAD exists in
+// an existing namespace, False listing the problems otherwise.
+func (status *StatusManager) SetAdditionalNetworkProblems(problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(problems) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "AdditionalNetworksApplied",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "AdditionalNetworksApplied",
+		Status:  operv1.ConditionFalse,
+		Reason:  "AdditionalNetworkNotApplied",
+		Message: fmt.Sprintf("The following additional networks are not fully applied: %s", strings.Join(problems, "; ")),
+	})
+}
+
+// SetCAInjectionProblems publishes a TrustedCABundleInjected condition: True
+// when every labeled ConfigMap carries the current trust bundle, False
+// listing the ConfigMaps whose injection is failing otherwise.
+func (status *StatusManager) SetCAInjectionProblems(problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(problems) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "TrustedCABundleInjected",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "TrustedCABundleInjected",
+		Status:  operv1.ConditionFalse,
+		Reason:  "InjectionFailing",
+		Message: fmt.Sprintf("Injection of the trusted CA bundle is failing for: %s", strings.Join(problems, "; ")),
+	})
+}
+
+// upgradeableCondition computes the Upgradeable condition: True unless a
+// managed certificate is close to expiry while its rotation is failing, in
+// which case an upgrade could leave the cluster with lapsed certificates.
+// Must be called with the lock held.
+func (status *StatusManager) upgradeableCondition() operv1.OperatorCondition {
+	problems := []string{}
+	for _, p := range status.certExpiryProblems {
+		problems = append(problems, p...)
+	}
+	if len(problems) == 0 {
+		return operv1.OperatorCondition{
+			Type:   operv1.OperatorStatusTypeUpgradeable,
+			Status: operv1.ConditionTrue,
+		}
+	}
+	sort.Strings(problems)
+	return operv1.OperatorCondition{
+		Type:    operv1.OperatorStatusTypeUpgradeable,
+		Status:  operv1.ConditionFalse,
+		Reason:  "CertificateExpiring",
+		Message: fmt.Sprintf("The following certificates are close to expiry and their rotation is failing: %s", strings.Join(problems, "; ")),
+	}
+}
+
+// SetCertificateExpiryProblems records the certificates managed by the given
+// source that are close to expiry while their rotation is failing, or clears
+// them. Any recorded problem publishes a CertificateRotationHealthy=False
+// warning and turns Upgradeable False; an expiring certificate whose rotation
+// still works resolves itself and is not reported here.
+func (status *StatusManager) SetCertificateExpiryProblems(statusLevel StatusLevel, problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if status.certExpiryProblems == nil {
+		status.certExpiryProblems = map[StatusLevel][]string{}
+	}
+	if len(problems) == 0 {
+		delete(status.certExpiryProblems, statusLevel)
+	} else {
+		sort.Strings(problems)
+		status.certExpiryProblems[statusLevel] = problems
+	}
+
+	cond := status.upgradeableCondition()
+	if cond.Status == operv1.ConditionTrue {
+		status.set(false, cond, operv1.OperatorCondition{
+			Type:   "CertificateRotationHealthy",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	status.set(false, cond, operv1.OperatorCondition{
+		Type:    "CertificateRotationHealthy",
+		Status:  operv1.ConditionFalse,
+		Reason:  cond.Reason,
+		Message: cond.Message,
+	})
+}
+
+// SetPlatformAddressesPropagating publishes a PlatformAddressesPropagated
+// condition: False while a change to the platform addresses embedded in
+// rendered manifests (API/ingress VIPs, load balancer configuration) is
+// still being re-rendered, True once the change has propagated.
+func (status *StatusManager) SetPlatformAddressesPropagating(changed []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(changed) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "PlatformAddressesPropagated",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "PlatformAddressesPropagated",
+		Status:  operv1.ConditionFalse,
+		Reason:  "InfrastructureChanged",
+		Message: fmt.Sprintf("The following platform addresses changed and are being re-propagated: %s", strings.Join(changed, ", ")),
+	})
+}
+
+// SetCloudCredentialProblems publishes a CloudCredentialsHealthy condition:
+// True when the cloud credentials used for egress IP assignment are current
+// and sufficient, False listing expiry or permission problems otherwise.
+func (status *StatusManager) SetCloudCredentialProblems(problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(problems) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "CloudCredentialsHealthy",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	sort.Strings(problems)
+	status.set(false, operv1.OperatorCondition{
+		Type:    "CloudCredentialsHealthy",
+		Status:  operv1.ConditionFalse,
+		Reason:  "CloudCredentialError",
+		Message: fmt.Sprintf("Cloud credential problems affecting egress IP assignment: %s", strings.Join(problems, "; ")),
+	})
+}
+
+// SetIngressConflicts publishes an IngressConfigCompatible condition: True
+// when the ingress controller configuration is compatible with the network
+// configuration, False listing the detected conflicts otherwise.
+func (status *StatusManager) SetIngressConflicts(problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(problems) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "IngressConfigCompatible",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	sort.Strings(problems)
+	status.set(false, operv1.OperatorCondition{
+		Type:    "IngressConfigCompatible",
+		Status:  operv1.ConditionFalse,
+		Reason:  "IngressNetworkConflict",
+		Message: fmt.Sprintf("The ingress controller configuration conflicts with the network configuration: %s", strings.Join(problems, "; ")),
+	})
+}
+
+// SetNodeCertificateProblems publishes a NodeCertificatesIssued condition:
+// True when every node has a current identity certificate, False listing the
+// nodes whose certificate is missing or stale otherwise.
+func (status *StatusManager) SetNodeCertificateProblems(problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(problems) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "NodeCertificatesIssued",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	sort.Strings(problems)
+	status.set(false, operv1.OperatorCondition{
+		Type:    "NodeCertificatesIssued",
+		Status:  operv1.ConditionFalse,
+		Reason:  "NodeCertificateMissing",
+		Message: fmt.Sprintf("The following nodes do not have a current identity certificate: %s", strings.Join(problems, "; ")),
+	})
+}
+
+// SetPodNetworkLatencyProblems publishes a PodNetworkLatencyAcceptable
+// condition: True when every sampled node pool sets up pod sandbox
+// networking within the configured threshold, False listing the slow pools
+// otherwise.
+func (status *StatusManager) SetPodNetworkLatencyProblems(problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(problems) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "PodNetworkLatencyAcceptable",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	sort.Strings(problems)
+	status.set(false, operv1.OperatorCondition{
+		Type:    "PodNetworkLatencyAcceptable",
+		Status:  operv1.ConditionFalse,
+		Reason:  "PodNetworkLatencyHigh",
+		Message: fmt.Sprintf("Pod network setup latency exceeds the configured threshold: %s", strings.Join(problems, "; ")),
+	})
+}
+
+// SetProxyUnreachable publishes a ProxyReachable condition: True when every
+// configured proxy endpoint accepts connections, False listing the problems
+// otherwise. This is deliberately a warning, not a Degraded condition; the
+// dependent operators degrade on their own if the proxy is really down.
+func (status *StatusManager) SetProxyUnreachable(problems []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(problems) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "ProxyReachable",
+			Status: operv1.ConditionTrue,
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "ProxyReachable",
+		Status:  operv1.ConditionFalse,
+		Reason:  "ProxyUnreachable",
+		Message: strings.Join(problems, "; "),
+	})
+}
+
+// SetConnectivityDegraded publishes a warning-level ConnectivityDegraded
+// condition summarizing cluster-wide connectivity check failures, or clears
+// it when reason is empty. It is deliberately not a Degraded condition:
+// connectivity checks fail for reasons outside the operator's control.
+func (status *StatusManager) SetConnectivityDegraded(reason, message string) {
+	status.Lock()
+	defer status.Unlock()
+	if reason == "" {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "ConnectivityDegraded",
+			Status: operv1.ConditionFalse,
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "ConnectivityDegraded",
+		Status:  operv1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// SetDrifted publishes a ManagedObjectsDrifted condition listing rendered
+// objects that have been modified out-of-band, or clears it if the list is
+// empty.
+func (status *StatusManager) SetDrifted(drifted []string) {
+	status.Lock()
+	defer status.Unlock()
+	if len(drifted) == 0 {
+		status.set(false, operv1.OperatorCondition{
+			Type:   "ManagedObjectsDrifted",
+			Status: operv1.ConditionFalse,
+		})
+		return
+	}
+	status.set(false, operv1.OperatorCondition{
+		Type:    "ManagedObjectsDrifted",
+		Status:  operv1.ConditionTrue,
+		Reason:  "OutOfBandModification",
+		Message: fmt.Sprintf("The following managed objects have been modified out-of-band: %s", strings.Join(drifted, ", ")),
+	})
+}
+
+// SetUnsafeChangeAcknowledged records that an administrator explicitly
+// acknowledged an unsafe configuration change, identified by its hash, so the
+// override is visible in the operator status rather than only in pod logs.
+func (status *StatusManager) SetUnsafeChangeAcknowledged(hash string) {
+	status.Lock()
+	defer status.Unlock()
+	status.set(false, operv1.OperatorCondition{
+		Type:    "UnsafeChangeAcknowledged",
+		Status:  operv1.ConditionTrue,
+		Reason:  "AcknowledgmentAnnotation",
+		Message: fmt.Sprintf("An unsafe configuration change (hash %s) was applied because it was acknowledged by annotation", hash),
+	})
+}
+
 func (status *StatusManager) SetDaemonSets(daemonSets []types.NamespacedName) {
 	status.Lock()
 	defer status.Unlock()
@@ -0,0 +1,143 @@
+package statusmanager
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// StatusSource is one entry in the structured status report: a single
+// subsystem that is currently Degraded or Progressing, with its machine
+// readable reason code.
+type StatusSource struct {
+	Source  string `json:"source"`
+	State   string `json:"state"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// StructuredStatus is the operator's condition state in a form that tooling
+// can consume without parsing free-form condition messages. It is published
+// as JSON in the STRUCTURED_STATUS_CONFIGMAP.
+type StructuredStatus struct {
+	UpdatedAt string         `json:"updatedAt"`
+	Sources   []StatusSource `json:"sources"`
+}
+
+// statusLevelName returns the stable name used for a StatusLevel in the
+// structured status report.
+func statusLevelName(level StatusLevel) string {
+	switch level {
+	case ClusterConfig:
+		return "ClusterConfig"
+	case OperatorConfig:
+		return "OperatorConfig"
+	case ProxyConfig:
+		return "ProxyConfig"
+	case InjectorConfig:
+		return "InjectorConfig"
+	case PodDeployment:
+		return "PodDeployment"
+	case PKIConfig:
+		return "PKIConfig"
+	case EgressRouterConfig:
+		return "EgressRouterConfig"
+	case RolloutHung:
+		return "RolloutHung"
+	case CertificateSigner:
+		return "CertificateSigner"
+	case ExternalHealth:
+		return "ExternalHealth"
+	case EgressIPConfig:
+		return "EgressIPConfig"
+	case ExternalGatewayConfig:
+		return "ExternalGatewayConfig"
+	case MTUConfig:
+		return "MTUConfig"
+	case IPsecConfig:
+		return "IPsecConfig"
+	case MigrationConfig:
+		return "MigrationConfig"
+	case DashboardConfig:
+		return "DashboardConfig"
+	default:
+		return "Unknown"
+	}
+}
+
+// syncStructuredStatus publishes the per-source condition state to the
+// structured status ConfigMap. Must be called with the lock held. Failures
+// are logged but never block condition updates.
+func (status *StatusManager) syncStructuredStatus() {
+	report := StructuredStatus{
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		Sources:   []StatusSource{},
+	}
+	for level, c := range status.failing {
+		if c == nil {
+			continue
+		}
+		report.Sources = append(report.Sources, StatusSource{
+			Source:  statusLevelName(StatusLevel(level)),
+			State:   "Degraded",
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+	if status.podProgressing != "" {
+		report.Sources = append(report.Sources, StatusSource{
+			Source:  statusLevelName(PodDeployment),
+			State:   "Progressing",
+			Reason:  "Deploying",
+			Message: status.podProgressing,
+		})
+	}
+	if status.machineConfigPoolsUpdating {
+		report.Sources = append(report.Sources, StatusSource{
+			Source: statusLevelName(PodDeployment),
+			State:  "Progressing",
+			Reason: "MachineConfigPoolsUpdating",
+		})
+	}
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		log.Printf("Failed to marshal structured status (continuing): %v", err)
+		return
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &v1.ConfigMap{}
+		nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.STRUCTURED_STATUS_CONFIGMAP}
+		err := status.client.Get(context.TODO(), nsn, cm)
+		if errors.IsNotFound(err) {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsn.Namespace,
+					Name:      nsn.Name,
+				},
+				Data: map[string]string{"status": string(raw)},
+			}
+			return status.client.Create(context.TODO(), cm)
+		} else if err != nil {
+			return err
+		}
+		if cm.Data["status"] == string(raw) {
+			return nil
+		}
+		cm.Data = map[string]string{"status": string(raw)}
+		return status.client.Update(context.TODO(), cm)
+	})
+	if err != nil {
+		log.Printf("Failed to publish structured status (continuing): %v", err)
+	}
+}
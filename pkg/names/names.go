@@ -29,6 +29,306 @@ const APPLIED_NAMESPACE = "openshift-network-operator"
 // or update. Useful when we want to make a CR for which the CRD may not exist yet.
 const IgnoreObjectErrorAnnotation = "networkoperator.openshift.io/ignore-errors"
 
+// DryRunAnnotation is an annotation on the networks.operator.openshift.io CR to request
+// that rendered manifests be written to the DRY_RUN_CONFIGMAP instead of being applied
+// to the cluster, so the effect of a configuration change can be previewed.
+const DryRunAnnotation = "networkoperator.openshift.io/dry-run"
+
+// DRY_RUN_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE where the
+// rendered manifests are stored when a dry-run render is requested.
+const DRY_RUN_CONFIGMAP = "dry-run-render"
+
+// PauseOperandAnnotation is an annotation on the networks.operator.openshift.io CR
+// holding a comma-separated list of operand names ("name" or "namespace/name") whose
+// rendered objects should not be applied. Useful for holding an operand steady
+// during incident response. Paused objects are still rendered and drift is reported.
+const PauseOperandAnnotation = "networkoperator.openshift.io/pause-operand"
+
+// DRIFT_REPORT_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE where
+// the drift detector records managed objects that were modified out-of-band.
+const DRIFT_REPORT_CONFIGMAP = "drift-report"
+
+// ApplyBarrierAnnotation is an annotation on rendered Deployments/DaemonSets that
+// turns the object into a rollout barrier: objects rendered after it are not applied
+// until the workload reports availability. The value is the wait timeout (a
+// time.Duration string); an empty value uses the default timeout.
+const ApplyBarrierAnnotation = "networkoperator.openshift.io/apply-barrier"
+
+// GCDisabledAnnotation is an annotation on the networks.operator.openshift.io CR
+// that disables garbage collection of previously-rendered objects that are no
+// longer rendered by the current configuration.
+const GCDisabledAnnotation = "networkoperator.openshift.io/disable-orphan-cleanup"
+
+// UnsafeChangeAcknowledgeAnnotation is an annotation on the
+// networks.operator.openshift.io CR whose value is the hash (reported in the
+// Degraded condition) of a configuration change rejected as unsafe. Setting it
+// tells the operator the administrator accepts the disruption and the change
+// should be applied anyway.
+const UnsafeChangeAcknowledgeAnnotation = "networkoperator.openshift.io/ack-unsafe-change"
+
+// APPLIED_HISTORY_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// holding the last few applied configurations, for rollback.
+const APPLIED_HISTORY_CONFIGMAP = "applied-history"
+
+// RollbackAnnotation is an annotation on the networks.operator.openshift.io CR
+// naming the appliedAt timestamp of a previously applied configuration (from the
+// APPLIED_HISTORY_CONFIGMAP) to roll back to.
+const RollbackAnnotation = "networkoperator.openshift.io/rollback-to"
+
+// VerifyRolloutAnnotation is an annotation on the networks.operator.openshift.io CR
+// that enables a bounded post-apply verification phase: the reconcile only reports
+// success once critical operand DaemonSets report Ready. The value is the wait
+// timeout (a time.Duration string); an empty value uses the default.
+const VerifyRolloutAnnotation = "networkoperator.openshift.io/verify-rollout"
+
+// STRUCTURED_STATUS_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// holding a machine-readable JSON report of the per-subsystem degraded and
+// progressing state, for tooling that should not parse condition messages.
+const STRUCTURED_STATUS_CONFIGMAP = "network-operator-status"
+
+// DegradedGracePeriodAnnotation is an annotation on the networks.operator.openshift.io
+// CR configuring per-source debounce windows before Degraded is reported, e.g.
+// "PodDeployment=2m,RolloutHung=5m". While within the window the pending
+// failure is reported as Progressing instead.
+const DegradedGracePeriodAnnotation = "networkoperator.openshift.io/degraded-grace-periods"
+
+// NODEPOOL_STATUS_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// holding per-NodePool operand health on HyperShift hosted clusters.
+const NODEPOOL_STATUS_CONFIGMAP = "network-operator-nodepool-status"
+
+// PreserveFieldsAnnotation is an annotation an administrator can put on a
+// CNO-managed object naming field paths (comma-separated, dot-delimited, e.g.
+// "spec.replicas,spec.template.spec.containers") that the operator must not
+// reconcile; the live values are kept on every apply.
+const PreserveFieldsAnnotation = "networkoperator.openshift.io/preserve-fields"
+
+// PruneOnRemovalAnnotation is an annotation on rendered objects that registers
+// them for pruning: when the object disappears from the render set (e.g. a
+// feature-gated operand is turned off), it is deleted even for kinds that the
+// orphan cleanup normally preserves, and even if orphan cleanup is disabled.
+const PruneOnRemovalAnnotation = "networkoperator.openshift.io/prune-on-removal"
+
+// RESOURCE_OVERRIDES_CONFIGMAP is the name of a ConfigMap in APPLIED_NAMESPACE
+// through which an administrator can override the CPU / memory requests and
+// limits of rendered operand containers.
+const RESOURCE_OVERRIDES_CONFIGMAP = "operand-resource-overrides"
+
+// OVN_INTERNAL_SUBNETS_CONFIGMAP is the name of a ConfigMap in
+// APPLIED_NAMESPACE that overrides the subnets OVN uses internally (join,
+// masquerade, transit switch), per IP family.
+const OVN_INTERNAL_SUBNETS_CONFIGMAP = "ovn-internal-subnets"
+
+// OVNInternalSubnetsAnnotation is an annotation on the OVN daemonsets holding
+// the internal subnet configuration they were rendered with, used to stage
+// subnet reconfigurations master-first.
+const OVNInternalSubnetsAnnotation = "networkoperator.openshift.io/ovn-internal-subnets"
+
+// OVN_INTERCONNECT_CONFIGMAP is the name of a ConfigMap in APPLIED_NAMESPACE
+// configuring the OVN interconnect zone layout (enabled, nodes-per-zone).
+const OVN_INTERCONNECT_CONFIGMAP = "ovn-interconnect-config"
+
+// OVNInterconnectAnnotation is an annotation on the OVN daemonsets holding the
+// zone layout they were rendered with, used to guard the single-zone <->
+// multi-zone migration.
+const OVNInterconnectAnnotation = "networkoperator.openshift.io/ovn-interconnect"
+
+// HardwareOffloadProfileLabel is the label that marks a ConfigMap in
+// APPLIED_NAMESPACE as a hardware offload profile, declaring an
+// offload-capable node pool and its ovs-hw-offload settings.
+const HardwareOffloadProfileLabel = "networkoperator.openshift.io/hw-offload-profile"
+
+// MTU_PROBE_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// where the operator reports the per-machine-pool uplink MTU probe results
+// and the cluster-wide minimum.
+const MTU_PROBE_CONFIGMAP = "network-operator-mtu"
+
+// MTUHintAnnotation is an annotation on the networks.operator.openshift.io CR
+// carrying an externally supplied host MTU (e.g. from a HostedCluster spec),
+// used as the probe result when no worker node has reported an uplink MTU.
+const MTUHintAnnotation = "networkoperator.openshift.io/mtu-hint"
+
+// MTU_MIGRATION_STATUS_CONFIGMAP is the name of the ConfigMap in
+// APPLIED_NAMESPACE where the operator publishes per-node MTU migration
+// progress and the paused flag.
+const MTU_MIGRATION_STATUS_CONFIGMAP = "mtu-migration-status"
+
+// NodeAppliedMTUAnnotation is an annotation on Node objects, published by the
+// mtu-prober DaemonSet, carrying the MTU currently applied on the node's
+// cluster-network interface.
+const NodeAppliedMTUAnnotation = "networkoperator.openshift.io/applied-mtu"
+
+// NodeMTUErrorAnnotation is an annotation on Node objects set by the
+// mtu-prober when probing the node's MTUs failed, with the failure message.
+const NodeMTUErrorAnnotation = "networkoperator.openshift.io/mtu-error"
+
+// MIGRATION_PREFLIGHT_CONFIGMAP is the name of the ConfigMap in
+// APPLIED_NAMESPACE where the operator publishes the pre-flight validation
+// report for a requested default network migration.
+const MIGRATION_PREFLIGHT_CONFIGMAP = "migration-preflight-report"
+
+// MigrationAckAnnotation is an annotation on the networks.operator.openshift.io
+// CR whose value "true" tells the operator the administrator has reviewed the
+// migration pre-flight report and accepts starting the migration despite the
+// problems it found.
+const MigrationAckAnnotation = "networkoperator.openshift.io/acknowledge-migration-risks"
+
+// NodeMigrationStateAnnotation is an annotation on Node objects set by the
+// node-side migration tooling with the node's live migration state:
+// "routes-installed", "cni-switched" or "done". Absent means pending.
+const NodeMigrationStateAnnotation = "networkoperator.openshift.io/live-migration-state"
+
+// MIGRATION_STATUS_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// where the operator publishes per-node default network migration progress.
+const MIGRATION_STATUS_CONFIGMAP = "network-migration-status"
+
+// MIGRATION_TUNING_CONFIGMAP is the name of a ConfigMap in APPLIED_NAMESPACE
+// with which administrators throttle a default network live migration:
+// "max-unavailable" (nodes converted in parallel), "pause-windows"
+// (comma-separated HH:MM-HH:MM UTC ranges during which no new nodes are
+// converted) and "namespace-order" (namespaces to convert first).
+const MIGRATION_TUNING_CONFIGMAP = "migration-tuning"
+
+// EGRESS_FIREWALL_MIGRATION_CONFIGMAP is the name of the ConfigMap in
+// APPLIED_NAMESPACE where the operator reports the verification of the
+// EgressNetworkPolicy to EgressFirewall conversion during a migration to
+// OVN-Kubernetes.
+const EGRESS_FIREWALL_MIGRATION_CONFIGMAP = "egressfirewall-migration-report"
+
+// HibernationAnnotation is an annotation on the networks.operator.openshift.io
+// CR whose value "true" scales the rendered control-plane Deployments to zero
+// replicas (e.g. while the cluster is hibernated). Removing it restores the
+// configured replica counts on the next reconcile.
+const HibernationAnnotation = "networkoperator.openshift.io/hibernate"
+
+// DebugDumpAnnotation is an annotation on the networks.operator.openshift.io
+// CR requesting a runtime debug dump of the operator. Its value is a
+// comma-separated list of "goroutine" and "heap"; the dump is written to the
+// DEBUG_DUMP_CONFIGMAP. DebugDumpHandledAnnotation records the last request
+// value acted upon, so re-annotating with a new value triggers a fresh dump.
+const DebugDumpAnnotation = "networkoperator.openshift.io/debug-dump"
+const DebugDumpHandledAnnotation = "networkoperator.openshift.io/debug-dump-handled"
+
+// DEBUG_DUMP_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// where annotation-triggered debug dumps are written.
+const DEBUG_DUMP_CONFIGMAP = "network-operator-debug-dump"
+
+// LEADER_STATUS_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// where the operator publishes the identity of the current leader.
+const LEADER_STATUS_CONFIGMAP = "network-operator-leader"
+
+// ISOLATION_POLICY_CONFIGMAP is the name of a ConfigMap in APPLIED_NAMESPACE
+// that enables rendering of NetworkPolicies restricting traffic to the
+// CNO-managed control-plane components ("enabled"), with an optional
+// "allow-from" list of additional namespace label selectors.
+const ISOLATION_POLICY_CONFIGMAP = "network-isolation-config"
+
+// PRIMARY_INTERFACE_POLICY_CONFIGMAP is the name of a ConfigMap in
+// APPLIED_NAMESPACE describing how nodes select the primary interface the
+// default network attaches to, for clusters whose NIC naming defeats
+// auto-detection.
+const PRIMARY_INTERFACE_POLICY_CONFIGMAP = "primary-interface-policy"
+
+// POD_LATENCY_SAMPLER_CONFIGMAP is the name of a ConfigMap in
+// APPLIED_NAMESPACE that enables the pod network latency sampler: an
+// "interval" between sampling passes and a "warning-threshold" above which
+// sandbox network setup latency raises a warning condition.
+const POD_LATENCY_SAMPLER_CONFIGMAP = "pod-latency-sampler"
+
+// NETWORK_DIAGNOSTICS_CONFIGMAP is the name of a ConfigMap in
+// APPLIED_NAMESPACE that tunes the network diagnostics subsystem: a "checks"
+// list selecting which connectivity check groups are generated, an
+// "external-targets" list of extra name=host:port endpoints to probe, and a
+// "probe-interval" overriding how often the checkers probe each target.
+const NETWORK_DIAGNOSTICS_CONFIGMAP = "network-diagnostics-config"
+
+// WHEREABOUTS_CONFIGMAP is the name of a ConfigMap in APPLIED_NAMESPACE that
+// tunes the whereabouts IPAM plugin: "log-level" for its logging verbosity,
+// and "reconciler-enabled"/"reconciler-schedule" controlling the cron
+// expression for its stale IP reconciliation.
+const WHEREABOUTS_CONFIGMAP = "whereabouts-config"
+
+// DEFAULT_NETWORK_OVERRIDES_CONFIGMAP is the name of a ConfigMap in
+// APPLIED_NAMESPACE mapping namespace names to the NetworkAttachmentDefinition
+// (name or namespace/name) multus should use as that namespace's default
+// network instead of the cluster network.
+const DEFAULT_NETWORK_OVERRIDES_CONFIGMAP = "default-network-overrides"
+
+// SysctlAllowlistRequestLabel marks a ConfigMap whose "sysctls" key requests
+// additional patterns for the cni-sysctl-allowlist. Requests are validated
+// against a deny pattern list before being merged into the rendered
+// allowlist.
+const SysctlAllowlistRequestLabel = "networkoperator.openshift.io/sysctl-allowlist-request"
+
+// OPERAND_NOPROXY_CONFIGMAP is the name of a ConfigMap in APPLIED_NAMESPACE
+// whose "no-proxy" key lists extra noProxy entries for CNO-rendered operands
+// only, merged into OPERAND_PROXY_CONFIGMAP instead of the cluster-wide
+// Proxy resource.
+const OPERAND_NOPROXY_CONFIGMAP = "operand-noproxy-config"
+
+// OPERAND_PROXY_CONFIGMAP is the name of the managed ConfigMap in
+// APPLIED_NAMESPACE holding the effective HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+// settings for CNO-rendered operands.
+const OPERAND_PROXY_CONFIGMAP = "operand-proxy"
+
+// CSR_SIGNER_EXTERNAL_CA_SECRET is the name of an optional Secret in
+// APPLIED_NAMESPACE configuring an externally managed CA for the CSR signer,
+// either via signing credentials (tls.crt/tls.key) or a signing webhook URL
+// (signing-webhook-url).
+const CSR_SIGNER_EXTERNAL_CA_SECRET = "csr-signer-external-ca"
+
+// MULTICAST_MIGRATION_CONFIGMAP is the name of the ConfigMap in
+// APPLIED_NAMESPACE with the multicast enablement dry-run report for a
+// migration to OVN-Kubernetes.
+const MULTICAST_MIGRATION_CONFIGMAP = "multicast-migration-report"
+
+// MigrationRollbackAnnotation is an annotation on the
+// networks.operator.openshift.io CR whose value "true" requests that an
+// in-flight default network migration be unwound: migration rendering stops,
+// node migration state is reverted and the original operands are restored.
+const MigrationRollbackAnnotation = "networkoperator.openshift.io/rollback-migration"
+
+// NodeUplinkMTUAnnotation is an annotation on Node objects carrying the MTU
+// of the node's uplink interface, published by node-side tooling and
+// validated by the operator against the configured cluster network MTU.
+const NodeUplinkMTUAnnotation = "networkoperator.openshift.io/uplink-mtu"
+
+// IPSEC_CONFIG_CONFIGMAP is the name of a ConfigMap in APPLIED_NAMESPACE
+// that overrides the IPsec crypto parameters (IKE/ESP proposals, PFS group,
+// SA lifetime) rendered into the ovn-ipsec libreswan configuration.
+const IPSEC_CONFIG_CONFIGMAP = "ipsec-config"
+
+// IPSEC_STATUS_CONFIGMAP is the name of the ConfigMap in APPLIED_NAMESPACE
+// where the ovn-ipsec pods aggregate their per-node tunnel state
+// ("node.<name>" keys of the form "up=<n>,down=<n>,failed=<n>").
+const IPSEC_STATUS_CONFIGMAP = "ipsec-status"
+
+// IPsecCertRotateAnnotation is an annotation on OperatorPKI objects that
+// forces a certificate rotation: every time its value changes, the target
+// cert Secret is regenerated.
+const IPsecCertRotateAnnotation = "networkoperator.openshift.io/rotate-certs"
+
+// NodeCertReissueAnnotation is an annotation on Node objects that forces the
+// node's identity certificate to be reissued: every time its value changes,
+// the node's CSR is deleted so the node requests a fresh certificate.
+const NodeCertReissueAnnotation = "networkoperator.openshift.io/reissue-node-cert"
+
+// NodeCertReissueHandledAnnotation records, on the Node, the last
+// NodeCertReissueAnnotation value the operator acted on, making the forced
+// reissue one-shot per annotation value.
+const NodeCertReissueHandledAnnotation = "networkoperator.openshift.io/reissue-node-cert-handled"
+
+// OVNCanarySelectorAnnotation is an annotation on the networks.operator.openshift.io
+// CR holding a node label selector; when set, updates to the ovnkube-node
+// DaemonSet are rolled out to the matching (canary) nodes first and only
+// proceed cluster-wide once those pods are verified healthy.
+const OVNCanarySelectorAnnotation = "networkoperator.openshift.io/ovn-canary-selector"
+
+// OVNNodeOverlayLabel marks ConfigMaps in APPLIED_NAMESPACE that declare a
+// per-node-pool ovnkube-node configuration overlay. Each such ConfigMap
+// produces an additional ovnkube-node DaemonSet scoped to the nodes matching
+// its node-selector entry.
+const OVNNodeOverlayLabel = "networkoperator.openshift.io/ovn-node-overlay"
+
 // NonCriticalAnnotation is an annotation on Deployments/DaemonSets to indicate
 // that they are not critical to the functioning of the pod network
 const NonCriticalAnnotation = "networkoperator.openshift.io/non-critical"
@@ -136,3 +436,6 @@ const IPFamilySingleStack = "single-stack"
 
 // dual stack IP family mode
 const IPFamilyDualStack = "dual-stack"
+
+// dual stack IP family mode with IPv6 as the primary family
+const IPFamilyDualStackIPv6Primary = "dual-stack-ipv6-primary"
@@ -31,11 +31,16 @@ func renderAdditionalNetworksCRD(manifestDir string) ([]*uns.Unstructured, error
 func renderRawCNIConfig(conf *operv1.AdditionalNetworkDefinition, manifestDir string) ([]*uns.Unstructured, error) {
 	var err error
 
+	cniConfig, err := expandChainedPlugins(conf.RawCNIConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to expand chained plugins")
+	}
+
 	// render RawCNIConfig manifests
 	data := render.MakeRenderData()
 	data.Data["AdditionalNetworkName"] = conf.Name
 	data.Data["AdditionalNetworkNamespace"] = conf.Namespace
-	data.Data["AdditionalNetworkConfig"] = conf.RawCNIConfig
+	data.Data["AdditionalNetworkConfig"] = cniConfig
 	objs, err := render.RenderDir(filepath.Join(manifestDir, "network/additional-networks/raw"), &data)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render additional network")
@@ -57,6 +62,9 @@ func validateRaw(conf *operv1.AdditionalNetworkDefinition) []error {
 	err = json.Unmarshal(confBytes, &rawConfig)
 	if err != nil {
 		out = append(out, errors.Errorf("Failed to Unmarshal RawCNIConfig: %v", confBytes))
+	} else {
+		out = append(out, validateCNIConfig(rawConfig)...)
+		out = append(out, validateChainedPlugins(rawConfig)...)
 	}
 
 	return out
@@ -12,8 +12,8 @@ import (
 var NetworkAttachmentConfigRaw = operv1.Network{
 	Spec: operv1.NetworkSpec{
 		AdditionalNetworks: []operv1.AdditionalNetworkDefinition{
-			{Type: operv1.NetworkTypeRaw, Namespace: "foobar", Name: "net-attach-1", RawCNIConfig: "{}"},
-			{Type: operv1.NetworkTypeRaw, Name: "net-attach-2", RawCNIConfig: "{}"},
+			{Type: operv1.NetworkTypeRaw, Namespace: "foobar", Name: "net-attach-1", RawCNIConfig: `{"type": "macvlan"}`},
+			{Type: operv1.NetworkTypeRaw, Name: "net-attach-2", RawCNIConfig: `{"type": "bridge"}`},
 		},
 	},
 }
@@ -119,6 +119,92 @@ func TestValidateRaw(t *testing.T) {
 
 	rawConfig.Name = ""
 	errExpect("Additional Network Name cannot be nil")
+
+	rawConfig.Name = "net-attach-1"
+	rawConfig.RawCNIConfig = `{"type": "macvlan", "chainedPlugins": "bandwidth"}`
+	errExpect("chainedPlugins must be a list")
+
+	rawConfig.RawCNIConfig = `{"type": "macvlan", "chainedPlugins": [{"capabilities": {"bandwidth": true}}]}`
+	errExpect("must declare a plugin type")
+
+	rawConfig.RawCNIConfig = `{"type": "macvlan", "chainedPlugins": [{"type": "portmap"}]}`
+	errExpect(`plugin type "portmap" cannot be chained`)
+
+	rawConfig.RawCNIConfig = `{}`
+	errExpect("a plugin configuration must have a type")
+
+	rawConfig.RawCNIConfig = `{"cniVersion": "latest", "type": "macvlan"}`
+	errExpect("invalid cniVersion")
+
+	rawConfig.RawCNIConfig = `{"type": "macvlan", "mode": "brdige"}`
+	errExpect("invalid macvlan mode: brdige")
+
+	rawConfig.RawCNIConfig = `{"type": "ipvlan", "mode": "l4"}`
+	errExpect("invalid ipvlan mode: l4")
+
+	rawConfig.RawCNIConfig = `{"type": "bridge", "mtu": -1}`
+	errExpect("bridge mtu must be a positive integer")
+
+	rawConfig.RawCNIConfig = `{"name": "conflist", "plugins": []}`
+	errExpect("plugins must be a non-empty list")
+
+	rawConfig.RawCNIConfig = `{"type": "macvlan", "ipam": {"type": "whereabouts"}}`
+	errExpect("whereabouts requires a range")
+
+	rawConfig.RawCNIConfig = `{"type": "macvlan", "ipam": {"type": "whereabouts", "range": "192.168.2.225/28", "range_start": "not-an-ip"}}`
+	errExpect("invalid whereabouts range_start")
+
+	rawConfig.RawCNIConfig = `{"type": "macvlan", "ipam": {"type": "whereabouts", "range": "192.168.2.225/28", "exclude": ["192.168.2.229/30", "bad"]}}`
+	errExpect("invalid whereabouts exclude entry")
+}
+
+func TestExpandChainedPlugins(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// no chainedPlugins key: unchanged
+	expanded, err := expandChainedPlugins(`{"cniVersion": "0.3.1", "type": "macvlan"}`)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(expanded).To(Equal(`{"cniVersion": "0.3.1", "type": "macvlan"}`))
+
+	// single plugin becomes a conflist with the chained plugins appended
+	expanded, err = expandChainedPlugins(`{
+		"cniVersion": "0.4.0",
+		"name": "chained",
+		"type": "macvlan",
+		"master": "eth0",
+		"chainedPlugins": [
+			{"type": "bandwidth", "ingressRate": 1000000},
+			{"type": "tuning", "sysctl": {"net.core.somaxconn": "500"}}
+		]
+	}`)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(expanded).To(MatchJSON(`{
+		"cniVersion": "0.4.0",
+		"name": "chained",
+		"plugins": [
+			{"type": "macvlan", "master": "eth0"},
+			{"type": "bandwidth", "ingressRate": 1000000},
+			{"type": "tuning", "sysctl": {"net.core.somaxconn": "500"}}
+		]
+	}`))
+
+	// existing conflist gets the chained plugins appended
+	expanded, err = expandChainedPlugins(`{
+		"cniVersion": "0.3.1",
+		"name": "conflist",
+		"plugins": [{"type": "bridge"}],
+		"chainedPlugins": [{"type": "sbr"}]
+	}`)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(expanded).To(MatchJSON(`{
+		"cniVersion": "0.3.1",
+		"name": "conflist",
+		"plugins": [{"type": "bridge"}, {"type": "sbr"}]
+	}`))
+
+	// invalid declarations surface as errors
+	_, err = expandChainedPlugins(`{"type": "macvlan", "chainedPlugins": [{"type": "portmap"}]}`)
+	g.Expect(err).To(MatchError(ContainSubstring("cannot be chained")))
 }
 
 func TestRenderSimpleMacvlanConfig(t *testing.T) {
@@ -0,0 +1,100 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	operv1 "github.com/openshift/api/operator/v1"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkAPIServerNetworkConflict verifies that the addresses the API server
+// advertises do not fall inside the configured ClusterNetwork, and that the
+// API server VIPs are not carved out of the ServiceNetwork. Such conflicts
+// produce obscure dataplane failures post-install (the overlay claims the
+// API server's address), so they are surfaced as an explicit pre-flight
+// error instead.
+func checkAPIServerNetworkConflict(ctx context.Context, conf *operv1.NetworkSpec, kubeClient client.Client) error {
+	infra := &configv1.Infrastructure{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Name: "cluster"}, infra); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("could not retrieve infrastructure config: %v", err)
+	}
+
+	endpoints := apiServerEndpoints(infra)
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	networks := []string{}
+	networks = append(networks, conf.ServiceNetwork...)
+	for _, cn := range conf.ClusterNetwork {
+		networks = append(networks, cn.CIDR)
+	}
+
+	for source, ip := range endpoints {
+		for _, cidr := range networks {
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				// Unparseable CIDRs are caught by Validate.
+				continue
+			}
+			if network.Contains(ip) {
+				return fmt.Errorf("API server address %s (%s) falls inside the configured network %s; the service and cluster networks must not overlap the API server advertise configuration",
+					ip, source, cidr)
+			}
+		}
+	}
+	return nil
+}
+
+// apiServerEndpoints collects the addresses the API server advertises, keyed
+// by where each was found. DNS hostnames are skipped; only literal IPs can
+// conflict with a CIDR.
+func apiServerEndpoints(infra *configv1.Infrastructure) map[string]net.IP {
+	endpoints := map[string]net.IP{}
+
+	for source, rawURL := range map[string]string{
+		"apiServerURL":         infra.Status.APIServerURL,
+		"apiServerInternalURL": infra.Status.APIServerInternalURL,
+	} {
+		if rawURL == "" {
+			continue
+		}
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		if ip := net.ParseIP(parsed.Hostname()); ip != nil {
+			endpoints[source] = ip
+		}
+	}
+
+	if ps := infra.Status.PlatformStatus; ps != nil {
+		vips := map[string]string{}
+		switch {
+		case ps.BareMetal != nil:
+			vips["baremetal apiServerInternalIP"] = ps.BareMetal.APIServerInternalIP
+		case ps.OpenStack != nil:
+			vips["openstack apiServerInternalIP"] = ps.OpenStack.APIServerInternalIP
+		case ps.VSphere != nil:
+			vips["vsphere apiServerInternalIP"] = ps.VSphere.APIServerInternalIP
+		case ps.Ovirt != nil:
+			vips["ovirt apiServerInternalIP"] = ps.Ovirt.APIServerInternalIP
+		}
+		for source, raw := range vips {
+			if ip := net.ParseIP(raw); ip != nil {
+				endpoints[source] = ip
+			}
+		}
+	}
+	return endpoints
+}
@@ -1,23 +1,65 @@
 package network
 
 import (
+	"context"
+
 	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
-	"github.com/openshift/cluster-network-operator/pkg/platform/openstack"
+	"github.com/openshift/cluster-network-operator/pkg/platform"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operv1 "github.com/openshift/api/operator/v1"
+
+	// Register the platform bootstrap providers.
+	_ "github.com/openshift/cluster-network-operator/pkg/platform/openstack"
 )
 
 // Bootstrap creates resources required by SDN on the cloud.
 func Bootstrap(conf *operv1.Network, client client.Client) (*bootstrap.BootstrapResult, error) {
+	// Catch address conflicts with the API server before rendering anything;
+	// they only show up as obscure dataplane failures otherwise.
+	if err := checkAPIServerNetworkConflict(context.TODO(), &conf.Spec, client); err != nil {
+		return nil, err
+	}
+
+	var res *bootstrap.BootstrapResult
+	var err error
 	switch conf.Spec.DefaultNetwork.Type {
 	case operv1.NetworkTypeKuryr:
-		return openstack.BootstrapKuryr(&conf.Spec, client)
+		res, err = platformBootstrap(configv1.OpenStackPlatformType, conf, client)
 	case operv1.NetworkTypeOpenShiftSDN:
-		return bootstrapSDN(conf, client)
+		res, err = bootstrapSDN(conf, client)
 	case operv1.NetworkTypeOVNKubernetes:
-		return bootstrapOVN(conf, client)
+		res, err = bootstrapOVN(conf, client)
+	}
+	if err != nil || res == nil {
+		return res, err
 	}
 
-	return nil, nil
+	// Network-type-independent configuration.
+	if res.Diagnostics, err = getNetworkDiagnosticsConfig(client); err != nil {
+		return nil, err
+	}
+	if res.Whereabouts, err = getWhereaboutsConfig(client); err != nil {
+		return nil, err
+	}
+	if res.SysctlAllowlist, err = getSysctlAllowlist(client); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// platformBootstrap validates the configuration with the provider registered
+// for the given platform and runs its cloud bootstrap.
+func platformBootstrap(platformType configv1.PlatformType, conf *operv1.Network, client client.Client) (*bootstrap.BootstrapResult, error) {
+	provider := platform.ProviderFor(platformType)
+	if provider == nil {
+		return nil, errors.Errorf("no bootstrap provider registered for platform %s", platformType)
+	}
+	if errs := provider.Validate(&conf.Spec); len(errs) > 0 {
+		return nil, errors.Errorf("invalid configuration for platform %s: %v", platformType, errs)
+	}
+	return provider.Bootstrap(&conf.Spec, client)
 }
@@ -0,0 +1,98 @@
+package network
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// chainedPluginsKey is the key in a raw CNI configuration under which chained
+// plugins are declared. It is stripped before rendering; the declared plugins
+// are appended to the main plugin in a generated conflist.
+const chainedPluginsKey = "chainedPlugins"
+
+// allowedChainedPluginTypes are the meta plugins that may be chained after
+// the main plugin of an additional network.
+var allowedChainedPluginTypes = map[string]struct{}{
+	"bandwidth": {},
+	"tuning":    {},
+	"sbr":       {},
+}
+
+// validateChainedPlugins checks the chainedPlugins declaration of an already
+// unmarshalled raw CNI configuration.
+func validateChainedPlugins(rawConfig map[string]interface{}) []error {
+	out := []error{}
+	raw, ok := rawConfig[chainedPluginsKey]
+	if !ok {
+		return out
+	}
+
+	chained, ok := raw.([]interface{})
+	if !ok {
+		return append(out, errors.Errorf("%s must be a list of plugin configurations", chainedPluginsKey))
+	}
+	for _, entry := range chained {
+		plugin, ok := entry.(map[string]interface{})
+		if !ok {
+			out = append(out, errors.Errorf("%s entries must be plugin configuration objects", chainedPluginsKey))
+			continue
+		}
+		pluginType, _ := plugin["type"].(string)
+		if pluginType == "" {
+			out = append(out, errors.Errorf("%s entries must declare a plugin type", chainedPluginsKey))
+			continue
+		}
+		if _, allowed := allowedChainedPluginTypes[pluginType]; !allowed {
+			out = append(out, errors.Errorf("plugin type %q cannot be chained", pluginType))
+		}
+	}
+	return out
+}
+
+// expandChainedPlugins resolves the chainedPlugins declaration of a raw CNI
+// configuration into a CNI conflist: the declared plugins are appended after
+// the main plugin (or after the existing plugins of a conflist), and the
+// cniVersion and name move to the top level. Configurations without chained
+// plugins are returned unchanged.
+func expandChainedPlugins(rawCNIConfig string) (string, error) {
+	config := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(rawCNIConfig), &config); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal RawCNIConfig")
+	}
+	raw, ok := config[chainedPluginsKey]
+	if !ok {
+		return rawCNIConfig, nil
+	}
+	if errs := validateChainedPlugins(config); len(errs) > 0 {
+		return "", errs[0]
+	}
+	chained := raw.([]interface{})
+	delete(config, chainedPluginsKey)
+
+	conflist := map[string]interface{}{
+		"cniVersion": "0.3.1",
+		"plugins":    []interface{}{},
+	}
+	if version, ok := config["cniVersion"]; ok {
+		conflist["cniVersion"] = version
+	}
+	if name, ok := config["name"]; ok {
+		conflist["name"] = name
+	}
+
+	if plugins, ok := config["plugins"].([]interface{}); ok {
+		// already a conflist; chain after its existing plugins
+		conflist["plugins"] = append(plugins, chained...)
+	} else {
+		delete(config, "cniVersion")
+		delete(config, "name")
+		conflist["plugins"] = append([]interface{}{config}, chained...)
+	}
+
+	expanded, err := json.Marshal(conflist)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal chained CNI configuration")
+	}
+	return string(expanded), nil
+}
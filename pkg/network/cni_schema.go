@@ -0,0 +1,163 @@
+package network
+
+// Structural validation of raw CNI configurations. A typo in a rawCNIConfig
+// otherwise only surfaces when the first pod sandbox using the network fails
+// to create; validating the CNI structure (and the fields of the common
+// plugins) at Validate time turns that into an InvalidOperatorConfig error.
+
+import (
+	"net"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+var cniVersionRegexp = regexp.MustCompile(`^[0-9]+\.[0-9]+(\.[0-9]+)?$`)
+
+var macvlanModes = map[string]struct{}{
+	"bridge": {}, "private": {}, "vepa": {}, "passthru": {},
+}
+
+var ipvlanModes = map[string]struct{}{
+	"l2": {}, "l3": {}, "l3s": {},
+}
+
+// validateCNIConfig checks an unmarshalled raw CNI configuration: either a
+// single plugin configuration or a conflist with a "plugins" list.
+func validateCNIConfig(config map[string]interface{}) []error {
+	out := []error{}
+
+	if raw, ok := config["cniVersion"]; ok {
+		if version, ok := raw.(string); !ok || !cniVersionRegexp.MatchString(version) {
+			out = append(out, errors.Errorf("invalid cniVersion %v", raw))
+		}
+	}
+
+	raw, conflist := config["plugins"]
+	if !conflist {
+		return append(out, validateCNIPlugin(config)...)
+	}
+
+	if name, _ := config["name"].(string); name == "" {
+		out = append(out, errors.Errorf("a CNI conflist must have a name"))
+	}
+	plugins, ok := raw.([]interface{})
+	if !ok || len(plugins) == 0 {
+		return append(out, errors.Errorf("plugins must be a non-empty list of plugin configurations"))
+	}
+	for _, entry := range plugins {
+		plugin, ok := entry.(map[string]interface{})
+		if !ok {
+			out = append(out, errors.Errorf("plugins entries must be plugin configuration objects"))
+			continue
+		}
+		out = append(out, validateCNIPlugin(plugin)...)
+	}
+	return out
+}
+
+// validateCNIPlugin checks one plugin configuration: every plugin needs a
+// type, and for the common plugins the well-known fields are checked too.
+func validateCNIPlugin(plugin map[string]interface{}) []error {
+	out := []error{}
+
+	pluginType, _ := plugin["type"].(string)
+	if pluginType == "" {
+		return append(out, errors.Errorf("a plugin configuration must have a type"))
+	}
+
+	switch pluginType {
+	case "macvlan", "ipvlan":
+		modes := macvlanModes
+		if pluginType == "ipvlan" {
+			modes = ipvlanModes
+		}
+		if raw, ok := plugin["mode"]; ok {
+			if mode, ok := raw.(string); !ok {
+				out = append(out, errors.Errorf("%s mode must be a string", pluginType))
+			} else if _, valid := modes[mode]; !valid {
+				out = append(out, errors.Errorf("invalid %s mode: %s", pluginType, mode))
+			}
+		}
+		if raw, ok := plugin["master"]; ok {
+			if master, ok := raw.(string); !ok || master == "" {
+				out = append(out, errors.Errorf("%s master must be a non-empty string", pluginType))
+			}
+		}
+		out = append(out, validateCNIMTU(pluginType, plugin)...)
+	case "bridge":
+		if raw, ok := plugin["bridge"]; ok {
+			if bridge, ok := raw.(string); !ok || bridge == "" {
+				out = append(out, errors.Errorf("bridge name must be a non-empty string"))
+			}
+		}
+		out = append(out, validateCNIMTU(pluginType, plugin)...)
+	}
+
+	if raw, ok := plugin["ipam"]; ok {
+		ipam, ok := raw.(map[string]interface{})
+		if !ok {
+			return append(out, errors.Errorf("ipam must be a configuration object"))
+		}
+		out = append(out, validateCNIIPAM(ipam)...)
+	}
+	return out
+}
+
+func validateCNIMTU(pluginType string, plugin map[string]interface{}) []error {
+	raw, ok := plugin["mtu"]
+	if !ok {
+		return nil
+	}
+	if mtu, isNumber := raw.(float64); !isNumber || mtu <= 0 || mtu != float64(int(mtu)) {
+		return []error{errors.Errorf("%s mtu must be a positive integer", pluginType)}
+	}
+	return nil
+}
+
+// validateCNIIPAM checks an ipam section; the whereabouts fields are checked
+// in detail since it is the IPAM plugin shipped for additional networks.
+func validateCNIIPAM(ipam map[string]interface{}) []error {
+	out := []error{}
+
+	ipamType, _ := ipam["type"].(string)
+	if ipamType == "" {
+		return append(out, errors.Errorf("an ipam configuration must have a type"))
+	}
+	if ipamType != "whereabouts" {
+		return out
+	}
+
+	if raw, ok := ipam["range"]; ok {
+		if cidr, ok := raw.(string); !ok {
+			out = append(out, errors.Errorf("whereabouts range must be a string"))
+		} else if _, _, err := net.ParseCIDR(cidr); err != nil {
+			out = append(out, errors.Errorf("invalid whereabouts range: %v", err))
+		}
+	} else {
+		out = append(out, errors.Errorf("whereabouts requires a range"))
+	}
+	for _, field := range []string{"range_start", "range_end", "gateway"} {
+		raw, ok := ipam[field]
+		if !ok {
+			continue
+		}
+		if ip, ok := raw.(string); !ok || net.ParseIP(ip) == nil {
+			out = append(out, errors.Errorf("invalid whereabouts %s: %v", field, raw))
+		}
+	}
+	if raw, ok := ipam["exclude"]; ok {
+		exclude, ok := raw.([]interface{})
+		if !ok {
+			return append(out, errors.Errorf("whereabouts exclude must be a list of CIDRs"))
+		}
+		for _, entry := range exclude {
+			if cidr, ok := entry.(string); !ok {
+				out = append(out, errors.Errorf("whereabouts exclude must be a list of CIDRs"))
+			} else if _, _, err := net.ParseCIDR(cidr); err != nil {
+				out = append(out, errors.Errorf("invalid whereabouts exclude entry: %v", err))
+			}
+		}
+	}
+	return out
+}
@@ -3,6 +3,7 @@ package network
 import (
 	. "github.com/onsi/gomega"
 	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
 	"testing"
 )
 
@@ -170,7 +171,7 @@ func TestRenderWithDHCP(t *testing.T) {
 	config := &crd.Spec
 	FillDefaults(config, nil)
 
-	objs, err := renderMultus(config, manifestDir)
+	objs, err := renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "dhcp-daemon")))
 }
@@ -183,7 +184,7 @@ func TestRenderNoDHCP(t *testing.T) {
 	config := &crd.Spec
 	FillDefaults(config, nil)
 
-	objs, err := renderMultus(config, manifestDir)
+	objs, err := renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "dhcp-daemon")))
 }
@@ -196,7 +197,7 @@ func TestRenderInvalidDHCP(t *testing.T) {
 	config := &crd.Spec
 	FillDefaults(config, nil)
 
-	objs, err := renderMultus(config, manifestDir)
+	objs, err := renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "dhcp-daemon")))
 }
@@ -209,7 +210,7 @@ func TestRenderWithDHCPSimpleMacvlan(t *testing.T) {
 	config := &crd.Spec
 	FillDefaults(config, nil)
 
-	objs, err := renderMultus(config, manifestDir)
+	objs, err := renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "dhcp-daemon")))
 }
@@ -222,7 +223,7 @@ func TestRenderNoDHCPSimpleMacvlan(t *testing.T) {
 	config := &crd.Spec
 	FillDefaults(config, nil)
 
-	objs, err := renderMultus(config, manifestDir)
+	objs, err := renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "dhcp-daemon")))
 }
@@ -0,0 +1,106 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// minDiagnosticsProbeInterval bounds how often the connectivity checkers may
+// be asked to probe; anything faster is configuration error, not diagnostics.
+const minDiagnosticsProbeInterval = 10 * time.Second
+
+// knownDiagnosticsChecks are the check groups the connectivity check
+// controller can generate, keyed by the name accepted in the "checks" list.
+var knownDiagnosticsChecks = map[string]struct{}{
+	"kubernetes-default-service":    {},
+	"kubernetes-apiserver-service":  {},
+	"kubernetes-apiserver-endpoint": {},
+	"openshift-apiserver-service":   {},
+	"openshift-apiserver-endpoint":  {},
+	"load-balancer":                 {},
+	"network-check-target-service":  {},
+	"network-check-target":          {},
+}
+
+// getNetworkDiagnosticsConfig reads the optional diagnostics tuning from the
+// NETWORK_DIAGNOSTICS_CONFIGMAP. "checks" is a comma-separated subset of the
+// known check groups to generate (absent or empty means all of them), and
+// "probe-interval" overrides how often the checkers probe each target.
+func getNetworkDiagnosticsConfig(kubeClient client.Client) (bootstrap.NetworkDiagnosticsConfig, error) {
+	ndc := bootstrap.NetworkDiagnosticsConfig{}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.NETWORK_DIAGNOSTICS_CONFIGMAP}
+	if err := kubeClient.Get(context.TODO(), nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ndc, nil
+		}
+		return ndc, err
+	}
+
+	if raw, ok := cm.Data["checks"]; ok && raw != "" {
+		for _, check := range strings.Split(raw, ",") {
+			check = strings.TrimSpace(check)
+			if _, known := knownDiagnosticsChecks[check]; !known {
+				return ndc, fmt.Errorf("unknown check %q in ConfigMap %s", check, names.NETWORK_DIAGNOSTICS_CONFIGMAP)
+			}
+			ndc.EnabledChecks = append(ndc.EnabledChecks, check)
+		}
+	}
+
+	if raw, ok := cm.Data["external-targets"]; ok && raw != "" {
+		for _, entry := range strings.Split(raw, ",") {
+			entry = strings.TrimSpace(entry)
+			parts := strings.SplitN(entry, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return ndc, fmt.Errorf("invalid external-targets entry %q in ConfigMap %s: must be name=host:port", entry, names.NETWORK_DIAGNOSTICS_CONFIGMAP)
+			}
+			if _, _, err := net.SplitHostPort(parts[1]); err != nil {
+				return ndc, fmt.Errorf("invalid external-targets endpoint %q in ConfigMap %s: %v", parts[1], names.NETWORK_DIAGNOSTICS_CONFIGMAP, err)
+			}
+			ndc.ExternalTargets = append(ndc.ExternalTargets, bootstrap.NetworkDiagnosticsTarget{
+				Name:     parts[0],
+				Endpoint: parts[1],
+			})
+		}
+	}
+
+	if raw, ok := cm.Data["dns-names"]; ok && raw != "" {
+		for _, name := range strings.Split(raw, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return ndc, fmt.Errorf("empty dns-names entry in ConfigMap %s", names.NETWORK_DIAGNOSTICS_CONFIGMAP)
+			}
+			ndc.DNSNames = append(ndc.DNSNames, name)
+		}
+	}
+
+	if raw, ok := cm.Data["latency-probes"]; ok && raw != "" {
+		if raw != "true" && raw != "false" {
+			return ndc, fmt.Errorf("invalid latency-probes value %q in ConfigMap %s: must be \"true\" or \"false\"", raw, names.NETWORK_DIAGNOSTICS_CONFIGMAP)
+		}
+		ndc.LatencyProbes = raw == "true"
+	}
+
+	if raw, ok := cm.Data["probe-interval"]; ok && raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil {
+			return ndc, fmt.Errorf("invalid probe-interval %q in ConfigMap %s: %v", raw, names.NETWORK_DIAGNOSTICS_CONFIGMAP, err)
+		}
+		if interval < minDiagnosticsProbeInterval {
+			return ndc, fmt.Errorf("probe-interval %q in ConfigMap %s is below the %v minimum", raw, names.NETWORK_DIAGNOSTICS_CONFIGMAP, minDiagnosticsProbeInterval)
+		}
+		ndc.ProbeInterval = interval
+	}
+	return ndc, nil
+}
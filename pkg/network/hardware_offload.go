@@ -0,0 +1,155 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getHardwareOffloadProfiles discovers hardware offload profile ConfigMaps
+// (labeled with names.HardwareOffloadProfileLabel in the operator namespace).
+// Each profile declares an offload-capable node pool: the MachineConfig role
+// of the pool, the node-selector label identifying its nodes, an optional NIC
+// selector, and the per-pool ovs hw-offload setting. Invalid profiles are
+// skipped with a warning.
+func getHardwareOffloadProfiles(kubeClient client.Client) ([]bootstrap.HardwareOffloadProfile, error) {
+	cms := &corev1.ConfigMapList{}
+	err := kubeClient.List(context.TODO(), cms,
+		client.InNamespace(names.APPLIED_NAMESPACE), client.HasLabels{names.HardwareOffloadProfileLabel})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list hardware offload profile ConfigMaps")
+	}
+
+	profiles := []bootstrap.HardwareOffloadProfile{}
+	for _, cm := range cms.Items {
+		selector := cm.Data["node-selector"]
+		parts := strings.SplitN(selector, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			klog.Warningf("Ignoring hardware offload profile %s: node-selector must be of the form key=value, got %q", cm.Name, selector)
+			continue
+		}
+		role := cm.Data["role"]
+		if role == "" {
+			klog.Warningf("Ignoring hardware offload profile %s: role is required", cm.Name)
+			continue
+		}
+		offload := cm.Data["ovs-hw-offload"]
+		if offload == "" {
+			offload = "true"
+		}
+		if offload != "true" && offload != "false" {
+			klog.Warningf("Ignoring hardware offload profile %s: ovs-hw-offload must be \"true\" or \"false\", got %q", cm.Name, offload)
+			continue
+		}
+		profiles = append(profiles, bootstrap.HardwareOffloadProfile{
+			Name:         cm.Name,
+			Role:         role,
+			LabelKey:     parts[0],
+			LabelValue:   parts[1],
+			NICSelector:  cm.Data["nic-selector"],
+			OvsHwOffload: offload == "true",
+			MaxIdle:      cm.Data["max-idle"],
+		})
+	}
+	return profiles, nil
+}
+
+// renderHardwareOffloadProfiles converts the profiles into MachineConfigs
+// that program OVS hardware offload on the matching machine pool.
+func renderHardwareOffloadProfiles(profiles []bootstrap.HardwareOffloadProfile) []*uns.Unstructured {
+	objs := []*uns.Unstructured{}
+	for _, profile := range profiles {
+		objs = append(objs, renderOffloadMachineConfig(profile))
+	}
+	return objs
+}
+
+func renderOffloadMachineConfig(profile bootstrap.HardwareOffloadProfile) *uns.Unstructured {
+	otherConfig := fmt.Sprintf("other_config:hw-offload=%t", profile.OvsHwOffload)
+	if profile.MaxIdle != "" {
+		otherConfig += fmt.Sprintf(" other_config:max-idle=%s", profile.MaxIdle)
+	}
+	unit := fmt.Sprintf(`[Unit]
+Description=Configure OVS hardware offload
+Requires=openvswitch.service
+After=openvswitch.service
+
+[Service]
+Type=oneshot
+ExecStart=/usr/bin/ovs-vsctl set Open_vSwitch . %s
+RemainAfterExit=yes
+
+[Install]
+WantedBy=multi-user.target
+`, otherConfig)
+
+	files := []interface{}{}
+	if profile.NICSelector != "" {
+		// Record the NIC selector on disk for the node-side tooling that
+		// binds offload-capable NICs.
+		files = append(files, map[string]interface{}{
+			"path": "/etc/cno/hw-offload-nics",
+			"mode": int64(0644),
+			"contents": map[string]interface{}{
+				"source": "data:text/plain;charset=utf-8," + url.PathEscape(profile.NICSelector+"\n"),
+			},
+		})
+	}
+
+	mc := &uns.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "machineconfiguration.openshift.io/v1",
+		"kind":       "MachineConfig",
+		"metadata": map[string]interface{}{
+			"name": fmt.Sprintf("50-ovs-hw-offload-%s", profile.Name),
+			"labels": map[string]interface{}{
+				"machineconfiguration.openshift.io/role": profile.Role,
+			},
+		},
+		"spec": map[string]interface{}{
+			"config": map[string]interface{}{
+				"ignition": map[string]interface{}{
+					"version": "3.2.0",
+				},
+				"storage": map[string]interface{}{
+					"files": files,
+				},
+				"systemd": map[string]interface{}{
+					"units": []interface{}{
+						map[string]interface{}{
+							"name":     "ovs-configure-hw-offload.service",
+							"enabled":  true,
+							"contents": unit,
+						},
+					},
+				},
+			},
+		},
+	}}
+	return mc
+}
+
+// offloadProfileOverlays exposes the offload node pools to the ovnkube-node
+// overlay machinery, so each pool gets its own DaemonSet variant scoped by
+// node selector.
+func offloadProfileOverlays(profiles []bootstrap.HardwareOffloadProfile) []bootstrap.OVNNodeOverlay {
+	overlays := []bootstrap.OVNNodeOverlay{}
+	for _, profile := range profiles {
+		overlays = append(overlays, bootstrap.OVNNodeOverlay{
+			Name:       fmt.Sprintf("hw-offload-%s", profile.Name),
+			LabelKey:   profile.LabelKey,
+			LabelValue: profile.LabelValue,
+		})
+	}
+	return overlays
+}
@@ -0,0 +1,136 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// proposalRe matches libreswan cipher proposal strings such as
+// "aes_gcm256-sha2_512" or "aes256-sha2_256;dh20".
+var proposalRe = regexp.MustCompile(`^[a-z0-9_+,;-]+$`)
+
+// pfsGroups are the supported PFS (DH) groups.
+var pfsGroups = map[string]bool{"none": true, "dh14": true, "dh19": true, "dh20": true, "dh21": true}
+
+// getIPsecConfig reads the optional IPsec crypto parameter overrides (IKE/ESP
+// proposals, PFS group, SA lifetime) from the IPSEC_CONFIG_CONFIGMAP.
+// Compliance regimes require specific algorithm sets, so invalid values are
+// an error rather than silently falling back to the libreswan defaults.
+func getIPsecConfig(kubeClient client.Client) (bootstrap.OVNIPsecConfig, error) {
+	cfg := bootstrap.OVNIPsecConfig{}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.IPSEC_CONFIG_CONFIGMAP}
+	if err := kubeClient.Get(context.TODO(), nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if ike := cm.Data["ike-proposal"]; ike != "" {
+		if !proposalRe.MatchString(ike) {
+			return cfg, fmt.Errorf("invalid ike-proposal %q in ConfigMap %s", ike, names.IPSEC_CONFIG_CONFIGMAP)
+		}
+		cfg.IKEProposal = ike
+	}
+	if esp := cm.Data["esp-proposal"]; esp != "" {
+		if !proposalRe.MatchString(esp) {
+			return cfg, fmt.Errorf("invalid esp-proposal %q in ConfigMap %s", esp, names.IPSEC_CONFIG_CONFIGMAP)
+		}
+		cfg.ESPProposal = esp
+	}
+	if group := cm.Data["pfs-group"]; group != "" {
+		if !pfsGroups[group] {
+			return cfg, fmt.Errorf("invalid pfs-group %q in ConfigMap %s: must be one of none, dh14, dh19, dh20, dh21", group, names.IPSEC_CONFIG_CONFIGMAP)
+		}
+		cfg.PFSGroup = group
+	}
+	if mode := cm.Data["mode"]; mode != "" {
+		if mode != "full" && mode != "external" {
+			return cfg, fmt.Errorf("invalid mode %q in ConfigMap %s: must be \"full\" or \"external\"", mode, names.IPSEC_CONFIG_CONFIGMAP)
+		}
+		cfg.Mode = mode
+	}
+	if selector := cm.Data["external-node-selector"]; selector != "" {
+		if cfg.Mode != "external" {
+			return cfg, fmt.Errorf("external-node-selector is set in ConfigMap %s but mode is not \"external\"", names.IPSEC_CONFIG_CONFIGMAP)
+		}
+		parts := strings.SplitN(selector, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return cfg, fmt.Errorf("invalid external-node-selector %q in ConfigMap %s: must be of the form key=value", selector, names.IPSEC_CONFIG_CONFIGMAP)
+		}
+		cfg.ExternalSelectorKey = parts[0]
+		cfg.ExternalSelectorValue = parts[1]
+	}
+	if raw := cm.Data["sa-lifetime-seconds"]; raw != "" {
+		lifetime, err := strconv.Atoi(raw)
+		if err != nil || lifetime < 60 {
+			return cfg, fmt.Errorf("invalid sa-lifetime-seconds %q in ConfigMap %s: must be an integer of at least 60", raw, names.IPSEC_CONFIG_CONFIGMAP)
+		}
+		cfg.SALifetime = lifetime
+	}
+	return cfg, nil
+}
+
+// ipsecRenderData converts the crypto parameters into libreswan config
+// values: the PFS group turns into pfs=yes/no and is appended to the ESP
+// proposal, the SA lifetime is rendered in seconds.
+func ipsecRenderData(cfg bootstrap.OVNIPsecConfig) (ike, esp, pfs, saLifetime string) {
+	ike = cfg.IKEProposal
+	esp = cfg.ESPProposal
+	switch cfg.PFSGroup {
+	case "":
+	case "none":
+		pfs = "no"
+	default:
+		pfs = "yes"
+		if esp != "" {
+			esp = esp + ";" + cfg.PFSGroup
+		}
+	}
+	if cfg.SALifetime > 0 {
+		saLifetime = strconv.Itoa(cfg.SALifetime) + "s"
+	}
+	return
+}
+
+// scopeIPsecDaemonSet restricts the ovn-ipsec DaemonSet to the external-mode
+// node pool, so enabling north-south IPsec for a labeled subset of nodes does
+// not roll it out cluster-wide. Status tracking follows automatically: the
+// DaemonSet only schedules (and is only waited on) for the selected pool.
+func scopeIPsecDaemonSet(objs []*uns.Unstructured, cfg bootstrap.OVNIPsecConfig) error {
+	if cfg.Mode != "external" || cfg.ExternalSelectorKey == "" {
+		return nil
+	}
+	for _, obj := range objs {
+		if obj.GetKind() != "DaemonSet" || obj.GetNamespace() != "openshift-ovn-kubernetes" || obj.GetName() != "ovn-ipsec" {
+			continue
+		}
+		selector, _, err := uns.NestedStringMap(obj.Object, "spec", "template", "spec", "nodeSelector")
+		if err != nil {
+			return errors.Wrap(err, "failed to read ovn-ipsec nodeSelector")
+		}
+		if selector == nil {
+			selector = map[string]string{}
+		}
+		selector[cfg.ExternalSelectorKey] = cfg.ExternalSelectorValue
+		if err := uns.SetNestedStringMap(obj.Object, selector, "spec", "template", "spec", "nodeSelector"); err != nil {
+			return errors.Wrap(err, "failed to set ovn-ipsec nodeSelector")
+		}
+	}
+	return nil
+}
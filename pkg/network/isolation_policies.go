@@ -0,0 +1,58 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getIsolationPolicyConfig reads the network isolation configuration from the
+// ISOLATION_POLICY_CONFIGMAP. When the ConfigMap is absent no isolation
+// NetworkPolicies are rendered. "allow-from" is a comma-separated list of
+// namespace label selectors ("key" or "key=value") whose namespaces may reach
+// the control-plane components in addition to the built-in allowances.
+func getIsolationPolicyConfig(kubeClient client.Client) (bootstrap.IsolationPolicyConfig, error) {
+	ipc := bootstrap.IsolationPolicyConfig{}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.ISOLATION_POLICY_CONFIGMAP}
+	if err := kubeClient.Get(context.TODO(), nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ipc, nil
+		}
+		return ipc, err
+	}
+
+	if enabled, ok := cm.Data["enabled"]; ok {
+		if enabled != "true" && enabled != "false" {
+			return ipc, fmt.Errorf("invalid enabled value %q in ConfigMap %s: must be \"true\" or \"false\"", enabled, names.ISOLATION_POLICY_CONFIGMAP)
+		}
+		ipc.Enabled = enabled == "true"
+	}
+
+	if raw, ok := cm.Data["allow-from"]; ok && raw != "" {
+		if !ipc.Enabled {
+			return ipc, fmt.Errorf("allow-from is set in ConfigMap %s but isolation is not enabled", names.ISOLATION_POLICY_CONFIGMAP)
+		}
+		for _, selector := range strings.Split(raw, ",") {
+			selector = strings.TrimSpace(selector)
+			parts := strings.SplitN(selector, "=", 2)
+			if parts[0] == "" {
+				return ipc, fmt.Errorf("invalid allow-from selector %q in ConfigMap %s", selector, names.ISOLATION_POLICY_CONFIGMAP)
+			}
+			allow := bootstrap.IsolationAllowFrom{Key: parts[0]}
+			if len(parts) == 2 {
+				allow.Value = parts[1]
+			}
+			ipc.AllowFrom = append(ipc.AllowFrom, allow)
+		}
+	}
+	return ipc, nil
+}
@@ -1,3 +1,4 @@
+//go:build linux
 // +build linux
 
 package network
@@ -42,3 +43,16 @@ func getDefaultMTU() (int, error) {
 
 	return mtu, nil
 }
+
+// getInterfaceMTU gets the mtu of the named link, or 0 if no such link
+// exists on this node.
+func getInterfaceMTU(name string) (int, error) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		if _, notFound := err.(netlink.LinkNotFoundError); notFound {
+			return 0, nil
+		}
+		return 0, errors.Wrapf(err, "could not retrieve link %q", name)
+	}
+	return link.Attrs().MTU, nil
+}
@@ -0,0 +1,55 @@
+package network
+
+import "sync"
+
+// probedHostMTU is the cluster-wide minimum uplink MTU established by the
+// multi-node MTU sampler, when available. It overrides the local single-node
+// probe so heterogeneous clusters default to an MTU every node can carry.
+var (
+	probedHostMTUMu sync.Mutex
+	probedHostMTU   int
+)
+
+// SetProbedHostMTU records the consensus host MTU from the multi-node
+// sampler. A value of 0 clears it.
+func SetProbedHostMTU(mtu int) {
+	probedHostMTUMu.Lock()
+	defer probedHostMTUMu.Unlock()
+	probedHostMTU = mtu
+}
+
+// ProbedHostMTU returns the recorded consensus host MTU, or 0 if none has
+// been established.
+func ProbedHostMTU() int {
+	probedHostMTUMu.Lock()
+	defer probedHostMTUMu.Unlock()
+	return probedHostMTU
+}
+
+// LocalHostMTU probes the MTU of the local default route, i.e. the
+// control-plane side when the operator runs on a hosted control plane.
+func LocalHostMTU() (int, error) {
+	return getDefaultMTU()
+}
+
+// clusterNetworkInterfaces are the node-local interfaces of the default
+// cluster networks, in probe order: the OVN-Kubernetes management port and
+// the OpenShift SDN tunnel device. Their MTU is the overlay MTU actually
+// applied on the node.
+var clusterNetworkInterfaces = []string{"ovn-k8s-mp0", "tun0"}
+
+// LocalClusterNetworkMTU probes the MTU applied on this node's
+// cluster-network interface. It returns 0 if no such interface exists, e.g.
+// under a third-party network plugin.
+func LocalClusterNetworkMTU() (int, error) {
+	for _, name := range clusterNetworkInterfaces {
+		mtu, err := getInterfaceMTU(name)
+		if err != nil {
+			return 0, err
+		}
+		if mtu > 0 {
+			return mtu, nil
+		}
+	}
+	return 0, nil
+}
@@ -1,5 +1,8 @@
+//go:build !linux
 // +build !linux
 
 package network
 
 func getDefaultMTU() (int, error) { return 1500, nil }
+
+func getInterfaceMTU(name string) (int, error) { return 0, nil }
@@ -3,8 +3,10 @@ package network
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
 	"github.com/openshift/cluster-network-operator/pkg/render"
 	"github.com/pkg/errors"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -17,7 +19,7 @@ const (
 )
 
 // renderMultus generates the manifests of Multus
-func renderMultus(conf *operv1.NetworkSpec, manifestDir string) ([]*uns.Unstructured, error) {
+func renderMultus(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult, manifestDir string) ([]*uns.Unstructured, error) {
 	if *conf.DisableMultiNetwork {
 		return nil, nil
 	}
@@ -32,7 +34,7 @@ func renderMultus(conf *operv1.NetworkSpec, manifestDir string) ([]*uns.Unstruct
 	out = append(out, objs...)
 
 	usedhcp := useDHCP(conf)
-	objs, err = renderMultusConfig(manifestDir, string(conf.DefaultNetwork.Type), usedhcp)
+	objs, err = renderMultusConfig(manifestDir, string(conf.DefaultNetwork.Type), usedhcp, bootstrapResult)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +50,7 @@ func renderMultus(conf *operv1.NetworkSpec, manifestDir string) ([]*uns.Unstruct
 }
 
 // renderMultusConfig returns the manifests of Multus
-func renderMultusConfig(manifestDir, defaultNetworkType string, useDHCP bool) ([]*uns.Unstructured, error) {
+func renderMultusConfig(manifestDir, defaultNetworkType string, useDHCP bool, bootstrapResult *bootstrap.BootstrapResult) ([]*uns.Unstructured, error) {
 	objs := []*uns.Unstructured{}
 
 	// render the manifests on disk
@@ -67,6 +69,23 @@ func renderMultusConfig(manifestDir, defaultNetworkType string, useDHCP bool) ([
 	data.Data["DefaultNetworkType"] = defaultNetworkType
 	data.Data["CNIBinDir"] = CNIBinDir
 
+	logLevel := bootstrapResult.Whereabouts.LogLevel
+	if logLevel == "" {
+		logLevel = "debug"
+	}
+	data.Data["WhereaboutsLogLevel"] = logLevel
+	schedule := bootstrapResult.Whereabouts.ReconcilerSchedule
+	if bootstrapResult.Whereabouts.ReconcilerDisabled {
+		schedule = ""
+	}
+	data.Data["WhereaboutsReconcilerSchedule"] = schedule
+
+	allowlist := bootstrapResult.SysctlAllowlist
+	if allowlist == "" {
+		allowlist = strings.Join(defaultSysctlAllowlist, "\n")
+	}
+	data.Data["SysctlAllowlist"] = allowlist
+
 	manifests, err := render.RenderDir(filepath.Join(manifestDir, "network/multus"), &data)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render multus manifests")
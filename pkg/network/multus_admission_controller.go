@@ -3,6 +3,7 @@ package network
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/openshift/cluster-network-operator/pkg/names"
 	"github.com/openshift/cluster-network-operator/pkg/render"
@@ -21,6 +22,12 @@ func renderMultusAdmissonControllerConfig(manifestDir string) ([]*uns.Unstructur
 	data.Data["MultusValidatingWebhookName"] = names.MULTUS_VALIDATING_WEBHOOK
 	data.Data["KubeRBACProxyImage"] = os.Getenv("KUBE_RBAC_PROXY_IMAGE")
 
+	replicas, err := multusAdmissionControllerReplicas()
+	if err != nil {
+		return nil, err
+	}
+	data.Data["MultusAdmissionControllerReplicas"] = replicas
+
 	manifests, err := render.RenderDir(filepath.Join(manifestDir, "network/multus-admission-controller"), &data)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render multus admission controller manifests")
@@ -28,3 +35,18 @@ func renderMultusAdmissonControllerConfig(manifestDir string) ([]*uns.Unstructur
 	objs = append(objs, manifests...)
 	return objs, nil
 }
+
+// multusAdmissionControllerReplicas returns the webhook replica count,
+// overridable via MULTUS_ADMISSION_CONTROLLER_REPLICAS for topologies that
+// need more (or, on single-node clusters, fewer) replicas.
+func multusAdmissionControllerReplicas() (int, error) {
+	raw := os.Getenv("MULTUS_ADMISSION_CONTROLLER_REPLICAS")
+	if raw == "" {
+		return 2, nil
+	}
+	replicas, err := strconv.Atoi(raw)
+	if err != nil || replicas < 1 {
+		return 0, errors.Errorf("invalid MULTUS_ADMISSION_CONTROLLER_REPLICAS %q: must be a positive integer", raw)
+	}
+	return replicas, nil
+}
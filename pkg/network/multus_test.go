@@ -5,6 +5,7 @@ import (
 
 	operv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/cluster-network-operator/pkg/apply"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
 
 	. "github.com/onsi/gomega"
 )
@@ -38,19 +39,19 @@ func TestRenderMultus(t *testing.T) {
 	FillDefaults(config, nil)
 
 	// disable Multus
-	objs, err := renderMultus(config, manifestDir)
+	objs, err := renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "multus")))
 
 	// enable Multus
 	enabled := false
 	config.DisableMultiNetwork = &enabled
-	objs, err = renderMultus(config, manifestDir)
+	objs, err = renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "multus")))
 
 	// It's important that the namespace is first
-	g.Expect(len(objs)).To(Equal(20))
+	g.Expect(len(objs)).To(Equal(21))
 	g.Expect(objs[0]).To(HaveKubernetesID("CustomResourceDefinition", "", "network-attachment-definitions.k8s.cni.cncf.io"))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("Namespace", "", "openshift-multus")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("ClusterRole", "", "multus")))
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/gomega"
 	operv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/cluster-network-operator/pkg/apply"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
 )
 
 var NetworkMetricsDaemonConfig = operv1.Network{
@@ -37,20 +38,20 @@ func TestRenderNetworkMetricsDaemon(t *testing.T) {
 	FillDefaults(config, nil)
 
 	// disable MultusAdmissionController
-	objs, err := renderMultus(config, manifestDir)
+	objs, err := renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "network-metrics-daemon")))
 
 	// enable MultusAdmissionController
 	enabled := false
 	config.DisableMultiNetwork = &enabled
-	objs, err = renderMultus(config, manifestDir)
+	objs, err = renderMultus(config, &bootstrap.BootstrapResult{}, manifestDir)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "network-metrics-daemon")))
 
 	// Check rendered object
 
-	g.Expect(len(objs)).To(Equal(20))
+	g.Expect(len(objs)).To(Equal(21))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("DaemonSet", "openshift-multus", "network-metrics-daemon")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("Service", "openshift-multus", "network-metrics-service")))
 	g.Expect(objs).To(ContainElement(HaveKubernetesID("ClusterRole", "", "metrics-daemon-role")))
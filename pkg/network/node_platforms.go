@@ -0,0 +1,55 @@
+package network
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getNodePlatforms returns the distinct provider schemes (the part of the
+// node providerID before "://", e.g. "aws" or "kubevirt") across all nodes,
+// sorted. A cluster whose node pools span more than one platform must not be
+// rendered assuming the infrastructure platform applies to every node.
+func getNodePlatforms(kubeClient client.Client) ([]string, error) {
+	nodes := &corev1.NodeList{}
+	if err := kubeClient.List(context.TODO(), nodes); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, node := range nodes.Items {
+		providerID := node.Spec.ProviderID
+		if providerID == "" {
+			continue
+		}
+		idx := strings.Index(providerID, "://")
+		if idx <= 0 {
+			continue
+		}
+		seen[providerID[:idx]] = true
+	}
+
+	platforms := make([]string, 0, len(seen))
+	for platform := range seen {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	if len(platforms) > 1 {
+		klog.Warningf("Cluster has nodes on multiple platforms: %s", strings.Join(platforms, ", "))
+	}
+	return platforms, nil
+}
+
+// hasNodePlatform reports whether any node runs on the given provider scheme.
+func hasNodePlatform(platforms []string, platform string) bool {
+	for _, p := range platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
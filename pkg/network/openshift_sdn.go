@@ -47,7 +47,9 @@ func renderOpenShiftSDN(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.Boo
 	data.Data["Mode"] = c.Mode
 	data.Data["CNIConfDir"] = pluginCNIConfDir(conf)
 	data.Data["CNIBinDir"] = CNIBinDir
-	if bootstrapResult.SDN.Platform == configv1.AzurePlatformType {
+	// The Azure-specific bits are needed whenever any node pool runs on
+	// Azure, not only when it is the infrastructure platform.
+	if bootstrapResult.SDN.Platform == configv1.AzurePlatformType || hasNodePlatform(bootstrapResult.SDN.NodePlatforms, "azure") {
 		data.Data["SDNPlatformAzure"] = true
 	} else {
 		data.Data["SDNPlatformAzure"] = false
@@ -295,9 +297,15 @@ func bootstrapSDN(conf *operv1.Network, kubeClient client.Client) (*bootstrap.Bo
 	}
 	klog.V(2).Infof("Openshift-SDN: Bootstrap SDN infraConfig Platform: %q", platformType)
 
+	nodePlatforms, err := getNodePlatforms(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect node platforms: %v", err)
+	}
+
 	res := bootstrap.BootstrapResult{
 		SDN: bootstrap.SDNBootstrapResult{
-			Platform: platformType,
+			Platform:      platformType,
+			NodePlatforms: nodePlatforms,
 		},
 	}
 	return &res, nil
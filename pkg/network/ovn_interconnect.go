@@ -0,0 +1,94 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getOVNInterconnectConfig reads the interconnect zone sizing configuration
+// from the OVN_INTERCONNECT_CONFIGMAP. When the ConfigMap is absent,
+// interconnect stays disabled (single zone). Invalid values are an error: a
+// wrong zone layout must not be rendered.
+func getOVNInterconnectConfig(kubeClient client.Client) (bootstrap.OVNInterconnectConfig, error) {
+	ic := bootstrap.OVNInterconnectConfig{}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.OVN_INTERCONNECT_CONFIGMAP}
+	if err := kubeClient.Get(context.TODO(), nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ic, nil
+		}
+		return ic, err
+	}
+
+	if enabled, ok := cm.Data["enabled"]; ok {
+		if enabled != "true" && enabled != "false" {
+			return ic, fmt.Errorf("invalid enabled value %q in ConfigMap %s: must be \"true\" or \"false\"", enabled, names.OVN_INTERCONNECT_CONFIGMAP)
+		}
+		ic.Enabled = enabled == "true"
+	}
+	if raw, ok := cm.Data["nodes-per-zone"]; ok {
+		nodesPerZone, err := strconv.Atoi(raw)
+		if err != nil || nodesPerZone < 1 {
+			return ic, fmt.Errorf("invalid nodes-per-zone value %q in ConfigMap %s: must be a positive integer", raw, names.OVN_INTERCONNECT_CONFIGMAP)
+		}
+		ic.NodesPerZone = nodesPerZone
+	}
+	if ic.Enabled && ic.NodesPerZone == 0 {
+		ic.NodesPerZone = 1
+	}
+	if !ic.Enabled && ic.NodesPerZone != 0 {
+		return ic, fmt.Errorf("nodes-per-zone is set in ConfigMap %s but interconnect is not enabled", names.OVN_INTERCONNECT_CONFIGMAP)
+	}
+	return ic, nil
+}
+
+// interconnectValue returns the annotation value describing the zone layout,
+// used to guard the migration between single-zone and multi-zone.
+func interconnectValue(ic bootstrap.OVNInterconnectConfig) string {
+	if !ic.Enabled {
+		// Keep the annotation empty for the single-zone layout so existing
+		// clusters that predate it do not see a spurious change.
+		return ""
+	}
+	return fmt.Sprintf("multi-zone:%d", ic.NodesPerZone)
+}
+
+// shouldUpdateOVNKonInterconnectChange guards the migration between the
+// single-zone and multi-zone layouts: the master daemonset is converted first
+// and nodes are held back until that rollout has converged, so at no point do
+// nodes speak a zone layout the control plane does not understand.
+func shouldUpdateOVNKonInterconnectChange(existingNode, existingMaster *appsv1.DaemonSet, icValue string) (updateNode, updateMaster bool) {
+	// Fresh cluster - full steam ahead!
+	if existingNode == nil || existingMaster == nil {
+		return true, true
+	}
+	nodeIC := existingNode.GetAnnotations()[names.OVNInterconnectAnnotation]
+	masterIC := existingMaster.GetAnnotations()[names.OVNInterconnectAnnotation]
+	// exit if the zone layout is unchanged
+	if nodeIC == icValue && masterIC == icValue {
+		return true, true
+	}
+	// If the master config has changed update only the master, the node will be updated later
+	if masterIC != icValue {
+		klog.V(2).Infof("Interconnect zone layout change detected to %q, updating OVN-Kubernetes master", icValue)
+		return false, true
+	}
+	// Don't rollout the changes on nodes until the master daemonset rollout has finished
+	if daemonSetProgressing(existingMaster, false) {
+		klog.V(2).Infof("Waiting for OVN-Kubernetes master daemonset zone layout rollout before updating node")
+		return false, true
+	}
+	klog.V(2).Infof("OVN-Kubernetes master daemonset rollout complete, updating zone layout on node daemonset")
+	return true, true
+}
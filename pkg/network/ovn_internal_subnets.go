@@ -0,0 +1,102 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+	iputil "github.com/openshift/cluster-network-operator/pkg/util/ip"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/utils/net"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getOVNInternalSubnets reads the optional internal subnet overrides (join,
+// masquerade, transit switch) from the OVN_INTERNAL_SUBNETS_CONFIGMAP and
+// validates them against the cluster and service networks. Unlike the gateway
+// tuning ConfigMap, invalid values here are an error rather than a warning:
+// rendering ovnkube with an overlapping internal subnet would break pod
+// networking.
+func getOVNInternalSubnets(kubeClient client.Client, conf *operv1.NetworkSpec) (bootstrap.OVNInternalSubnets, error) {
+	subnets := bootstrap.OVNInternalSubnets{}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.OVN_INTERNAL_SUBNETS_CONFIGMAP}
+	if err := kubeClient.Get(context.TODO(), nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return subnets, nil
+		}
+		return subnets, err
+	}
+
+	fields := map[string]*struct {
+		value *string
+		ipv6  bool
+	}{
+		"join-subnet-v4":           {&subnets.JoinV4, false},
+		"join-subnet-v6":           {&subnets.JoinV6, true},
+		"masquerade-subnet-v4":     {&subnets.MasqueradeV4, false},
+		"masquerade-subnet-v6":     {&subnets.MasqueradeV6, true},
+		"transit-switch-subnet-v4": {&subnets.TransitSwitchV4, false},
+		"transit-switch-subnet-v6": {&subnets.TransitSwitchV6, true},
+	}
+
+	// The internal subnets must not overlap the cluster networks, the service
+	// networks, or each other.
+	pool := iputil.IPPool{}
+	for _, cn := range conf.ClusterNetwork {
+		_, cidr, err := net.ParseCIDR(cn.CIDR)
+		if err != nil {
+			continue
+		}
+		_ = pool.Add(*cidr)
+	}
+	for _, sn := range conf.ServiceNetwork {
+		_, cidr, err := net.ParseCIDR(sn)
+		if err != nil {
+			continue
+		}
+		_ = pool.Add(*cidr)
+	}
+
+	for key, field := range fields {
+		raw, ok := cm.Data[key]
+		if !ok || raw == "" {
+			continue
+		}
+		raw = strings.TrimSpace(raw)
+		_, cidr, err := net.ParseCIDR(raw)
+		if err != nil {
+			return subnets, fmt.Errorf("invalid %s %q in ConfigMap %s: %v", key, raw, names.OVN_INTERNAL_SUBNETS_CONFIGMAP, err)
+		}
+		if utilnet.IsIPv6CIDR(cidr) != field.ipv6 {
+			return subnets, fmt.Errorf("%s %q in ConfigMap %s is not of the expected IP family", key, raw, names.OVN_INTERNAL_SUBNETS_CONFIGMAP)
+		}
+		if err := pool.Add(*cidr); err != nil {
+			return subnets, fmt.Errorf("%s in ConfigMap %s overlaps another network: %v", key, names.OVN_INTERNAL_SUBNETS_CONFIGMAP, err)
+		}
+		*field.value = cidr.String()
+	}
+	return subnets, nil
+}
+
+// internalSubnetsValue returns the annotation value summarizing the internal
+// subnet configuration, used to stage its rollout across the daemonsets.
+func internalSubnetsValue(subnets bootstrap.OVNInternalSubnets) string {
+	if subnets == (bootstrap.OVNInternalSubnets{}) {
+		// No overrides: keep the annotation empty so existing clusters that
+		// predate it do not see a spurious configuration change.
+		return ""
+	}
+	return strings.Join([]string{
+		subnets.JoinV4, subnets.JoinV6,
+		subnets.MasqueradeV4, subnets.MasqueradeV6,
+		subnets.TransitSwitchV4, subnets.TransitSwitchV6,
+	}, ",")
+}
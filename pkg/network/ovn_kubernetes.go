@@ -68,6 +68,20 @@ func renderOVNKubernetes(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.Bo
 	data.Data["CNIConfDir"] = pluginCNIConfDir(conf)
 	data.Data["CNIBinDir"] = CNIBinDir
 	data.Data["OVN_GATEWAY_MODE"] = bootstrapResult.OVN.GatewayMode
+	data.Data["OVN_GATEWAY_ROUTER_SUBNET_V4"] = bootstrapResult.OVN.GatewayRouterSubnetV4
+	data.Data["OVN_GATEWAY_ROUTER_SUBNET_V6"] = bootstrapResult.OVN.GatewayRouterSubnetV6
+	data.Data["OVN_GATEWAY_ROUTING_TABLE_ID"] = bootstrapResult.OVN.GatewayRoutingTableID
+	data.Data["OVN_PRIMARY_IFACE_POLICY"] = bootstrapResult.OVN.PrimaryInterface.Policy
+	data.Data["OVN_PRIMARY_IFACE_NAME"] = bootstrapResult.OVN.PrimaryInterface.InterfaceName
+	data.Data["OVN_PRIMARY_IFACE_SUBNET"] = bootstrapResult.OVN.PrimaryInterface.Subnet
+	data.Data["OVN_JOIN_SUBNET_V4"] = bootstrapResult.OVN.InternalSubnets.JoinV4
+	data.Data["OVN_JOIN_SUBNET_V6"] = bootstrapResult.OVN.InternalSubnets.JoinV6
+	data.Data["OVN_MASQUERADE_SUBNET_V4"] = bootstrapResult.OVN.InternalSubnets.MasqueradeV4
+	data.Data["OVN_MASQUERADE_SUBNET_V6"] = bootstrapResult.OVN.InternalSubnets.MasqueradeV6
+	data.Data["OVN_TRANSIT_SWITCH_SUBNET_V4"] = bootstrapResult.OVN.InternalSubnets.TransitSwitchV4
+	data.Data["OVN_TRANSIT_SWITCH_SUBNET_V6"] = bootstrapResult.OVN.InternalSubnets.TransitSwitchV6
+	data.Data["OVN_INTERCONNECT_ENABLE"] = bootstrapResult.OVN.Interconnect.Enabled
+	data.Data["OVN_NODES_PER_ZONE"] = bootstrapResult.OVN.Interconnect.NodesPerZone
 	data.Data["OVN_NB_PORT"] = OVN_NB_PORT
 	data.Data["OVN_SB_PORT"] = OVN_SB_PORT
 	data.Data["OVN_NB_RAFT_PORT"] = OVN_NB_RAFT_PORT
@@ -124,6 +138,14 @@ func renderOVNKubernetes(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.Bo
 	} else {
 		data.Data["EnableIPsec"] = false
 	}
+	ipsecIKE, ipsecESP, ipsecPFS, ipsecSALifetime := ipsecRenderData(bootstrapResult.OVN.IPsecConfig)
+	data.Data["OVN_IPSEC_IKE"] = ipsecIKE
+	data.Data["OVN_IPSEC_ESP"] = ipsecESP
+	data.Data["OVN_IPSEC_PFS"] = ipsecPFS
+	data.Data["OVN_IPSEC_SA_LIFETIME"] = ipsecSALifetime
+
+	data.Data["RenderIsolationPolicies"] = bootstrapResult.OVN.IsolationPolicies.Enabled
+	data.Data["IsolationAllowFrom"] = bootstrapResult.OVN.IsolationPolicies.AllowFrom
 
 	exportNetworkFlows := conf.ExportNetworkFlows
 	if exportNetworkFlows != nil {
@@ -156,10 +178,15 @@ func renderOVNKubernetes(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.Bo
 	}
 	objs = append(objs, manifests...)
 
-	// obtain the current IP family mode.
+	// obtain the current IP family mode. The primary family is part of the
+	// mode so that swapping it (IPv4-primary <-> IPv6-primary) rolls out with
+	// the same master-first staging as a single/dual-stack conversion.
 	ipFamilyMode := names.IPFamilySingleStack
 	if len(conf.ServiceNetwork) == 2 {
 		ipFamilyMode = names.IPFamilyDualStack
+		if utilnet.IsIPv6CIDRString(conf.ServiceNetwork[0]) {
+			ipFamilyMode = names.IPFamilyDualStackIPv6Primary
+		}
 	}
 	// check if the IP family mode has changed and control the conversion process.
 	updateNode, updateMaster := shouldUpdateOVNKonIPFamilyChange(bootstrapResult.OVN.ExistingNodeDaemonset, bootstrapResult.OVN.ExistingMasterDaemonset, ipFamilyMode)
@@ -170,6 +197,28 @@ func renderOVNKubernetes(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.Bo
 		return nil, errors.Wrapf(err, "failed to set IP family %s annotation on daemonsets", ipFamilyMode)
 	}
 
+	// stage internal subnet reconfiguration the same way: masters first, nodes
+	// once the master rollout has converged.
+	subnetsValue := internalSubnetsValue(bootstrapResult.OVN.InternalSubnets)
+	updateNodeSubnets, updateMasterSubnets := shouldUpdateOVNKonInternalSubnetChange(bootstrapResult.OVN.ExistingNodeDaemonset, bootstrapResult.OVN.ExistingMasterDaemonset, subnetsValue)
+	updateNode = updateNode && updateNodeSubnets
+	updateMaster = updateMaster && updateMasterSubnets
+	err = setOVNDaemonsetAnnotation(objs, names.OVNInternalSubnetsAnnotation, subnetsValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set internal subnets annotation on daemonsets")
+	}
+
+	// guard the single-zone <-> multi-zone interconnect migration the same
+	// way.
+	icValue := interconnectValue(bootstrapResult.OVN.Interconnect)
+	updateNodeIC, updateMasterIC := shouldUpdateOVNKonInterconnectChange(bootstrapResult.OVN.ExistingNodeDaemonset, bootstrapResult.OVN.ExistingMasterDaemonset, icValue)
+	updateNode = updateNode && updateNodeIC
+	updateMaster = updateMaster && updateMasterIC
+	err = setOVNDaemonsetAnnotation(objs, names.OVNInterconnectAnnotation, icValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set interconnect annotation on daemonsets")
+	}
+
 	// don't process upgrades if we are handling a dual-stack conversion.
 	if updateMaster && updateNode {
 		updateNode, updateMaster = shouldUpdateOVNKonUpgrade(bootstrapResult.OVN.ExistingNodeDaemonset, bootstrapResult.OVN.ExistingMasterDaemonset, os.Getenv("RELEASE_VERSION"))
@@ -191,31 +240,93 @@ func renderOVNKubernetes(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.Bo
 		objs = k8s.ReplaceObj(objs, us)
 	}
 
+	// Fan the node DaemonSet out per node pool, if overlays are declared.
+	objs, err = applyOVNNodeOverlays(objs, bootstrapResult.OVN.NodeOverlays)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply OVN node overlays")
+	}
+
+	// Program OVS hardware offload on the declared offload pools.
+	objs = append(objs, renderHardwareOffloadProfiles(bootstrapResult.OVN.OffloadProfiles)...)
+
+	// In external IPsec mode, restrict the ovn-ipsec DaemonSet to the
+	// selected node pool.
+	if err := scopeIPsecDaemonSet(objs, bootstrapResult.OVN.IPsecConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to scope the ovn-ipsec daemonset")
+	}
+
 	return objs, nil
 }
 
+// GatewayConfig is the supported gateway tuning surface, read from the
+// openshift-network-operator/gateway-mode-config ConfigMap. Besides the
+// routing mode it carries optional gateway router subnet overrides and a
+// custom routing table ID, so these no longer require unsupported
+// env-override ConfigMaps.
+type GatewayConfig struct {
+	Mode           string
+	RouterSubnetV4 string
+	RouterSubnetV6 string
+	RoutingTableID string
+}
+
 // returns the value of mode found in the openshift-ovn-kubernetes/gateway-mode-config configMap
 // if it exists, otherwise returns whatever the global OVN_GATEWAY_MODE is set to (shared)
 func GetGatewayMode(kubeClient client.Client) (string, error) {
-	defaultGatewayMode := "shared"
+	gc, err := GetGatewayConfig(kubeClient)
+	if err != nil {
+		return "", err
+	}
+	return gc.Mode, nil
+}
+
+// GetGatewayConfig reads and validates the gateway configuration from the
+// openshift-network-operator/gateway-mode-config ConfigMap. Invalid values
+// are ignored with a warning, falling back to the defaults.
+func GetGatewayConfig(kubeClient client.Client) (*GatewayConfig, error) {
+	gc := &GatewayConfig{Mode: "shared"}
 	cm := &corev1.ConfigMap{}
 	nsn := types.NamespacedName{Namespace: "openshift-network-operator", Name: "gateway-mode-config"}
 	err := kubeClient.Get(context.TODO(), nsn, cm)
 
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			klog.Warningf("Did not find gateway-mode-config. Using default mode: \"%s\"", defaultGatewayMode)
-			return defaultGatewayMode, nil
+			klog.Warningf("Did not find gateway-mode-config. Using default mode: \"%s\"", gc.Mode)
+			return gc, nil
 		} else {
-			return "", fmt.Errorf("Could not determine gateway mode: %w", err)
+			return nil, fmt.Errorf("Could not determine gateway mode: %w", err)
 		}
 	}
 	if cm.Data["mode"] != "shared" && cm.Data["mode"] != "local" {
 		klog.Warningf("Ignoring gateway-mode-config %s. Does not match \"shared\" or \"local\"", cm.Data["mode"])
-		return defaultGatewayMode, nil
+	} else {
+		klog.Infof("Overriding OVN gateway mode to %s", cm.Data["mode"])
+		gc.Mode = cm.Data["mode"]
+	}
+
+	if subnet := cm.Data["router-subnet-v4"]; subnet != "" {
+		if _, _, err := net.ParseCIDR(subnet); err != nil || utilnet.IsIPv6CIDRString(subnet) {
+			klog.Warningf("Ignoring invalid router-subnet-v4 %q in gateway-mode-config", subnet)
+		} else {
+			gc.RouterSubnetV4 = subnet
+		}
+	}
+	if subnet := cm.Data["router-subnet-v6"]; subnet != "" {
+		if _, _, err := net.ParseCIDR(subnet); err != nil || !utilnet.IsIPv6CIDRString(subnet) {
+			klog.Warningf("Ignoring invalid router-subnet-v6 %q in gateway-mode-config", subnet)
+		} else {
+			gc.RouterSubnetV6 = subnet
+		}
 	}
-	klog.Infof("Overriding OVN gateway mode to %s", cm.Data["mode"])
-	return cm.Data["mode"], nil
+	if tableID := cm.Data["routing-table-id"]; tableID != "" {
+		// Custom routing tables must avoid the reserved tables (253-255).
+		if id, err := strconv.Atoi(tableID); err != nil || id < 1 || id > 252 {
+			klog.Warningf("Ignoring invalid routing-table-id %q in gateway-mode-config; must be 1-252", tableID)
+		} else {
+			gc.RoutingTableID = tableID
+		}
+	}
+	return gc, nil
 }
 
 // validateOVNKubernetes checks that the ovn-kubernetes specific configuration
@@ -378,11 +489,53 @@ func bootstrapOVN(conf *operv1.Network, kubeClient client.Client) (*bootstrap.Bo
 		return nil, fmt.Errorf("Unable to bootstrap OVN, unable to unmarshal install-config: %s", err)
 	}
 
-	gatewayMode, err := GetGatewayMode(kubeClient)
+	gatewayConfig, err := GetGatewayConfig(kubeClient)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to bootstrap OVN, undetermined gateway-mode: '%s'", err)
 	}
 
+	internalSubnets, err := getOVNInternalSubnets(kubeClient, &conf.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, invalid internal subnets: %s", err)
+	}
+
+	interconnect, err := getOVNInterconnectConfig(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, invalid interconnect config: %s", err)
+	}
+
+	nodeOverlays, err := getOVNNodeOverlays(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, could not read node overlays: %w", err)
+	}
+
+	offloadProfiles, err := getHardwareOffloadProfiles(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, could not read hardware offload profiles: %w", err)
+	}
+
+	ipsecConfig, err := getIPsecConfig(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, invalid IPsec config: %s", err)
+	}
+
+	isolationPolicies, err := getIsolationPolicyConfig(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, invalid isolation policy config: %s", err)
+	}
+
+	primaryInterface, err := getPrimaryInterfacePolicy(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, invalid primary interface policy: %s", err)
+	}
+
+	nodePlatforms, err := getNodePlatforms(kubeClient)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to bootstrap OVN, failed to detect node platforms: %s", err)
+	}
+	// Offload pools get their own ovnkube-node DaemonSet variant.
+	nodeOverlays = append(nodeOverlays, offloadProfileOverlays(offloadProfiles)...)
+
 	controlPlaneReplicaCount, _ := strconv.Atoi(rcD.ControlPlane.Replicas)
 
 	var heartBeat int
@@ -483,7 +636,18 @@ func bootstrapOVN(conf *operv1.Network, kubeClient client.Client) (*bootstrap.Bo
 			ClusterInitiator:        clusterInitiator,
 			ExistingMasterDaemonset: masterDS,
 			ExistingNodeDaemonset:   nodeDS,
-			GatewayMode:             gatewayMode,
+			GatewayMode:             gatewayConfig.Mode,
+			GatewayRouterSubnetV4:   gatewayConfig.RouterSubnetV4,
+			GatewayRouterSubnetV6:   gatewayConfig.RouterSubnetV6,
+			GatewayRoutingTableID:   gatewayConfig.RoutingTableID,
+			NodeOverlays:            nodeOverlays,
+			InternalSubnets:         internalSubnets,
+			Interconnect:            interconnect,
+			OffloadProfiles:         offloadProfiles,
+			IPsecConfig:             ipsecConfig,
+			IsolationPolicies:       isolationPolicies,
+			PrimaryInterface:        primaryInterface,
+			NodePlatforms:           nodePlatforms,
 		},
 	}
 	return &res, nil
@@ -550,6 +714,36 @@ func shouldUpdateOVNKonIPFamilyChange(existingNode, existingMaster *appsv1.Daemo
 	return true, true
 }
 
+// shouldUpdateOVNKonInternalSubnetChange determines if we should roll out
+// changes to the master and node daemonsets when the internal subnet
+// configuration (join / masquerade / transit switch) changes. Masters are
+// updated first; nodes wait for the master rollout to converge, so existing
+// nodes keep working against the old subnets until the control plane is ready.
+func shouldUpdateOVNKonInternalSubnetChange(existingNode, existingMaster *appsv1.DaemonSet, subnetsValue string) (updateNode, updateMaster bool) {
+	// Fresh cluster - full steam ahead!
+	if existingNode == nil || existingMaster == nil {
+		return true, true
+	}
+	nodeSubnets := existingNode.GetAnnotations()[names.OVNInternalSubnetsAnnotation]
+	masterSubnets := existingMaster.GetAnnotations()[names.OVNInternalSubnetsAnnotation]
+	// exit if there are no internal subnet changes
+	if nodeSubnets == subnetsValue && masterSubnets == subnetsValue {
+		return true, true
+	}
+	// If the master config has changed update only the master, the node will be updated later
+	if masterSubnets != subnetsValue {
+		klog.V(2).Infof("Internal subnet change detected, updating OVN-Kubernetes master")
+		return false, true
+	}
+	// Don't rollout the changes on nodes until the master daemonset rollout has finished
+	if daemonSetProgressing(existingMaster, false) {
+		klog.V(2).Infof("Waiting for OVN-Kubernetes master daemonset internal subnet rollout before updating node")
+		return false, true
+	}
+	klog.V(2).Infof("OVN-Kubernetes master daemonset rollout complete, updating internal subnets on node daemonset")
+	return true, true
+}
+
 // shouldUpdateOVNKonUpgrade determines if we should roll out changes to
 // the master and node daemonsets on upgrades. We roll out nodes first,
 // then masters. Downgrades, we do the opposite.
@@ -1432,3 +1432,36 @@ func checkDaemonsetAnnotation(g *WithT, objs []*uns.Unstructured, key, value str
 func ptrToUint32(x uint32) *uint32 {
 	return &x
 }
+
+func TestRenderOVNKubernetesIsolationPolicies(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	crd := OVNKubernetesConfig.DeepCopy()
+	config := &crd.Spec
+	FillDefaults(config, nil)
+
+	// Disabled by default: no NetworkPolicies rendered.
+	bootstrapResult := &bootstrap.BootstrapResult{
+		OVN: bootstrap.OVNBootstrapResult{
+			MasterIPs: []string{"1.2.3.4"},
+		},
+	}
+	objs, err := renderOVNKubernetes(config, bootstrapResult, manifestDirOvn)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).NotTo(ContainElement(HaveKubernetesID("NetworkPolicy", "openshift-ovn-kubernetes", "default-deny-ingress")))
+
+	bootstrapResult.OVN.IsolationPolicies = bootstrap.IsolationPolicyConfig{
+		Enabled: true,
+		AllowFrom: []bootstrap.IsolationAllowFrom{
+			{Key: "tenant"},
+			{Key: "team", Value: "networking"},
+		},
+	}
+	objs, err = renderOVNKubernetes(config, bootstrapResult, manifestDirOvn)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("NetworkPolicy", "openshift-ovn-kubernetes", "default-deny-ingress")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("NetworkPolicy", "openshift-ovn-kubernetes", "allow-same-namespace")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("NetworkPolicy", "openshift-ovn-kubernetes", "allow-from-monitoring")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("NetworkPolicy", "openshift-ovn-kubernetes", "allow-from-configured-0")))
+	g.Expect(objs).To(ContainElement(HaveKubernetesID("NetworkPolicy", "openshift-ovn-kubernetes", "allow-from-configured-1")))
+}
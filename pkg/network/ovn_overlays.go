@@ -0,0 +1,147 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getOVNNodeOverlays discovers per-node-pool overlay ConfigMaps (labeled with
+// names.OVNNodeOverlayLabel in the operator namespace). Each overlay yields an
+// additional ovnkube-node DaemonSet scoped to the nodes carrying the
+// overlay's node-selector label. Invalid overlays are skipped with a warning.
+func getOVNNodeOverlays(kubeClient client.Client) ([]bootstrap.OVNNodeOverlay, error) {
+	cms := &corev1.ConfigMapList{}
+	err := kubeClient.List(context.TODO(), cms,
+		client.InNamespace(names.APPLIED_NAMESPACE), client.HasLabels{names.OVNNodeOverlayLabel})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list OVN node overlay ConfigMaps")
+	}
+
+	overlays := []bootstrap.OVNNodeOverlay{}
+	for _, cm := range cms.Items {
+		selector := cm.Data["node-selector"]
+		parts := strings.SplitN(selector, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			klog.Warningf("Ignoring OVN node overlay %s: node-selector must be of the form key=value, got %q", cm.Name, selector)
+			continue
+		}
+		overlays = append(overlays, bootstrap.OVNNodeOverlay{
+			Name:          cm.Name,
+			LabelKey:      parts[0],
+			LabelValue:    parts[1],
+			CPURequest:    cm.Data["cpu-request"],
+			MemoryRequest: cm.Data["memory-request"],
+		})
+	}
+	return overlays, nil
+}
+
+// applyOVNNodeOverlays turns the single rendered ovnkube-node DaemonSet into
+// one DaemonSet per node pool: each overlay gets a clone restricted to its
+// nodes (with its resource overrides applied), and the base DaemonSet is
+// excluded from all overlay nodes so no node runs two copies.
+func applyOVNNodeOverlays(objs []*uns.Unstructured, overlays []bootstrap.OVNNodeOverlay) ([]*uns.Unstructured, error) {
+	if len(overlays) == 0 {
+		return objs, nil
+	}
+
+	var base *uns.Unstructured
+	for _, obj := range objs {
+		if obj.GetKind() == "DaemonSet" && obj.GetNamespace() == "openshift-ovn-kubernetes" && obj.GetName() == "ovnkube-node" {
+			base = obj
+			break
+		}
+	}
+	if base == nil {
+		return objs, nil
+	}
+
+	for _, overlay := range overlays {
+		clone := base.DeepCopy()
+		clone.SetName(fmt.Sprintf("ovnkube-node-%s", overlay.Name))
+
+		selector, _, err := uns.NestedStringMap(clone.Object, "spec", "template", "spec", "nodeSelector")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read nodeSelector for overlay %s", overlay.Name)
+		}
+		if selector == nil {
+			selector = map[string]string{}
+		}
+		selector[overlay.LabelKey] = overlay.LabelValue
+		if err := uns.SetNestedStringMap(clone.Object, selector, "spec", "template", "spec", "nodeSelector"); err != nil {
+			return nil, errors.Wrapf(err, "failed to set nodeSelector for overlay %s", overlay.Name)
+		}
+
+		if err := setOVNNodeResourceRequests(clone, overlay); err != nil {
+			return nil, errors.Wrapf(err, "failed to set resource requests for overlay %s", overlay.Name)
+		}
+		objs = append(objs, clone)
+	}
+
+	// Keep the base DaemonSet off the overlay nodes.
+	matchExpressions := []interface{}{}
+	for _, overlay := range overlays {
+		matchExpressions = append(matchExpressions, map[string]interface{}{
+			"key":      overlay.LabelKey,
+			"operator": "DoesNotExist",
+		})
+	}
+	affinity := map[string]interface{}{
+		"nodeAffinity": map[string]interface{}{
+			"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+				"nodeSelectorTerms": []interface{}{
+					map[string]interface{}{"matchExpressions": matchExpressions},
+				},
+			},
+		},
+	}
+	if err := uns.SetNestedMap(base.Object, affinity, "spec", "template", "spec", "affinity"); err != nil {
+		return nil, errors.Wrap(err, "failed to exclude overlay nodes from the base ovnkube-node DaemonSet")
+	}
+
+	return objs, nil
+}
+
+// setOVNNodeResourceRequests applies the overlay's CPU/memory request
+// overrides to the ovnkube-node container.
+func setOVNNodeResourceRequests(ds *uns.Unstructured, overlay bootstrap.OVNNodeOverlay) error {
+	if overlay.CPURequest == "" && overlay.MemoryRequest == "" {
+		return nil
+	}
+	containers, found, err := uns.NestedSlice(ds.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		return errors.Errorf("failed to read containers: %v", err)
+	}
+	for i := range containers {
+		container, ok := containers[i].(map[string]interface{})
+		if !ok || container["name"] != "ovnkube-node" {
+			continue
+		}
+		requests := map[string]interface{}{}
+		if existing, found, _ := uns.NestedMap(container, "resources", "requests"); found {
+			requests = existing
+		}
+		if overlay.CPURequest != "" {
+			requests["cpu"] = overlay.CPURequest
+		}
+		if overlay.MemoryRequest != "" {
+			requests["memory"] = overlay.MemoryRequest
+		}
+		if err := uns.SetNestedMap(container, requests, "resources", "requests"); err != nil {
+			return err
+		}
+		containers[i] = container
+	}
+	return uns.SetNestedSlice(ds.Object, containers, "spec", "template", "spec", "containers")
+}
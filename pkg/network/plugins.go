@@ -0,0 +1,125 @@
+package network
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/render"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// pluginManifestDirEnv names a directory of third-party network provider
+// plugin manifests. Each subdirectory is a provider: its name is the
+// NetworkType it serves, and its contents are rendered when that type is the
+// default network.
+const pluginManifestDirEnv = "CNO_NETWORK_PLUGIN_DIR"
+
+// NetworkProvider is the set of hooks a default network renderer implements.
+// Out-of-tree providers register one via RegisterNetworkProvider (or are
+// discovered from the plugin manifest directory) instead of being added to
+// the hard-coded type switches.
+type NetworkProvider interface {
+	// FillDefaults applies provider defaults to conf, carrying values forward
+	// from previous when set.
+	FillDefaults(conf, previous *operv1.NetworkSpec, hostMTU int)
+	// Validate checks the provider-specific configuration.
+	Validate(conf *operv1.NetworkSpec) []error
+	// IsChangeSafe checks whether a configuration change is allowed.
+	IsChangeSafe(prev, next *operv1.NetworkSpec) []error
+	// Render produces the provider's objects.
+	Render(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult, manifestDir string) ([]*uns.Unstructured, error)
+}
+
+var networkProvidersMu sync.Mutex
+var networkProviders = map[operv1.NetworkType]NetworkProvider{}
+var loadPluginsOnce sync.Once
+
+// RegisterNetworkProvider registers a default network provider for the given
+// type. The built-in types cannot be overridden.
+func RegisterNetworkProvider(networkType operv1.NetworkType, provider NetworkProvider) error {
+	switch networkType {
+	case operv1.NetworkTypeOpenShiftSDN, operv1.NetworkTypeOVNKubernetes, operv1.NetworkTypeKuryr:
+		return errors.Errorf("network type %s is built in and cannot be overridden", networkType)
+	}
+	networkProvidersMu.Lock()
+	defer networkProvidersMu.Unlock()
+	if _, exists := networkProviders[networkType]; exists {
+		return errors.Errorf("a provider for network type %s is already registered", networkType)
+	}
+	networkProviders[networkType] = provider
+	return nil
+}
+
+// networkProvider returns the registered provider for the given type, if any,
+// loading plugin-manifest providers on first use.
+func networkProvider(networkType operv1.NetworkType) (NetworkProvider, bool) {
+	loadPluginsOnce.Do(loadPluginProviders)
+	networkProvidersMu.Lock()
+	defer networkProvidersMu.Unlock()
+	provider, ok := networkProviders[networkType]
+	return provider, ok
+}
+
+// loadPluginProviders discovers manifest-based providers from the plugin
+// manifest directory, if one is declared.
+func loadPluginProviders() {
+	pluginDir := os.Getenv(pluginManifestDirEnv)
+	if pluginDir == "" {
+		return
+	}
+	entries, err := os.ReadDir(pluginDir)
+	if err != nil {
+		log.Printf("Failed to read network plugin manifest directory %s: %v", pluginDir, err)
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		networkType := operv1.NetworkType(entry.Name())
+		provider := &manifestProvider{manifestDir: filepath.Join(pluginDir, entry.Name())}
+		if err := RegisterNetworkProvider(networkType, provider); err != nil {
+			log.Printf("Skipping network plugin %s: %v", entry.Name(), err)
+			continue
+		}
+		log.Printf("Registered third-party network provider %s from %s", networkType, provider.manifestDir)
+	}
+}
+
+// manifestProvider is a NetworkProvider backed purely by a directory of
+// templated manifests; it imposes no defaults and no change-safety
+// restrictions of its own.
+type manifestProvider struct {
+	manifestDir string
+}
+
+func (p *manifestProvider) FillDefaults(conf, previous *operv1.NetworkSpec, hostMTU int) {}
+
+func (p *manifestProvider) Validate(conf *operv1.NetworkSpec) []error { return nil }
+
+func (p *manifestProvider) IsChangeSafe(prev, next *operv1.NetworkSpec) []error { return nil }
+
+func (p *manifestProvider) Render(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult, manifestDir string) ([]*uns.Unstructured, error) {
+	data := render.MakeRenderData()
+	data.Data["ReleaseVersion"] = os.Getenv("RELEASE_VERSION")
+	data.Data["ServiceNetwork"] = conf.ServiceNetwork
+	clusterNetwork, err := json.Marshal(conf.ClusterNetwork)
+	if err != nil {
+		return nil, err
+	}
+	data.Data["ClusterNetwork"] = string(clusterNetwork)
+
+	manifests, err := render.RenderDir(p.manifestDir, &data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render network provider manifests from %s", p.manifestDir)
+	}
+	return manifests, nil
+}
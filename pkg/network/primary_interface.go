@@ -0,0 +1,72 @@
+package network
+
+// The primary interface selection policy lets clusters whose NIC naming
+// defeats auto-detection tell ovnkube-node how to pick the interface that
+// br-ex attaches to: by the default route (the default), by an interface
+// name glob, or by the subnet the interface's address falls in. It is read
+// from a ConfigMap in the operator namespace and rendered into the
+// ovnkube-node DaemonSet.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getPrimaryInterfacePolicy reads and validates the primary interface
+// selection policy ConfigMap.
+func getPrimaryInterfacePolicy(kubeClient client.Client) (bootstrap.PrimaryInterfacePolicyConfig, error) {
+	pic := bootstrap.PrimaryInterfacePolicyConfig{Policy: "default-route"}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.PRIMARY_INTERFACE_POLICY_CONFIGMAP}
+	if err := kubeClient.Get(context.TODO(), nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return pic, nil
+		}
+		return pic, err
+	}
+
+	if policy, ok := cm.Data["policy"]; ok {
+		switch policy {
+		case "default-route", "interface-name", "subnet":
+			pic.Policy = policy
+		default:
+			return pic, fmt.Errorf("invalid policy %q in ConfigMap %s: must be \"default-route\", \"interface-name\" or \"subnet\"", policy, names.PRIMARY_INTERFACE_POLICY_CONFIGMAP)
+		}
+	}
+
+	pic.InterfaceName = cm.Data["interface-name"]
+	if pic.Policy == "interface-name" {
+		if pic.InterfaceName == "" {
+			return pic, fmt.Errorf("interface-name must be set in ConfigMap %s when policy is \"interface-name\"", names.PRIMARY_INTERFACE_POLICY_CONFIGMAP)
+		}
+		if _, err := filepath.Match(pic.InterfaceName, "eth0"); err != nil {
+			return pic, fmt.Errorf("invalid interface-name glob %q in ConfigMap %s: %v", pic.InterfaceName, names.PRIMARY_INTERFACE_POLICY_CONFIGMAP, err)
+		}
+	} else if pic.InterfaceName != "" {
+		return pic, fmt.Errorf("interface-name is set in ConfigMap %s but policy is %q", names.PRIMARY_INTERFACE_POLICY_CONFIGMAP, pic.Policy)
+	}
+
+	pic.Subnet = cm.Data["subnet"]
+	if pic.Policy == "subnet" {
+		if pic.Subnet == "" {
+			return pic, fmt.Errorf("subnet must be set in ConfigMap %s when policy is \"subnet\"", names.PRIMARY_INTERFACE_POLICY_CONFIGMAP)
+		}
+		if _, _, err := net.ParseCIDR(pic.Subnet); err != nil {
+			return pic, fmt.Errorf("invalid subnet %q in ConfigMap %s: %v", pic.Subnet, names.PRIMARY_INTERFACE_POLICY_CONFIGMAP, err)
+		}
+	} else if pic.Subnet != "" {
+		return pic, fmt.Errorf("subnet is set in ConfigMap %s but policy is %q", names.PRIMARY_INTERFACE_POLICY_CONFIGMAP, pic.Policy)
+	}
+
+	return pic, nil
+}
@@ -24,7 +24,7 @@ func Render(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult
 	objs := []*uns.Unstructured{}
 
 	// render Multus
-	o, err := renderMultus(conf, manifestDir)
+	o, err := renderMultus(conf, bootstrapResult, manifestDir)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +66,14 @@ func Render(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult
 	objs = append(objs, o...)
 
 	// render network diagnostics
-	o, err = renderNetworkDiagnostics(conf, manifestDir)
+	o, err = renderNetworkDiagnostics(conf, bootstrapResult, manifestDir)
+	if err != nil {
+		return nil, err
+	}
+	objs = append(objs, o...)
+
+	// render the MTU prober
+	o, err = renderMTUProber(manifestDir)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +83,21 @@ func Render(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult
 	return objs, nil
 }
 
+// renderMTUProber renders the DaemonSet that publishes each node's uplink MTU
+// as a Node annotation, which the mtu controller aggregates into per-pool
+// minimums.
+func renderMTUProber(manifestDir string) ([]*uns.Unstructured, error) {
+	data := render.MakeRenderData()
+	data.Data["ReleaseVersion"] = os.Getenv("RELEASE_VERSION")
+	data.Data["CNOImage"] = os.Getenv("NETWORK_CHECK_SOURCE_IMAGE")
+
+	manifests, err := render.RenderDir(filepath.Join(manifestDir, "mtu-prober"), &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render mtu-prober manifests")
+	}
+	return manifests, nil
+}
+
 // deprecatedCanonicalizeIPAMConfig converts configuration to a canonical form
 // for backward compatibility.
 func deprecatedCanonicalizeIPAMConfig(conf *operv1.IPAMConfig) {
@@ -109,7 +131,7 @@ func deprecatedCanonicalizeSimpleMacvlanConfig(conf *operv1.SimpleMacvlanConfig)
 // DeprecatedCanonicalize converts configuration to a canonical form for backward
 // compatibility.
 //
-//      *** DO NOT ADD ANY NEW CANONICALIZATION TO THIS FUNCTION! ***
+//	*** DO NOT ADD ANY NEW CANONICALIZATION TO THIS FUNCTION! ***
 //
 // Altering the user-provided configuration from CNO causes problems when other components
 // need to look at the configuration before CNO starts. Users should just write the
@@ -182,6 +204,11 @@ func Validate(conf *operv1.NetworkSpec) error {
 // can change defaults as we move forward, but won't disrupt existing clusters.
 func FillDefaults(conf, previous *operv1.NetworkSpec) {
 	hostMTU, err := getDefaultMTU()
+	if probed := ProbedHostMTU(); probed > 0 {
+		// A multi-node probe consensus takes precedence over the MTU of the
+		// node the operator happens to run on.
+		hostMTU, err = probed, nil
+	}
 	if hostMTU == 0 {
 		hostMTU = 1500
 	}
@@ -285,7 +312,13 @@ func isNetworkChangeSafe(prev, next *operv1.NetworkSpec) error {
 		// Going from dual to single
 		dualStack, singleStack = prev, next
 	default:
-		// They didn't change single-vs-dual
+		// They didn't change single-vs-dual. Swapping the primary IP family of
+		// an existing dual-stack config is allowed as a standalone change, so
+		// a single-stack IPv6 cluster can convert to dual-stack and then make
+		// IPv4 primary (or vice versa) in two validated steps.
+		if isPrimaryIPFamilySwap(prev, next) {
+			return nil
+		}
 		if reflect.DeepEqual(prev.ServiceNetwork, next.ServiceNetwork) {
 			return errors.Errorf("cannot change ClusterNetwork")
 		} else {
@@ -322,6 +355,49 @@ func isNetworkChangeSafe(prev, next *operv1.NetworkSpec) error {
 	return nil
 }
 
+// isPrimaryIPFamilySwap returns true if prev and next are both dual-stack
+// configs containing exactly the same networks, but with the order of the IP
+// families reversed. No addresses may be added, removed, or changed; only the
+// primary family flips.
+func isPrimaryIPFamilySwap(prev, next *operv1.NetworkSpec) bool {
+	if len(prev.ServiceNetwork) != 2 || len(next.ServiceNetwork) != 2 {
+		return false
+	}
+	if prev.ServiceNetwork[0] != next.ServiceNetwork[1] || prev.ServiceNetwork[1] != next.ServiceNetwork[0] {
+		return false
+	}
+	if utilnet.IsIPv6CIDRString(next.ServiceNetwork[0]) == utilnet.IsIPv6CIDRString(next.ServiceNetwork[1]) {
+		return false
+	}
+
+	// The ClusterNetwork must contain the same entries with the two family
+	// groups swapped but each group's internal order preserved.
+	prevByFamily := clusterNetworkByFamily(prev.ClusterNetwork)
+	nextByFamily := clusterNetworkByFamily(next.ClusterNetwork)
+	if !reflect.DeepEqual(prevByFamily, nextByFamily) {
+		return false
+	}
+	wantFirstIPv6 := utilnet.IsIPv6CIDRString(next.ServiceNetwork[0])
+	for i, cn := range next.ClusterNetwork {
+		isIPv6 := utilnet.IsIPv6CIDRString(cn.CIDR)
+		if (i < len(nextByFamily[wantFirstIPv6])) != (isIPv6 == wantFirstIPv6) {
+			return false
+		}
+	}
+	return true
+}
+
+// clusterNetworkByFamily splits ClusterNetwork entries into the IPv4 (false)
+// and IPv6 (true) groups, preserving order within each group.
+func clusterNetworkByFamily(entries []operv1.ClusterNetworkEntry) map[bool][]operv1.ClusterNetworkEntry {
+	byFamily := map[bool][]operv1.ClusterNetworkEntry{}
+	for _, cn := range entries {
+		isIPv6 := utilnet.IsIPv6CIDRString(cn.CIDR)
+		byFamily[isIPv6] = append(byFamily[isIPv6], cn)
+	}
+	return byFamily
+}
+
 // validateIPPools checks that all IP addresses are valid
 // TODO: check for overlap
 func validateIPPools(conf *operv1.NetworkSpec) []error {
@@ -429,6 +505,9 @@ func validateDefaultNetwork(conf *operv1.NetworkSpec) []error {
 	case operv1.NetworkTypeKuryr:
 		return validateKuryr(conf)
 	default:
+		if provider, ok := networkProvider(conf.DefaultNetwork.Type); ok {
+			return provider.Validate(conf)
+		}
 		return nil
 	}
 }
@@ -449,6 +528,9 @@ func renderDefaultNetwork(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.B
 	case operv1.NetworkTypeKuryr:
 		return renderKuryr(conf, bootstrapResult, manifestDir)
 	default:
+		if provider, ok := networkProvider(dn.Type); ok {
+			return provider.Render(conf, bootstrapResult, manifestDir)
+		}
 		log.Printf("NOTICE: Unknown network type %s, ignoring", dn.Type)
 		return nil, nil
 	}
@@ -463,6 +545,9 @@ func fillDefaultNetworkDefaults(conf, previous *operv1.NetworkSpec, hostMTU int)
 	case operv1.NetworkTypeKuryr:
 		fillKuryrDefaults(conf, previous)
 	default:
+		if provider, ok := networkProvider(conf.DefaultNetwork.Type); ok {
+			provider.FillDefaults(conf, previous, hostMTU)
+		}
 	}
 }
 
@@ -487,6 +572,9 @@ func isDefaultNetworkChangeSafe(prev, next *operv1.NetworkSpec) []error {
 		case operv1.NetworkTypeKuryr:
 			return isKuryrChangeSafe(prev, next)
 		default:
+			if provider, ok := networkProvider(prev.DefaultNetwork.Type); ok {
+				return provider.IsChangeSafe(prev, next)
+			}
 			return nil
 		}
 	}
@@ -598,7 +686,7 @@ func renderMultiNetworkpolicy(conf *operv1.NetworkSpec, manifestDir string) ([]*
 }
 
 // renderNetworkDiagnostics renders the connectivity checks
-func renderNetworkDiagnostics(conf *operv1.NetworkSpec, manifestDir string) ([]*uns.Unstructured, error) {
+func renderNetworkDiagnostics(conf *operv1.NetworkSpec, bootstrapResult *bootstrap.BootstrapResult, manifestDir string) ([]*uns.Unstructured, error) {
 	if conf.DisableNetworkDiagnostics {
 		return nil, nil
 	}
@@ -607,6 +695,12 @@ func renderNetworkDiagnostics(conf *operv1.NetworkSpec, manifestDir string) ([]*
 	data.Data["ReleaseVersion"] = os.Getenv("RELEASE_VERSION")
 	data.Data["NetworkCheckSourceImage"] = os.Getenv("NETWORK_CHECK_SOURCE_IMAGE")
 	data.Data["NetworkCheckTargetImage"] = os.Getenv("NETWORK_CHECK_TARGET_IMAGE")
+	data.Data["NetworkCheckProbeInterval"] = ""
+	if bootstrapResult.Diagnostics.ProbeInterval != 0 {
+		data.Data["NetworkCheckProbeInterval"] = bootstrapResult.Diagnostics.ProbeInterval.String()
+	}
+	data.Data["NetworkCheckDNSNames"] = strings.Join(bootstrapResult.Diagnostics.DNSNames, ",")
+	data.Data["NetworkCheckLatencyProbes"] = bootstrapResult.Diagnostics.LatencyProbes
 
 	manifests, err := render.RenderDir(filepath.Join(manifestDir, "network-diagnostics"), &data)
 	if err != nil {
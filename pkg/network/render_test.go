@@ -76,6 +76,24 @@ func TestIsChangeSafe(t *testing.T) {
 	err = IsChangeSafe(next, prev)
 	g.Expect(err).To(MatchError(ContainSubstring("cannot change ServiceNetwork")))
 
+	// But you can swap the primary IP family of an existing dual-stack config
+	dualStack := OpenShiftSDNConfig.Spec.DeepCopy()
+	FillDefaults(dualStack, nil)
+	dualStack.ServiceNetwork = append(dualStack.ServiceNetwork, "fd02::/112")
+	dualStack.ClusterNetwork = append(dualStack.ClusterNetwork, operv1.ClusterNetworkEntry{
+		CIDR:       "fd01::/48",
+		HostPrefix: 64,
+	})
+	next = dualStack.DeepCopy()
+	next.ServiceNetwork = []string{dualStack.ServiceNetwork[1], dualStack.ServiceNetwork[0]}
+	next.ClusterNetwork = append(next.ClusterNetwork[len(dualStack.ClusterNetwork)-1:], dualStack.ClusterNetwork[:len(dualStack.ClusterNetwork)-1]...)
+	err = IsChangeSafe(dualStack, next)
+	g.Expect(err).NotTo(HaveOccurred())
+	// ...but not swap the primary family and change the networks at once
+	next.ClusterNetwork[0].CIDR = "fd03::/48"
+	err = IsChangeSafe(dualStack, next)
+	g.Expect(err).To(MatchError(ContainSubstring("cannot change ServiceNetwork")))
+
 	// You can add multiple ClusterNetworks of the new IP family
 	next = OpenShiftSDNConfig.Spec.DeepCopy()
 	FillDefaults(next, nil)
@@ -0,0 +1,125 @@
+package network
+
+// The cni-sysctl-allowlist ConfigMap lists the sysctl patterns the tuning
+// plugin may set on pod interfaces. Namespaces can request additional safe
+// sysctls through labeled ConfigMaps; requests are validated against a deny
+// pattern list and merged into the rendered allowlist with a comment
+// recording who requested them.
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultSysctlAllowlist are the interface-scoped sysctls that are always
+// safe to allow.
+var defaultSysctlAllowlist = []string{
+	"^net\\.ipv4\\.conf\\.IFNAME\\.accept_redirects$",
+	"^net\\.ipv4\\.conf\\.IFNAME\\.accept_source_route$",
+	"^net\\.ipv4\\.conf\\.IFNAME\\.arp_accept$",
+	"^net\\.ipv4\\.conf\\.IFNAME\\.arp_notify$",
+	"^net\\.ipv4\\.conf\\.IFNAME\\.disable_policy$",
+	"^net\\.ipv4\\.conf\\.IFNAME\\.secure_redirects$",
+	"^net\\.ipv4\\.conf\\.IFNAME\\.send_redirects$",
+	"^net\\.ipv6\\.conf\\.IFNAME\\.accept_ra$",
+	"^net\\.ipv6\\.conf\\.IFNAME\\.accept_redirects$",
+	"^net\\.ipv6\\.conf\\.IFNAME\\.accept_source_route$",
+	"^net\\.ipv6\\.neigh\\.IFNAME\\.base_reachable_time_ms$",
+	"^net\\.ipv6\\.neigh\\.IFNAME\\.retrans_time_ms$",
+}
+
+// requiredSysctlPrefix is the literal anchored prefix every requested
+// pattern must start with, restricting requests to network sysctls.
+const requiredSysctlPrefix = `^net\.`
+
+// forbiddenSysctls are canonical names of node-affecting sysctls that no
+// requested pattern may match, however the pattern is spelled. Non-network
+// sysctls are already unreachable through the required prefix; this list
+// covers the node-scoped settings living under net.
+var forbiddenSysctls = []string{
+	"net.core.somaxconn",
+	"net.core.rmem_max",
+	"net.core.wmem_max",
+	"net.core.netdev_max_backlog",
+	"net.ipv4.ip_forward",
+	"net.ipv4.ip_local_port_range",
+	"net.ipv4.conf.all.rp_filter",
+	"net.ipv4.conf.default.rp_filter",
+	"net.ipv4.conf.all.send_redirects",
+	"net.ipv4.conf.default.send_redirects",
+	"net.ipv4.conf.all.accept_redirects",
+	"net.ipv4.conf.default.accept_redirects",
+	"net.ipv6.conf.all.forwarding",
+	"net.ipv6.conf.default.forwarding",
+	"net.ipv6.conf.all.accept_ra",
+	"net.ipv6.conf.default.accept_ra",
+}
+
+// validateRequestedSysctl checks one requested allowlist pattern. The deny
+// check is semantic: the compiled pattern is matched against the forbidden
+// sysctl names, so no alternate spelling of a forbidden name slips through.
+func validateRequestedSysctl(pattern string) error {
+	if strings.TrimSpace(pattern) != pattern || pattern == "" {
+		return fmt.Errorf("sysctl pattern must be non-empty without surrounding whitespace")
+	}
+	if !strings.HasPrefix(pattern, requiredSysctlPrefix) {
+		return fmt.Errorf("sysctl pattern must start with the anchored prefix %q", requiredSysctlPrefix)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid sysctl pattern: %v", err)
+	}
+	for _, forbidden := range forbiddenSysctls {
+		if re.MatchString(forbidden) {
+			return fmt.Errorf("sysctl pattern %q matches forbidden sysctl %q", pattern, forbidden)
+		}
+	}
+	return nil
+}
+
+// getSysctlAllowlist builds the contents of the cni-sysctl-allowlist
+// ConfigMap: the default allowlist followed by the validated per-namespace
+// requests, each annotated with the ConfigMap that requested it.
+func getSysctlAllowlist(kubeClient crclient.Client) (string, error) {
+	lines := append([]string{}, defaultSysctlAllowlist...)
+
+	cms := &corev1.ConfigMapList{}
+	err := kubeClient.List(context.TODO(), cms, crclient.MatchingLabels{names.SysctlAllowlistRequestLabel: "true"})
+	if err != nil && !meta.IsNoMatchError(err) {
+		return "", err
+	}
+
+	// sort requests for deterministic rendering
+	sort.Slice(cms.Items, func(i, j int) bool {
+		if cms.Items[i].Namespace != cms.Items[j].Namespace {
+			return cms.Items[i].Namespace < cms.Items[j].Namespace
+		}
+		return cms.Items[i].Name < cms.Items[j].Name
+	})
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		for _, pattern := range strings.Split(cm.Data["sysctls"], "\n") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern == "" {
+				continue
+			}
+			if err := validateRequestedSysctl(pattern); err != nil {
+				klog.Warningf("Rejecting sysctl allowlist request from ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+				continue
+			}
+			klog.Infof("Allowing sysctl pattern %q requested by ConfigMap %s/%s", pattern, cm.Namespace, cm.Name)
+			lines = append(lines, fmt.Sprintf("# requested by ConfigMap %s/%s", cm.Namespace, cm.Name), pattern)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,60 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// whereaboutsLogLevels are the logging levels the whereabouts plugin accepts.
+var whereaboutsLogLevels = map[string]struct{}{
+	"panic":   {},
+	"error":   {},
+	"debug":   {},
+	"verbose": {},
+}
+
+// getWhereaboutsConfig reads the optional whereabouts tuning from the
+// WHEREABOUTS_CONFIGMAP: the plugin log level, whether the stale IP
+// reconciler runs at all, and the cron expression it runs on.
+func getWhereaboutsConfig(kubeClient client.Client) (bootstrap.WhereaboutsConfig, error) {
+	wc := bootstrap.WhereaboutsConfig{}
+	cm := &corev1.ConfigMap{}
+	nsn := types.NamespacedName{Namespace: names.APPLIED_NAMESPACE, Name: names.WHEREABOUTS_CONFIGMAP}
+	if err := kubeClient.Get(context.TODO(), nsn, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return wc, nil
+		}
+		return wc, err
+	}
+
+	if raw, ok := cm.Data["log-level"]; ok && raw != "" {
+		if _, known := whereaboutsLogLevels[raw]; !known {
+			return wc, fmt.Errorf("invalid log-level %q in ConfigMap %s", raw, names.WHEREABOUTS_CONFIGMAP)
+		}
+		wc.LogLevel = raw
+	}
+
+	if raw, ok := cm.Data["reconciler-enabled"]; ok && raw != "" {
+		if raw != "true" && raw != "false" {
+			return wc, fmt.Errorf("invalid reconciler-enabled value %q in ConfigMap %s: must be \"true\" or \"false\"", raw, names.WHEREABOUTS_CONFIGMAP)
+		}
+		wc.ReconcilerDisabled = raw == "false"
+	}
+
+	if raw, ok := cm.Data["reconciler-schedule"]; ok && raw != "" {
+		if len(strings.Fields(raw)) != 5 {
+			return wc, fmt.Errorf("invalid reconciler-schedule %q in ConfigMap %s: must be a five-field cron expression", raw, names.WHEREABOUTS_CONFIGMAP)
+		}
+		wc.ReconcilerSchedule = raw
+	}
+	return wc, nil
+}
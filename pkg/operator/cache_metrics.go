@@ -0,0 +1,89 @@
+package operator
+
+// Cache observability. The controller-runtime cache holds full copies of
+// every watched object, which dominates the operator's memory on large
+// clusters; this exports how many objects each of the high-cardinality
+// informers is holding, so cache growth shows up in metrics before it shows
+// up as OOM kills. (Process-level heap usage is already exported by the
+// standard Go collector.)
+//
+// Trimming the cached objects themselves (stripping managedFields and
+// unused metadata) is not possible with the vendored controller-runtime
+// (v0.9.0-beta.0): informer transform functions only appeared in
+// controller-runtime v0.12 / client-go v0.23. SelectorsByObject does exist,
+// but applies per type to the one shared cache that also serves arbitrary
+// client Gets, so scoping any of the types below would silently turn those
+// Gets into not-found errors.
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// cacheSamplePeriod is how often cached object counts are refreshed.
+const cacheSamplePeriod = time.Minute
+
+// cacheObjectCount reports the number of objects held in the informer cache
+// per kind.
+var cacheObjectCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_cache_object_count",
+	Help: "Number of objects held in the operator's informer cache, per kind.",
+}, []string{"kind"})
+
+func init() {
+	prometheus.MustRegister(cacheObjectCount)
+}
+
+// countedCaches are the kinds worth tracking. GetInformer starts an
+// informer if none exists yet, so only kinds that the controllers watch
+// through the manager (source.Kind in their add() functions) may be listed
+// here; sampling anything else would itself pin a cluster-wide cache.
+var countedCaches = map[string]client.Object{
+	"Node":       &corev1.Node{},
+	"ConfigMap":  &corev1.ConfigMap{},
+	"DaemonSet":  &appsv1.DaemonSet{},
+	"Deployment": &appsv1.Deployment{},
+}
+
+// startCacheMetrics registers a runnable that periodically samples the
+// informer cache sizes.
+func startCacheMetrics(mgr manager.Manager) error {
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		ticker := time.NewTicker(cacheSamplePeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				sampleCacheSizes(ctx, mgr)
+			}
+		}
+	}))
+}
+
+func sampleCacheSizes(ctx context.Context, mgr manager.Manager) {
+	for kind, obj := range countedCaches {
+		informer, err := mgr.GetCache().GetInformer(ctx, obj)
+		if err != nil {
+			klog.V(4).Infof("Unable to get %s informer for cache metrics: %v", kind, err)
+			continue
+		}
+		// The concrete informer is a client-go SharedIndexInformer; its
+		// store exposes the keys without copying the objects.
+		si, ok := informer.(toolscache.SharedIndexInformer)
+		if !ok {
+			continue
+		}
+		cacheObjectCount.WithLabelValues(kind).Set(float64(len(si.GetStore().ListKeys())))
+	}
+}
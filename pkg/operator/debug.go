@@ -0,0 +1,44 @@
+package operator
+
+// Runtime debug endpoints for the operator itself. The library-go server
+// already installs the standard net/http/pprof handlers under /debug/pprof,
+// behind the same TLS and delegated authentication as /metrics; this adds a
+// pair of convenience dump endpoints so support can grab goroutine stacks or
+// a heap profile with a single authenticated GET.
+
+import (
+	"net/http"
+	"runtime/pprof"
+
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/klog/v2"
+)
+
+// registerDebugHandlers installs the dump endpoints on the operator's
+// serving mux. A nil server means the operator runs without serving config
+// (e.g. during render); that is not an error.
+func registerDebugHandlers(server *genericapiserver.GenericAPIServer) {
+	if server == nil {
+		klog.Info("No server configured, debug endpoints disabled")
+		return
+	}
+
+	mux := server.Handler.NonGoRestfulMux
+
+	// Full stacks of every goroutine, the first thing to look at when the
+	// operator appears stuck.
+	mux.UnlistedHandleFunc("/debug/dump/goroutine", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := pprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+			klog.Errorf("Failed to write goroutine dump: %v", err)
+		}
+	})
+
+	// Heap profile in pprof format, suitable for `go tool pprof`.
+	mux.UnlistedHandleFunc("/debug/dump/heap", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if err := pprof.Lookup("heap").WriteTo(w, 0); err != nil {
+			klog.Errorf("Failed to write heap profile: %v", err)
+		}
+	})
+}
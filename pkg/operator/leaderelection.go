@@ -0,0 +1,162 @@
+package operator
+
+// Leader election with graceful handoff. The library-go election never
+// releases its lock, so after a rolling restart the replacement pod waits
+// out most of the old lease before it can reconcile. The operator therefore
+// runs its own election: the lease is released when the context is cancelled
+// (i.e. on SIGTERM), the timings are shorter and overridable via environment
+// variables, and the current leader identity is published to a ConfigMap.
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/openshift/cluster-network-operator/pkg/names"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultLeaseDuration = 60 * time.Second
+	defaultRenewDeadline = 35 * time.Second
+	defaultRetryPeriod   = 10 * time.Second
+)
+
+// runWithLeaderElection runs the given function once this replica has
+// acquired the leader lease, and releases the lease when ctx is cancelled.
+func runWithLeaderElection(ctx context.Context, cfg *rest.Config, run func(context.Context) error) error {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	// The suffix distinguishes restarts of the same pod in the lock history.
+	identity = identity + "_" + string(uuid.NewUUID())
+
+	lock, err := resourcelock.New(
+		resourcelock.ConfigMapsLeasesResourceLock,
+		names.APPLIED_NAMESPACE, LOCK_NAME,
+		clientset.CoreV1(), clientset.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: identity})
+	if err != nil {
+		return err
+	}
+
+	leaseDuration, renewDeadline, retryPeriod := leaderElectionTimings()
+
+	// The election must also stop when run returns on its own (e.g. a
+	// setup failure); otherwise this pod would keep renewing the lease
+	// while doing no work.
+	electionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	started := make(chan struct{})
+	runResult := make(chan error, 1)
+	leaderelection.RunOrDie(electionCtx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		Name:            LOCK_NAME,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				klog.Infof("Acquired leader lease as %s", identity)
+				close(started)
+				runResult <- run(ctx)
+				cancel()
+			},
+			OnStoppedLeading: func() {
+				if electionCtx.Err() != nil {
+					// Normal shutdown (or run returned); ReleaseOnCancel
+					// has handed the lease to the next replica.
+					klog.Info("Released leader lease on shutdown")
+					return
+				}
+				klog.Fatal("Leader lease lost, exiting")
+			},
+			OnNewLeader: func(leader string) {
+				go publishLeaderStatus(clientset, leader)
+			},
+		},
+	})
+	// RunOrDie has released the lease. If leadership was ever acquired,
+	// wait for run to finish and report its result.
+	select {
+	case <-started:
+		return <-runResult
+	default:
+		return nil
+	}
+}
+
+// leaderElectionTimings returns the lease timings, each overridable with an
+// environment variable (LEADER_ELECTION_LEASE_DURATION, _RENEW_DEADLINE,
+// _RETRY_PERIOD). An inconsistent combination falls back to the defaults.
+func leaderElectionTimings() (leaseDuration, renewDeadline, retryPeriod time.Duration) {
+	leaseDuration = durationFromEnv("LEADER_ELECTION_LEASE_DURATION", defaultLeaseDuration)
+	renewDeadline = durationFromEnv("LEADER_ELECTION_RENEW_DEADLINE", defaultRenewDeadline)
+	retryPeriod = durationFromEnv("LEADER_ELECTION_RETRY_PERIOD", defaultRetryPeriod)
+	if renewDeadline >= leaseDuration || retryPeriod >= renewDeadline {
+		klog.Warningf("Inconsistent leader election timings (lease %s, renew %s, retry %s), using defaults", leaseDuration, renewDeadline, retryPeriod)
+		return defaultLeaseDuration, defaultRenewDeadline, defaultRetryPeriod
+	}
+	return leaseDuration, renewDeadline, retryPeriod
+}
+
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil || value <= 0 {
+		klog.Warningf("Ignoring invalid %s %q, using %s", name, raw, fallback)
+		return fallback
+	}
+	return value
+}
+
+// publishLeaderStatus records the current leader in the
+// LEADER_STATUS_CONFIGMAP, so who is leading is visible without decoding
+// the lock annotations.
+func publishLeaderStatus(clientset kubernetes.Interface, leader string) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: names.APPLIED_NAMESPACE,
+			Name:      names.LEADER_STATUS_CONFIGMAP,
+		},
+		Data: map[string]string{
+			"leader": leader,
+			"since":  time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	client := clientset.CoreV1().ConfigMaps(cm.Namespace)
+	existing, err := client.Get(context.TODO(), cm.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(context.TODO(), cm, metav1.CreateOptions{})
+	} else if err == nil {
+		if existing.Data["leader"] == leader {
+			return
+		}
+		existing.Data = cm.Data
+		_, err = client.Update(context.TODO(), existing, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		klog.Errorf("Failed to publish leader status: %v", err)
+	}
+}
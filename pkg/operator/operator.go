@@ -3,15 +3,20 @@ package operator
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/openshift/cluster-network-operator/pkg/admission"
 	"github.com/openshift/cluster-network-operator/pkg/client"
 	"github.com/openshift/cluster-network-operator/pkg/controller"
 	"github.com/openshift/cluster-network-operator/pkg/controller/connectivitycheck"
 	"github.com/openshift/cluster-network-operator/pkg/controller/statusmanager"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/library-go/pkg/controller/controllercmd"
 	"github.com/openshift/library-go/pkg/operator/loglevel"
@@ -36,7 +41,16 @@ type Operator struct {
 
 const LOCK_NAME = "cluster-network-operator"
 
+// RunOperator acquires the leader lease and then runs the operator proper.
+// Leader election is handled here rather than by controllercmd so the lease
+// is released on shutdown; see leaderelection.go.
 func RunOperator(ctx context.Context, controllerConfig *controllercmd.ControllerContext) error {
+	return runWithLeaderElection(ctx, controllerConfig.KubeConfig, func(ctx context.Context) error {
+		return runOperator(ctx, controllerConfig)
+	})
+}
+
+func runOperator(ctx context.Context, controllerConfig *controllercmd.ControllerContext) error {
 	o := &Operator{
 		ccfg: controllerConfig,
 	}
@@ -54,6 +68,10 @@ func RunOperator(ctx context.Context, controllerConfig *controllercmd.Controller
 			return o.client.RESTMapper(), nil
 		},
 		MetricsBindAddress: "0",
+		// Secrets are only read occasionally (signing CAs, cloud
+		// credentials); reading one through the cache would start a
+		// cluster-wide Secret informer and pin every Secret in memory.
+		ClientDisableCacheFor: []crclient.Object{&corev1.Secret{}},
 	})
 	if err != nil {
 		return err
@@ -61,12 +79,21 @@ func RunOperator(ctx context.Context, controllerConfig *controllercmd.Controller
 
 	o.StatusManager = statusmanager.New(o.manager.GetClient(), o.client.RESTMapper(), "network")
 
+	// Serve goroutine/heap dumps next to the pprof endpoints, behind the
+	// same TLS and authentication as /metrics.
+	registerDebugHandlers(controllerConfig.Server)
+
 	// Add controller-runtime controllers
 	klog.Info("Adding controller-runtime controllers")
 	if err := controller.AddToManager(o.manager, o.StatusManager); err != nil {
 		return fmt.Errorf("Failed to add controllers to manager: %w", err)
 	}
 
+	// Export informer cache sizes.
+	if err := startCacheMetrics(o.manager); err != nil {
+		return fmt.Errorf("Failed to start cache metrics: %w", err)
+	}
+
 	// Initialize individual (non-controller-runtime) controllers
 
 	// logLevelController reacts to changes in the operator spec loglevel
@@ -95,10 +122,41 @@ func RunOperator(ctx context.Context, controllerConfig *controllercmd.Controller
 	}()
 	go logLevelController.Run(ctx, 1)
 	go managementStateController.Run(ctx, 1)
+	// Run any registered third-party operand health probes.
+	go o.StatusManager.RunHealthProbes(ctx)
 	if err := connectivitycheck.Start(ctx, o.ccfg.KubeConfig); err != nil {
 		klog.Errorf("Failed to start connectivitycheck controller: %v", err)
 	}
 
+	// Serve the network config admission webhook if serving certificates were
+	// provided, so unsafe config changes are rejected at write time.
+	if certFile := os.Getenv("NETWORK_CONFIG_WEBHOOK_CERT"); certFile != "" {
+		keyFile := os.Getenv("NETWORK_CONFIG_WEBHOOK_KEY")
+		addr := os.Getenv("NETWORK_CONFIG_WEBHOOK_ADDR")
+		if addr == "" {
+			addr = ":9743"
+		}
+		go func() {
+			// The serving certificate is provisioned asynchronously by the
+			// service CA operator; until it appears the webhook registration
+			// fails open.
+			for {
+				if _, err := os.Stat(certFile); err == nil {
+					break
+				}
+				klog.V(2).Infof("Waiting for webhook serving certificate %s", certFile)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(30 * time.Second):
+				}
+			}
+			if err := admission.NewServer(addr, certFile, keyFile).Run(ctx); err != nil {
+				klog.Errorf("Network config admission webhook failed: %v", err)
+			}
+		}()
+	}
+
 	<-ctx.Done()
 
 	return nil
@@ -369,6 +369,13 @@ func BootstrapKuryr(conf *operv1.NetworkSpec, kubeClient client.Client) (*bootst
 		return nil, errors.Wrap(err, "failed to create Neutron client")
 	}
 
+	// Fail early, with an actionable message, if the cloud cannot support
+	// Kuryr, instead of failing later deep in resource creation.
+	log.Print("Running OpenStack preflight checks")
+	if err := preflightChecks(client, cloud.AuthInfo.ProjectID); err != nil {
+		return nil, err
+	}
+
 	tag := "openshiftClusterID=" + clusterID
 	log.Printf("Using %s as resources tag", tag)
 
@@ -0,0 +1,102 @@
+package openstack
+
+// Preflight checks run at the start of the Kuryr bootstrap. A cloud missing
+// a required Neutron extension or out of security group quota otherwise only
+// fails much later, deep in resource creation, with a message that is hard
+// to act on.
+
+import (
+	"log"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/pkg/errors"
+)
+
+// requiredNetworkExtensions are the Neutron extensions Kuryr depends on:
+// security groups for pod isolation, subnet pools for the pod subnets and
+// trunk ports for attaching pod ports to the VM ports.
+var requiredNetworkExtensions = []string{"security-group", "subnet_allocation", "trunk"}
+
+// sgQuotaHeadroom is the minimum number of unused security groups the
+// project quota must still allow; the bootstrap and Kuryr itself create
+// several.
+const sgQuotaHeadroom = 10
+
+// preflightChecks verifies that the cloud can support Kuryr before any
+// resources are created. All problems are reported together.
+func preflightChecks(client *gophercloud.ServiceClient, projectID string) error {
+	problems := []string{}
+
+	aliases, err := listNetworkExtensions(client)
+	if err != nil {
+		return errors.Wrap(err, "failed to list Neutron extensions")
+	}
+	available := map[string]bool{}
+	for _, alias := range aliases {
+		available[alias] = true
+	}
+	for _, required := range requiredNetworkExtensions {
+		if !available[required] {
+			problems = append(problems, "Neutron extension "+required+" is not available")
+		}
+	}
+
+	// The quota details call needs the quotas extension and a project ID;
+	// treat failures here as non-fatal, missing quota will still fail the
+	// bootstrap later.
+	if projectID != "" {
+		headroom, unlimited, err := securityGroupQuotaHeadroom(client, projectID)
+		if err != nil {
+			log.Printf("Could not check security group quota (continuing): %v", err)
+		} else if !unlimited && headroom < sgQuotaHeadroom {
+			problems = append(problems, errors.Errorf(
+				"security group quota has only %d groups left, at least %d are needed", headroom, sgQuotaHeadroom).Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.Errorf("OpenStack preflight checks failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// listNetworkExtensions returns the aliases of the extensions the Neutron
+// endpoint advertises.
+func listNetworkExtensions(client *gophercloud.ServiceClient) ([]string, error) {
+	var result struct {
+		Extensions []struct {
+			Alias string `json:"alias"`
+		} `json:"extensions"`
+	}
+	_, err := client.Get(client.ServiceURL("extensions"), &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	aliases := make([]string, 0, len(result.Extensions))
+	for _, ext := range result.Extensions {
+		aliases = append(aliases, ext.Alias)
+	}
+	return aliases, nil
+}
+
+// securityGroupQuotaHeadroom returns how many more security groups the
+// project may create, or that the quota is unlimited.
+func securityGroupQuotaHeadroom(client *gophercloud.ServiceClient, projectID string) (int, bool, error) {
+	var result struct {
+		Quota struct {
+			SecurityGroup struct {
+				Limit int `json:"limit"`
+				Used  int `json:"used"`
+			} `json:"security_group"`
+		} `json:"quota"`
+	}
+	_, err := client.Get(client.ServiceURL("quotas", projectID, "details.json"), &result, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	if result.Quota.SecurityGroup.Limit < 0 {
+		return 0, true, nil
+	}
+	return result.Quota.SecurityGroup.Limit - result.Quota.SecurityGroup.Used, false, nil
+}
@@ -0,0 +1,34 @@
+package openstack
+
+// provider plugs the OpenStack/Kuryr bootstrap into the generic platform
+// provider registry.
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+	"github.com/openshift/cluster-network-operator/pkg/platform"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type provider struct{}
+
+func init() {
+	platform.Register(&provider{})
+}
+
+func (p *provider) Name() configv1.PlatformType {
+	return configv1.OpenStackPlatformType
+}
+
+func (p *provider) Validate(conf *operv1.NetworkSpec) []error {
+	// The Kuryr deployment configuration is validated together with the
+	// rest of the network configuration in pkg/network; OpenStack imposes
+	// no additional constraints of its own.
+	return nil
+}
+
+func (p *provider) Bootstrap(conf *operv1.NetworkSpec, client crclient.Client) (*bootstrap.BootstrapResult, error) {
+	return BootstrapKuryr(conf, client)
+}
@@ -0,0 +1,52 @@
+package platform
+
+// platform holds the registry of per-platform bootstrap providers. Adding
+// support for a new cloud means implementing BootstrapProvider in its own
+// package under pkg/platform and registering it from an init function; the
+// rest of the operator stays platform-agnostic and providers can be unit
+// tested in isolation.
+
+import (
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/cluster-network-operator/pkg/bootstrap"
+
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BootstrapProvider is implemented by each platform that needs
+// platform-specific validation or cloud resource bootstrapping before the
+// network manifests are rendered.
+type BootstrapProvider interface {
+	// Name returns the platform type this provider handles.
+	Name() configv1.PlatformType
+
+	// Validate checks the parts of the network configuration that only
+	// this platform can judge. All errors are returned, not just the
+	// first.
+	Validate(conf *operv1.NetworkSpec) []error
+
+	// Bootstrap creates the cloud resources the network needs and returns
+	// the bootstrap data that rendering depends on.
+	Bootstrap(conf *operv1.NetworkSpec, client crclient.Client) (*bootstrap.BootstrapResult, error)
+}
+
+var providers = map[configv1.PlatformType]BootstrapProvider{}
+
+// Register adds a provider to the registry. It is meant to be called from
+// the provider package's init function and panics on a duplicate
+// registration, which is a programming error.
+func Register(p BootstrapProvider) {
+	if _, ok := providers[p.Name()]; ok {
+		panic(fmt.Sprintf("platform provider %s registered twice", p.Name()))
+	}
+	providers[p.Name()] = p
+}
+
+// ProviderFor returns the provider registered for the given platform, or nil
+// if the platform needs no platform-specific bootstrapping.
+func ProviderFor(name configv1.PlatformType) BootstrapProvider {
+	return providers[name]
+}
@@ -0,0 +1,104 @@
+package ipsec
+
+// Package ipsec holds the IPsec dataplane metrics. The ovn-ipsec pods
+// aggregate their tunnel state into a ConfigMap; the operator parses it and
+// exposes per-node gauges so dataplane encryption failures are alertable.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TunnelStatus is the tunnel state one node reports.
+type TunnelStatus struct {
+	Up          int
+	Down        int
+	FailedSANeg int
+}
+
+// ipsecMode reports the configured IPsec mode (0 disabled, 1 full, 2
+// external).
+var ipsecMode = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "cno_ipsec_mode",
+	Help: "Configured IPsec mode: 0 disabled, 1 full, 2 external.",
+})
+
+// tunnelsUp reports established IPsec tunnels per node.
+var tunnelsUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_ipsec_tunnels_up",
+	Help: "Number of established IPsec tunnels per node.",
+}, []string{"node"})
+
+// tunnelsDown reports expected but unestablished IPsec tunnels per node.
+var tunnelsDown = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_ipsec_tunnels_down",
+	Help: "Number of expected but unestablished IPsec tunnels per node.",
+}, []string{"node"})
+
+// saNegotiationFailures reports failed SA negotiations per node.
+var saNegotiationFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "cno_ipsec_sa_negotiation_failures",
+	Help: "Number of failed IPsec SA negotiations per node.",
+}, []string{"node"})
+
+func init() {
+	prometheus.MustRegister(ipsecMode)
+	prometheus.MustRegister(tunnelsUp)
+	prometheus.MustRegister(tunnelsDown)
+	prometheus.MustRegister(saNegotiationFailures)
+}
+
+// SetMode records the configured IPsec mode.
+func SetMode(mode string) {
+	switch mode {
+	case "full":
+		ipsecMode.Set(1)
+	case "external":
+		ipsecMode.Set(2)
+	default:
+		ipsecMode.Set(0)
+	}
+}
+
+// SetTunnelStatus publishes the per-node tunnel gauges. Nodes absent from the
+// map keep their previous values until ResetTunnelStatus is called.
+func SetTunnelStatus(statuses map[string]TunnelStatus) {
+	tunnelsUp.Reset()
+	tunnelsDown.Reset()
+	saNegotiationFailures.Reset()
+	for node, status := range statuses {
+		tunnelsUp.WithLabelValues(node).Set(float64(status.Up))
+		tunnelsDown.WithLabelValues(node).Set(float64(status.Down))
+		saNegotiationFailures.WithLabelValues(node).Set(float64(status.FailedSANeg))
+	}
+}
+
+// ParseNodeStatus parses one node's aggregation entry, of the form
+// "up=<n>,down=<n>,failed=<n>".
+func ParseNodeStatus(raw string) (TunnelStatus, error) {
+	status := TunnelStatus{}
+	for _, field := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(parts) != 2 {
+			return status, fmt.Errorf("malformed field %q", field)
+		}
+		value, err := strconv.Atoi(parts[1])
+		if err != nil || value < 0 {
+			return status, fmt.Errorf("invalid value in field %q", field)
+		}
+		switch parts[0] {
+		case "up":
+			status.Up = value
+		case "down":
+			status.Down = value
+		case "failed":
+			status.FailedSANeg = value
+		default:
+			return status, fmt.Errorf("unknown field %q", parts[0])
+		}
+	}
+	return status, nil
+}